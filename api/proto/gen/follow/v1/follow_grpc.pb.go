@@ -25,6 +25,13 @@ const (
 	FollowService_FollowInfo_FullMethodName      = "/follow.v1.FollowService/FollowInfo"
 	FollowService_GetFollower_FullMethodName     = "/follow.v1.FollowService/GetFollower"
 	FollowService_GetFollowStatic_FullMethodName = "/follow.v1.FollowService/GetFollowStatic"
+	FollowService_Block_FullMethodName           = "/follow.v1.FollowService/Block"
+	FollowService_CancelBlock_FullMethodName     = "/follow.v1.FollowService/CancelBlock"
+	FollowService_Mute_FullMethodName            = "/follow.v1.FollowService/Mute"
+	FollowService_CancelMute_FullMethodName      = "/follow.v1.FollowService/CancelMute"
+	FollowService_GetBlockList_FullMethodName    = "/follow.v1.FollowService/GetBlockList"
+	FollowService_GetBlockers_FullMethodName     = "/follow.v1.FollowService/GetBlockers"
+	FollowService_GetHiddenUsers_FullMethodName  = "/follow.v1.FollowService/GetHiddenUsers"
 )
 
 // FollowServiceClient is the client API for FollowService service.
@@ -42,6 +49,18 @@ type FollowServiceClient interface {
 	GetFollower(ctx context.Context, in *GetFollowerRequest, opts ...grpc.CallOption) (*GetFollowerResponse, error)
 	// 获取默认的关注人数
 	GetFollowStatic(ctx context.Context, in *GetFollowStaticRequest, opts ...grpc.CallOption) (*GetFollowStaticResponse, error)
+	// 拉黑，同时取消双方的关注
+	Block(ctx context.Context, in *BlockRequest, opts ...grpc.CallOption) (*BlockResponse, error)
+	CancelBlock(ctx context.Context, in *CancelBlockRequest, opts ...grpc.CallOption) (*CancelBlockResponse, error)
+	// 屏蔽，只影响自己的 feed
+	Mute(ctx context.Context, in *MuteRequest, opts ...grpc.CallOption) (*MuteResponse, error)
+	CancelMute(ctx context.Context, in *CancelMuteRequest, opts ...grpc.CallOption) (*CancelMuteResponse, error)
+	// 获取某人的黑名单或者屏蔽列表
+	GetBlockList(ctx context.Context, in *GetBlockListRequest, opts ...grpc.CallOption) (*GetBlockListResponse, error)
+	// candidates 里面拉黑了 uid 的人，评论服务之类的用来判断能不能评论、@
+	GetBlockers(ctx context.Context, in *GetBlockersRequest, opts ...grpc.CallOption) (*GetBlockersResponse, error)
+	// uid 不应该看到哪些人的内容，feed 用
+	GetHiddenUsers(ctx context.Context, in *GetHiddenUsersRequest, opts ...grpc.CallOption) (*GetHiddenUsersResponse, error)
 }
 
 type followServiceClient struct {
@@ -106,6 +125,69 @@ func (c *followServiceClient) GetFollowStatic(ctx context.Context, in *GetFollow
 	return out, nil
 }
 
+func (c *followServiceClient) Block(ctx context.Context, in *BlockRequest, opts ...grpc.CallOption) (*BlockResponse, error) {
+	out := new(BlockResponse)
+	err := c.cc.Invoke(ctx, FollowService_Block_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *followServiceClient) CancelBlock(ctx context.Context, in *CancelBlockRequest, opts ...grpc.CallOption) (*CancelBlockResponse, error) {
+	out := new(CancelBlockResponse)
+	err := c.cc.Invoke(ctx, FollowService_CancelBlock_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *followServiceClient) Mute(ctx context.Context, in *MuteRequest, opts ...grpc.CallOption) (*MuteResponse, error) {
+	out := new(MuteResponse)
+	err := c.cc.Invoke(ctx, FollowService_Mute_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *followServiceClient) CancelMute(ctx context.Context, in *CancelMuteRequest, opts ...grpc.CallOption) (*CancelMuteResponse, error) {
+	out := new(CancelMuteResponse)
+	err := c.cc.Invoke(ctx, FollowService_CancelMute_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *followServiceClient) GetBlockList(ctx context.Context, in *GetBlockListRequest, opts ...grpc.CallOption) (*GetBlockListResponse, error) {
+	out := new(GetBlockListResponse)
+	err := c.cc.Invoke(ctx, FollowService_GetBlockList_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *followServiceClient) GetBlockers(ctx context.Context, in *GetBlockersRequest, opts ...grpc.CallOption) (*GetBlockersResponse, error) {
+	out := new(GetBlockersResponse)
+	err := c.cc.Invoke(ctx, FollowService_GetBlockers_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *followServiceClient) GetHiddenUsers(ctx context.Context, in *GetHiddenUsersRequest, opts ...grpc.CallOption) (*GetHiddenUsersResponse, error) {
+	out := new(GetHiddenUsersResponse)
+	err := c.cc.Invoke(ctx, FollowService_GetHiddenUsers_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // FollowServiceServer is the server API for FollowService service.
 // All implementations must embed UnimplementedFollowServiceServer
 // for forward compatibility
@@ -121,6 +203,18 @@ type FollowServiceServer interface {
 	GetFollower(context.Context, *GetFollowerRequest) (*GetFollowerResponse, error)
 	// 获取默认的关注人数
 	GetFollowStatic(context.Context, *GetFollowStaticRequest) (*GetFollowStaticResponse, error)
+	// 拉黑，同时取消双方的关注
+	Block(context.Context, *BlockRequest) (*BlockResponse, error)
+	CancelBlock(context.Context, *CancelBlockRequest) (*CancelBlockResponse, error)
+	// 屏蔽，只影响自己的 feed
+	Mute(context.Context, *MuteRequest) (*MuteResponse, error)
+	CancelMute(context.Context, *CancelMuteRequest) (*CancelMuteResponse, error)
+	// 获取某人的黑名单或者屏蔽列表
+	GetBlockList(context.Context, *GetBlockListRequest) (*GetBlockListResponse, error)
+	// candidates 里面拉黑了 uid 的人，评论服务之类的用来判断能不能评论、@
+	GetBlockers(context.Context, *GetBlockersRequest) (*GetBlockersResponse, error)
+	// uid 不应该看到哪些人的内容，feed 用
+	GetHiddenUsers(context.Context, *GetHiddenUsersRequest) (*GetHiddenUsersResponse, error)
 	mustEmbedUnimplementedFollowServiceServer()
 }
 
@@ -146,6 +240,27 @@ func (UnimplementedFollowServiceServer) GetFollower(context.Context, *GetFollowe
 func (UnimplementedFollowServiceServer) GetFollowStatic(context.Context, *GetFollowStaticRequest) (*GetFollowStaticResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetFollowStatic not implemented")
 }
+func (UnimplementedFollowServiceServer) Block(context.Context, *BlockRequest) (*BlockResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Block not implemented")
+}
+func (UnimplementedFollowServiceServer) CancelBlock(context.Context, *CancelBlockRequest) (*CancelBlockResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelBlock not implemented")
+}
+func (UnimplementedFollowServiceServer) Mute(context.Context, *MuteRequest) (*MuteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Mute not implemented")
+}
+func (UnimplementedFollowServiceServer) CancelMute(context.Context, *CancelMuteRequest) (*CancelMuteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelMute not implemented")
+}
+func (UnimplementedFollowServiceServer) GetBlockList(context.Context, *GetBlockListRequest) (*GetBlockListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlockList not implemented")
+}
+func (UnimplementedFollowServiceServer) GetBlockers(context.Context, *GetBlockersRequest) (*GetBlockersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlockers not implemented")
+}
+func (UnimplementedFollowServiceServer) GetHiddenUsers(context.Context, *GetHiddenUsersRequest) (*GetHiddenUsersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetHiddenUsers not implemented")
+}
 func (UnimplementedFollowServiceServer) mustEmbedUnimplementedFollowServiceServer() {}
 
 // UnsafeFollowServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -267,6 +382,132 @@ func _FollowService_GetFollowStatic_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _FollowService_Block_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BlockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FollowServiceServer).Block(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FollowService_Block_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FollowServiceServer).Block(ctx, req.(*BlockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FollowService_CancelBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelBlockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FollowServiceServer).CancelBlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FollowService_CancelBlock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FollowServiceServer).CancelBlock(ctx, req.(*CancelBlockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FollowService_Mute_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MuteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FollowServiceServer).Mute(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FollowService_Mute_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FollowServiceServer).Mute(ctx, req.(*MuteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FollowService_CancelMute_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelMuteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FollowServiceServer).CancelMute(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FollowService_CancelMute_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FollowServiceServer).CancelMute(ctx, req.(*CancelMuteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FollowService_GetBlockList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBlockListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FollowServiceServer).GetBlockList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FollowService_GetBlockList_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FollowServiceServer).GetBlockList(ctx, req.(*GetBlockListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FollowService_GetBlockers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBlockersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FollowServiceServer).GetBlockers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FollowService_GetBlockers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FollowServiceServer).GetBlockers(ctx, req.(*GetBlockersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FollowService_GetHiddenUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetHiddenUsersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FollowServiceServer).GetHiddenUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FollowService_GetHiddenUsers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FollowServiceServer).GetHiddenUsers(ctx, req.(*GetHiddenUsersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // FollowService_ServiceDesc is the grpc.ServiceDesc for FollowService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -298,6 +539,34 @@ var FollowService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetFollowStatic",
 			Handler:    _FollowService_GetFollowStatic_Handler,
 		},
+		{
+			MethodName: "Block",
+			Handler:    _FollowService_Block_Handler,
+		},
+		{
+			MethodName: "CancelBlock",
+			Handler:    _FollowService_CancelBlock_Handler,
+		},
+		{
+			MethodName: "Mute",
+			Handler:    _FollowService_Mute_Handler,
+		},
+		{
+			MethodName: "CancelMute",
+			Handler:    _FollowService_CancelMute_Handler,
+		},
+		{
+			MethodName: "GetBlockList",
+			Handler:    _FollowService_GetBlockList_Handler,
+		},
+		{
+			MethodName: "GetBlockers",
+			Handler:    _FollowService_GetBlockers_Handler,
+		},
+		{
+			MethodName: "GetHiddenUsers",
+			Handler:    _FollowService_GetHiddenUsers_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "follow/v1/follow.proto",
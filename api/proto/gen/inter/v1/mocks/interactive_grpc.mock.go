@@ -40,6 +40,46 @@ func (m *MockInteractiveServiceClient) EXPECT() *MockInteractiveServiceClientMoc
 	return m.recorder
 }
 
+// BatchGet mocks base method.
+func (m *MockInteractiveServiceClient) BatchGet(ctx context.Context, in *interv1.BatchGetRequest, opts ...grpc.CallOption) (*interv1.BatchGetResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "BatchGet", varargs...)
+	ret0, _ := ret[0].(*interv1.BatchGetResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchGet indicates an expected call of BatchGet.
+func (mr *MockInteractiveServiceClientMockRecorder) BatchGet(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchGet", reflect.TypeOf((*MockInteractiveServiceClient)(nil).BatchGet), varargs...)
+}
+
+// CancelCollect mocks base method.
+func (m *MockInteractiveServiceClient) CancelCollect(ctx context.Context, in *interv1.CancelCollectRequest, opts ...grpc.CallOption) (*interv1.CancelCollectResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CancelCollect", varargs...)
+	ret0, _ := ret[0].(*interv1.CancelCollectResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelCollect indicates an expected call of CancelCollect.
+func (mr *MockInteractiveServiceClientMockRecorder) CancelCollect(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelCollect", reflect.TypeOf((*MockInteractiveServiceClient)(nil).CancelCollect), varargs...)
+}
+
 // CancelLike mocks base method.
 func (m *MockInteractiveServiceClient) CancelLike(ctx context.Context, in *interv1.CancelLikeRequest, opts ...grpc.CallOption) (*interv1.CancelLikeResponse, error) {
 	m.ctrl.T.Helper()
@@ -60,6 +100,26 @@ func (mr *MockInteractiveServiceClientMockRecorder) CancelLike(ctx, in any, opts
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelLike", reflect.TypeOf((*MockInteractiveServiceClient)(nil).CancelLike), varargs...)
 }
 
+// ChangeReaction mocks base method.
+func (m *MockInteractiveServiceClient) ChangeReaction(ctx context.Context, in *interv1.ChangeReactionRequest, opts ...grpc.CallOption) (*interv1.ChangeReactionResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ChangeReaction", varargs...)
+	ret0, _ := ret[0].(*interv1.ChangeReactionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangeReaction indicates an expected call of ChangeReaction.
+func (mr *MockInteractiveServiceClientMockRecorder) ChangeReaction(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeReaction", reflect.TypeOf((*MockInteractiveServiceClient)(nil).ChangeReaction), varargs...)
+}
+
 // Collect mocks base method.
 func (m *MockInteractiveServiceClient) Collect(ctx context.Context, in *interv1.CollectRequest, opts ...grpc.CallOption) (*interv1.CollectResponse, error) {
 	m.ctrl.T.Helper()
@@ -80,6 +140,46 @@ func (mr *MockInteractiveServiceClientMockRecorder) Collect(ctx, in any, opts ..
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Collect", reflect.TypeOf((*MockInteractiveServiceClient)(nil).Collect), varargs...)
 }
 
+// CreateCollection mocks base method.
+func (m *MockInteractiveServiceClient) CreateCollection(ctx context.Context, in *interv1.CreateCollectionRequest, opts ...grpc.CallOption) (*interv1.CreateCollectionResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateCollection", varargs...)
+	ret0, _ := ret[0].(*interv1.CreateCollectionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCollection indicates an expected call of CreateCollection.
+func (mr *MockInteractiveServiceClientMockRecorder) CreateCollection(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCollection", reflect.TypeOf((*MockInteractiveServiceClient)(nil).CreateCollection), varargs...)
+}
+
+// DeleteCollection mocks base method.
+func (m *MockInteractiveServiceClient) DeleteCollection(ctx context.Context, in *interv1.DeleteCollectionRequest, opts ...grpc.CallOption) (*interv1.DeleteCollectionResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteCollection", varargs...)
+	ret0, _ := ret[0].(*interv1.DeleteCollectionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteCollection indicates an expected call of DeleteCollection.
+func (mr *MockInteractiveServiceClientMockRecorder) DeleteCollection(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCollection", reflect.TypeOf((*MockInteractiveServiceClient)(nil).DeleteCollection), varargs...)
+}
+
 // Get mocks base method.
 func (m *MockInteractiveServiceClient) Get(ctx context.Context, in *interv1.GetRequest, opts ...grpc.CallOption) (*interv1.GetResponse, error) {
 	m.ctrl.T.Helper()
@@ -120,6 +220,66 @@ func (mr *MockInteractiveServiceClientMockRecorder) GetByIds(ctx, in any, opts .
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIds", reflect.TypeOf((*MockInteractiveServiceClient)(nil).GetByIds), varargs...)
 }
 
+// GetDailyStats mocks base method.
+func (m *MockInteractiveServiceClient) GetDailyStats(ctx context.Context, in *interv1.GetDailyStatsRequest, opts ...grpc.CallOption) (*interv1.GetDailyStatsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetDailyStats", varargs...)
+	ret0, _ := ret[0].(*interv1.GetDailyStatsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDailyStats indicates an expected call of GetDailyStats.
+func (mr *MockInteractiveServiceClientMockRecorder) GetDailyStats(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDailyStats", reflect.TypeOf((*MockInteractiveServiceClient)(nil).GetDailyStats), varargs...)
+}
+
+// GetLikedItems mocks base method.
+func (m *MockInteractiveServiceClient) GetLikedItems(ctx context.Context, in *interv1.GetLikedItemsRequest, opts ...grpc.CallOption) (*interv1.GetLikedItemsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetLikedItems", varargs...)
+	ret0, _ := ret[0].(*interv1.GetLikedItemsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLikedItems indicates an expected call of GetLikedItems.
+func (mr *MockInteractiveServiceClientMockRecorder) GetLikedItems(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLikedItems", reflect.TypeOf((*MockInteractiveServiceClient)(nil).GetLikedItems), varargs...)
+}
+
+// GetLikers mocks base method.
+func (m *MockInteractiveServiceClient) GetLikers(ctx context.Context, in *interv1.GetLikersRequest, opts ...grpc.CallOption) (*interv1.GetLikersResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetLikers", varargs...)
+	ret0, _ := ret[0].(*interv1.GetLikersResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLikers indicates an expected call of GetLikers.
+func (mr *MockInteractiveServiceClientMockRecorder) GetLikers(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLikers", reflect.TypeOf((*MockInteractiveServiceClient)(nil).GetLikers), varargs...)
+}
+
 // IncrReadCnt mocks base method.
 func (m *MockInteractiveServiceClient) IncrReadCnt(ctx context.Context, in *interv1.IncrReadCntRequest, opts ...grpc.CallOption) (*interv1.IncrReadCntResponse, error) {
 	m.ctrl.T.Helper()
@@ -160,6 +320,126 @@ func (mr *MockInteractiveServiceClientMockRecorder) Like(ctx, in any, opts ...an
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Like", reflect.TypeOf((*MockInteractiveServiceClient)(nil).Like), varargs...)
 }
 
+// ListCollectionItems mocks base method.
+func (m *MockInteractiveServiceClient) ListCollectionItems(ctx context.Context, in *interv1.ListCollectionItemsRequest, opts ...grpc.CallOption) (*interv1.ListCollectionItemsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListCollectionItems", varargs...)
+	ret0, _ := ret[0].(*interv1.ListCollectionItemsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCollectionItems indicates an expected call of ListCollectionItems.
+func (mr *MockInteractiveServiceClientMockRecorder) ListCollectionItems(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCollectionItems", reflect.TypeOf((*MockInteractiveServiceClient)(nil).ListCollectionItems), varargs...)
+}
+
+// ListCollections mocks base method.
+func (m *MockInteractiveServiceClient) ListCollections(ctx context.Context, in *interv1.ListCollectionsRequest, opts ...grpc.CallOption) (*interv1.ListCollectionsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListCollections", varargs...)
+	ret0, _ := ret[0].(*interv1.ListCollectionsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCollections indicates an expected call of ListCollections.
+func (mr *MockInteractiveServiceClientMockRecorder) ListCollections(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCollections", reflect.TypeOf((*MockInteractiveServiceClient)(nil).ListCollections), varargs...)
+}
+
+// MoveCollectionItems mocks base method.
+func (m *MockInteractiveServiceClient) MoveCollectionItems(ctx context.Context, in *interv1.MoveCollectionItemsRequest, opts ...grpc.CallOption) (*interv1.MoveCollectionItemsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "MoveCollectionItems", varargs...)
+	ret0, _ := ret[0].(*interv1.MoveCollectionItemsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoveCollectionItems indicates an expected call of MoveCollectionItems.
+func (mr *MockInteractiveServiceClientMockRecorder) MoveCollectionItems(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveCollectionItems", reflect.TypeOf((*MockInteractiveServiceClient)(nil).MoveCollectionItems), varargs...)
+}
+
+// RemoveReaction mocks base method.
+func (m *MockInteractiveServiceClient) RemoveReaction(ctx context.Context, in *interv1.RemoveReactionRequest, opts ...grpc.CallOption) (*interv1.RemoveReactionResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RemoveReaction", varargs...)
+	ret0, _ := ret[0].(*interv1.RemoveReactionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveReaction indicates an expected call of RemoveReaction.
+func (mr *MockInteractiveServiceClientMockRecorder) RemoveReaction(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveReaction", reflect.TypeOf((*MockInteractiveServiceClient)(nil).RemoveReaction), varargs...)
+}
+
+// TopN mocks base method.
+func (m *MockInteractiveServiceClient) TopN(ctx context.Context, in *interv1.TopNRequest, opts ...grpc.CallOption) (*interv1.TopNResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "TopN", varargs...)
+	ret0, _ := ret[0].(*interv1.TopNResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TopN indicates an expected call of TopN.
+func (mr *MockInteractiveServiceClientMockRecorder) TopN(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TopN", reflect.TypeOf((*MockInteractiveServiceClient)(nil).TopN), varargs...)
+}
+
+// UpdateCollection mocks base method.
+func (m *MockInteractiveServiceClient) UpdateCollection(ctx context.Context, in *interv1.UpdateCollectionRequest, opts ...grpc.CallOption) (*interv1.UpdateCollectionResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UpdateCollection", varargs...)
+	ret0, _ := ret[0].(*interv1.UpdateCollectionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCollection indicates an expected call of UpdateCollection.
+func (mr *MockInteractiveServiceClientMockRecorder) UpdateCollection(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCollection", reflect.TypeOf((*MockInteractiveServiceClient)(nil).UpdateCollection), varargs...)
+}
+
 // MockInteractiveServiceServer is a mock of InteractiveServiceServer interface.
 type MockInteractiveServiceServer struct {
 	ctrl     *gomock.Controller
@@ -183,6 +463,36 @@ func (m *MockInteractiveServiceServer) EXPECT() *MockInteractiveServiceServerMoc
 	return m.recorder
 }
 
+// BatchGet mocks base method.
+func (m *MockInteractiveServiceServer) BatchGet(arg0 context.Context, arg1 *interv1.BatchGetRequest) (*interv1.BatchGetResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchGet", arg0, arg1)
+	ret0, _ := ret[0].(*interv1.BatchGetResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchGet indicates an expected call of BatchGet.
+func (mr *MockInteractiveServiceServerMockRecorder) BatchGet(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchGet", reflect.TypeOf((*MockInteractiveServiceServer)(nil).BatchGet), arg0, arg1)
+}
+
+// CancelCollect mocks base method.
+func (m *MockInteractiveServiceServer) CancelCollect(arg0 context.Context, arg1 *interv1.CancelCollectRequest) (*interv1.CancelCollectResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelCollect", arg0, arg1)
+	ret0, _ := ret[0].(*interv1.CancelCollectResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelCollect indicates an expected call of CancelCollect.
+func (mr *MockInteractiveServiceServerMockRecorder) CancelCollect(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelCollect", reflect.TypeOf((*MockInteractiveServiceServer)(nil).CancelCollect), arg0, arg1)
+}
+
 // CancelLike mocks base method.
 func (m *MockInteractiveServiceServer) CancelLike(arg0 context.Context, arg1 *interv1.CancelLikeRequest) (*interv1.CancelLikeResponse, error) {
 	m.ctrl.T.Helper()
@@ -198,6 +508,21 @@ func (mr *MockInteractiveServiceServerMockRecorder) CancelLike(arg0, arg1 any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelLike", reflect.TypeOf((*MockInteractiveServiceServer)(nil).CancelLike), arg0, arg1)
 }
 
+// ChangeReaction mocks base method.
+func (m *MockInteractiveServiceServer) ChangeReaction(arg0 context.Context, arg1 *interv1.ChangeReactionRequest) (*interv1.ChangeReactionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeReaction", arg0, arg1)
+	ret0, _ := ret[0].(*interv1.ChangeReactionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangeReaction indicates an expected call of ChangeReaction.
+func (mr *MockInteractiveServiceServerMockRecorder) ChangeReaction(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeReaction", reflect.TypeOf((*MockInteractiveServiceServer)(nil).ChangeReaction), arg0, arg1)
+}
+
 // Collect mocks base method.
 func (m *MockInteractiveServiceServer) Collect(arg0 context.Context, arg1 *interv1.CollectRequest) (*interv1.CollectResponse, error) {
 	m.ctrl.T.Helper()
@@ -213,6 +538,36 @@ func (mr *MockInteractiveServiceServerMockRecorder) Collect(arg0, arg1 any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Collect", reflect.TypeOf((*MockInteractiveServiceServer)(nil).Collect), arg0, arg1)
 }
 
+// CreateCollection mocks base method.
+func (m *MockInteractiveServiceServer) CreateCollection(arg0 context.Context, arg1 *interv1.CreateCollectionRequest) (*interv1.CreateCollectionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCollection", arg0, arg1)
+	ret0, _ := ret[0].(*interv1.CreateCollectionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCollection indicates an expected call of CreateCollection.
+func (mr *MockInteractiveServiceServerMockRecorder) CreateCollection(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCollection", reflect.TypeOf((*MockInteractiveServiceServer)(nil).CreateCollection), arg0, arg1)
+}
+
+// DeleteCollection mocks base method.
+func (m *MockInteractiveServiceServer) DeleteCollection(arg0 context.Context, arg1 *interv1.DeleteCollectionRequest) (*interv1.DeleteCollectionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCollection", arg0, arg1)
+	ret0, _ := ret[0].(*interv1.DeleteCollectionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteCollection indicates an expected call of DeleteCollection.
+func (mr *MockInteractiveServiceServerMockRecorder) DeleteCollection(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCollection", reflect.TypeOf((*MockInteractiveServiceServer)(nil).DeleteCollection), arg0, arg1)
+}
+
 // Get mocks base method.
 func (m *MockInteractiveServiceServer) Get(arg0 context.Context, arg1 *interv1.GetRequest) (*interv1.GetResponse, error) {
 	m.ctrl.T.Helper()
@@ -243,6 +598,51 @@ func (mr *MockInteractiveServiceServerMockRecorder) GetByIds(arg0, arg1 any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIds", reflect.TypeOf((*MockInteractiveServiceServer)(nil).GetByIds), arg0, arg1)
 }
 
+// GetDailyStats mocks base method.
+func (m *MockInteractiveServiceServer) GetDailyStats(arg0 context.Context, arg1 *interv1.GetDailyStatsRequest) (*interv1.GetDailyStatsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDailyStats", arg0, arg1)
+	ret0, _ := ret[0].(*interv1.GetDailyStatsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDailyStats indicates an expected call of GetDailyStats.
+func (mr *MockInteractiveServiceServerMockRecorder) GetDailyStats(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDailyStats", reflect.TypeOf((*MockInteractiveServiceServer)(nil).GetDailyStats), arg0, arg1)
+}
+
+// GetLikedItems mocks base method.
+func (m *MockInteractiveServiceServer) GetLikedItems(arg0 context.Context, arg1 *interv1.GetLikedItemsRequest) (*interv1.GetLikedItemsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLikedItems", arg0, arg1)
+	ret0, _ := ret[0].(*interv1.GetLikedItemsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLikedItems indicates an expected call of GetLikedItems.
+func (mr *MockInteractiveServiceServerMockRecorder) GetLikedItems(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLikedItems", reflect.TypeOf((*MockInteractiveServiceServer)(nil).GetLikedItems), arg0, arg1)
+}
+
+// GetLikers mocks base method.
+func (m *MockInteractiveServiceServer) GetLikers(arg0 context.Context, arg1 *interv1.GetLikersRequest) (*interv1.GetLikersResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLikers", arg0, arg1)
+	ret0, _ := ret[0].(*interv1.GetLikersResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLikers indicates an expected call of GetLikers.
+func (mr *MockInteractiveServiceServerMockRecorder) GetLikers(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLikers", reflect.TypeOf((*MockInteractiveServiceServer)(nil).GetLikers), arg0, arg1)
+}
+
 // IncrReadCnt mocks base method.
 func (m *MockInteractiveServiceServer) IncrReadCnt(arg0 context.Context, arg1 *interv1.IncrReadCntRequest) (*interv1.IncrReadCntResponse, error) {
 	m.ctrl.T.Helper()
@@ -273,6 +673,96 @@ func (mr *MockInteractiveServiceServerMockRecorder) Like(arg0, arg1 any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Like", reflect.TypeOf((*MockInteractiveServiceServer)(nil).Like), arg0, arg1)
 }
 
+// ListCollectionItems mocks base method.
+func (m *MockInteractiveServiceServer) ListCollectionItems(arg0 context.Context, arg1 *interv1.ListCollectionItemsRequest) (*interv1.ListCollectionItemsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCollectionItems", arg0, arg1)
+	ret0, _ := ret[0].(*interv1.ListCollectionItemsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCollectionItems indicates an expected call of ListCollectionItems.
+func (mr *MockInteractiveServiceServerMockRecorder) ListCollectionItems(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCollectionItems", reflect.TypeOf((*MockInteractiveServiceServer)(nil).ListCollectionItems), arg0, arg1)
+}
+
+// ListCollections mocks base method.
+func (m *MockInteractiveServiceServer) ListCollections(arg0 context.Context, arg1 *interv1.ListCollectionsRequest) (*interv1.ListCollectionsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCollections", arg0, arg1)
+	ret0, _ := ret[0].(*interv1.ListCollectionsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCollections indicates an expected call of ListCollections.
+func (mr *MockInteractiveServiceServerMockRecorder) ListCollections(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCollections", reflect.TypeOf((*MockInteractiveServiceServer)(nil).ListCollections), arg0, arg1)
+}
+
+// MoveCollectionItems mocks base method.
+func (m *MockInteractiveServiceServer) MoveCollectionItems(arg0 context.Context, arg1 *interv1.MoveCollectionItemsRequest) (*interv1.MoveCollectionItemsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveCollectionItems", arg0, arg1)
+	ret0, _ := ret[0].(*interv1.MoveCollectionItemsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoveCollectionItems indicates an expected call of MoveCollectionItems.
+func (mr *MockInteractiveServiceServerMockRecorder) MoveCollectionItems(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveCollectionItems", reflect.TypeOf((*MockInteractiveServiceServer)(nil).MoveCollectionItems), arg0, arg1)
+}
+
+// RemoveReaction mocks base method.
+func (m *MockInteractiveServiceServer) RemoveReaction(arg0 context.Context, arg1 *interv1.RemoveReactionRequest) (*interv1.RemoveReactionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveReaction", arg0, arg1)
+	ret0, _ := ret[0].(*interv1.RemoveReactionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveReaction indicates an expected call of RemoveReaction.
+func (mr *MockInteractiveServiceServerMockRecorder) RemoveReaction(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveReaction", reflect.TypeOf((*MockInteractiveServiceServer)(nil).RemoveReaction), arg0, arg1)
+}
+
+// TopN mocks base method.
+func (m *MockInteractiveServiceServer) TopN(arg0 context.Context, arg1 *interv1.TopNRequest) (*interv1.TopNResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TopN", arg0, arg1)
+	ret0, _ := ret[0].(*interv1.TopNResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TopN indicates an expected call of TopN.
+func (mr *MockInteractiveServiceServerMockRecorder) TopN(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TopN", reflect.TypeOf((*MockInteractiveServiceServer)(nil).TopN), arg0, arg1)
+}
+
+// UpdateCollection mocks base method.
+func (m *MockInteractiveServiceServer) UpdateCollection(arg0 context.Context, arg1 *interv1.UpdateCollectionRequest) (*interv1.UpdateCollectionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCollection", arg0, arg1)
+	ret0, _ := ret[0].(*interv1.UpdateCollectionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCollection indicates an expected call of UpdateCollection.
+func (mr *MockInteractiveServiceServerMockRecorder) UpdateCollection(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCollection", reflect.TypeOf((*MockInteractiveServiceServer)(nil).UpdateCollection), arg0, arg1)
+}
+
 // mustEmbedUnimplementedInteractiveServiceServer mocks base method.
 func (m *MockInteractiveServiceServer) mustEmbedUnimplementedInteractiveServiceServer() {
 	m.ctrl.T.Helper()
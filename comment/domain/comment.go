@@ -11,17 +11,108 @@ type Comment struct {
 	BizId int64  `json:"biz_id"`
 
 	Content string `json:"content"`
+	// Edited 修改过内容
+	Edited bool `json:"edited"`
+	// Deleted 删掉了，Content 是 DeletedContent，回复还能看到
+	Deleted bool `json:"deleted"`
+	// Pinned 被资源的作者置顶了
+	Pinned bool `json:"pinned"`
+	// IsAuthor 评论的人是被评论的资源的作者
+	IsAuthor bool `json:"is_author"`
+	// Status 只有审核通过的才能被看到
+	Status CommentStatus `json:"status"`
+	// ModerationReason 要人工审核或者没有通过的原因
+	ModerationReason string `json:"moderation_reason"`
 
 	RootComment   *Comment `json:"root_comment"`
 	ParentComment *Comment `json:"parent_comment"`
 
 	Children []Comment `json:"children"`
 
+	// Mentions 内容里面 @ 到的人，找不到的不算
+	Mentions []Mention `json:"mentions"`
+
+	// LikeCnt 点赞数，点赞用的是互动服务，biz 是 BizComment
+	LikeCnt int64 `json:"like_cnt"`
+	// ReplyCnt 根评论是整个楼里面的回复数，回复是直接回复它的数量
+	ReplyCnt int64   `json:"reply_cnt"`
+	HotScore float64 `json:"hot_score"`
+
 	CTime time.Time `json:"c_time"`
 	UTime time.Time `json:"u_time"`
 }
 
+// Mention 评论里面 @ 了谁。Start 和 Length 是 "@昵称" 在内容里面的位置，按照字符算
+type Mention struct {
+	Uid      int64  `json:"uid"`
+	Nickname string `json:"nickname"`
+	Start    int    `json:"start"`
+	Length   int    `json:"length"`
+}
+
+type CommentStatus uint8
+
+const (
+	CommentStatusUnknown CommentStatus = iota
+	CommentStatusApproved
+	// CommentStatusPending 等人工审核
+	CommentStatusPending
+	CommentStatusRejected
+)
+
+// DeletedContent 删掉的评论显示的内容
+const DeletedContent = "该评论已删除"
+
+// BizComment 评论在互动服务里面的 biz
+const BizComment = "comment"
+
+// CommentSort 根评论的排序方式
+type CommentSort uint8
+
+const (
+	CommentSortNewest CommentSort = iota
+	CommentSortOldest
+	// CommentSortHot 按照点赞数、回复数和发表时间算出来的热度
+	CommentSortHot
+)
+
+// Cursor 翻页的游标，是上一页最后一条评论的，零值就是从头开始
+type Cursor struct {
+	Id       int64
+	HotScore float64
+}
+
 type User struct {
 	Id   int64  `json:"id"`
 	Name string `json:"name"`
 }
+
+type CommentEventType uint8
+
+const (
+	CommentEventTypeUnknown CommentEventType = iota
+	// CommentEventTypeCreated 第一次能被看到，审核通过的也算
+	CommentEventTypeCreated
+	CommentEventTypeEdited
+	CommentEventTypeDeleted
+)
+
+// CommentEvent 发件箱里面还没有发出去的评论事件
+type CommentEvent struct {
+	Id      int64
+	Type    CommentEventType
+	Comment Comment
+	// ParentUid 回复的评论是谁发的，根评论是 0
+	ParentUid int64
+	// Visible 评论现在能不能被看到
+	Visible bool
+	CTime   time.Time
+}
+
+// LikeDelta 一条点赞事件带来的点赞数变化。
+// Offset 是事件在 Kafka 分区里面的位置，重复消费的时候靠它跳过处理过的
+type LikeDelta struct {
+	Cid    int64
+	Delta  int64
+	Offset int64
+}
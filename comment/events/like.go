@@ -0,0 +1,84 @@
+// Copyright@daidai53 2024
+package events
+
+import (
+	"context"
+	"github.com/IBM/sarama"
+	"github.com/daidai53/webook/comment/domain"
+	"github.com/daidai53/webook/comment/repository"
+	"github.com/daidai53/webook/pkg/logger"
+	"github.com/daidai53/webook/pkg/saramax"
+	"github.com/prometheus/client_golang/prometheus"
+	"time"
+)
+
+// topicLikeEvent 互动服务发出来的点赞事件
+const topicLikeEvent = "interactive_like"
+
+// LikeEvent 和互动服务里面的点赞事件一样，只用得到这几个字段
+type LikeEvent struct {
+	Biz   string `json:"biz"`
+	BizId int64  `json:"biz_id"`
+	// Delta 点赞是 1，取消点赞是 -1
+	Delta int64 `json:"delta"`
+}
+
+// LikeEventConsumer 评论的点赞走的是互动服务，这里把点赞数同步到评论上，算热度要用
+type LikeEventConsumer struct {
+	repo   repository.CommentRepository
+	client sarama.Client
+	l      logger.LoggerV1
+}
+
+func NewLikeEventConsumer(repo repository.CommentRepository, client sarama.Client, l logger.LoggerV1) *LikeEventConsumer {
+	return &LikeEventConsumer{
+		repo:   repo,
+		client: client,
+		l:      l,
+	}
+}
+
+func (c *LikeEventConsumer) Start() error {
+	cg, err := sarama.NewConsumerGroupFromClient("comment_like_cnt", c.client)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		er := cg.Consume(context.Background(),
+			[]string{topicLikeEvent},
+			saramax.NewBatchHandler[LikeEvent](c.BatchConsume, c.l,
+				prometheus.CounterOpts{
+					Namespace: "daidai53",
+					Subsystem: "webook",
+					Name:      "comment_like_cnt_kafka",
+				}),
+		)
+		if er != nil {
+			c.l.Error("退出消费",
+				logger.Error(er))
+		}
+	}()
+	return nil
+}
+
+// BatchConsume 一批消息都是同一个分区的，消费到的位置和点赞数一起更新，重复消费不会多加
+func (c *LikeEventConsumer) BatchConsume(msgs []*sarama.ConsumerMessage, events []LikeEvent) error {
+	if len(msgs) == 0 {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	deltas := make([]domain.LikeDelta, 0, len(events))
+	for i, evt := range events {
+		if evt.Biz != domain.BizComment {
+			continue
+		}
+		deltas = append(deltas, domain.LikeDelta{
+			Cid:    evt.BizId,
+			Delta:  evt.Delta,
+			Offset: msgs[i].Offset,
+		})
+	}
+	return c.repo.IncrLikeCnt(ctx, msgs[0].Topic, msgs[0].Partition, deltas)
+}
@@ -0,0 +1,124 @@
+// Copyright@daidai53 2024
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/IBM/sarama"
+	"strconv"
+)
+
+const TopicMentionEvent = "comment_mention"
+
+// TopicCntEvent 评论数变了，key 是 biz:bizId，同一个资源的事件是有序的
+const TopicCntEvent = "comment_cnt"
+
+// TopicCommentEvent 评论新建、修改和删除，key 是评论的 id，同一条评论的事件是有序的
+const TopicCommentEvent = "comment_events"
+
+type Producer interface {
+	ProduceMentionEvent(ctx context.Context, evt MentionEvent) error
+	ProduceCntEvent(ctx context.Context, evt CntEvent) error
+	// ProduceCommentEvents 一批一起发，要么都成功要么返回错误
+	ProduceCommentEvents(ctx context.Context, evts []CommentEvent) error
+}
+
+type SaramaSyncProducer struct {
+	client sarama.SyncProducer
+}
+
+func NewSaramaSyncProducer(client sarama.SyncProducer) Producer {
+	return &SaramaSyncProducer{
+		client: client,
+	}
+}
+
+func (s *SaramaSyncProducer) ProduceMentionEvent(ctx context.Context, evt MentionEvent) error {
+	data, err := json.Marshal(evt)
+	if err != nil {
+		return err
+	}
+	_, _, err = s.client.SendMessage(&sarama.ProducerMessage{
+		Topic: TopicMentionEvent,
+		Key:   sarama.StringEncoder(strconv.FormatInt(evt.Cid, 10)),
+		Value: sarama.ByteEncoder(data),
+	})
+	return err
+}
+
+func (s *SaramaSyncProducer) ProduceCntEvent(ctx context.Context, evt CntEvent) error {
+	data, err := json.Marshal(evt)
+	if err != nil {
+		return err
+	}
+	_, _, err = s.client.SendMessage(&sarama.ProducerMessage{
+		Topic: TopicCntEvent,
+		Key:   sarama.StringEncoder(evt.Biz + ":" + strconv.FormatInt(evt.BizId, 10)),
+		Value: sarama.ByteEncoder(data),
+	})
+	return err
+}
+
+func (s *SaramaSyncProducer) ProduceCommentEvents(ctx context.Context, evts []CommentEvent) error {
+	msgs := make([]*sarama.ProducerMessage, 0, len(evts))
+	for _, evt := range evts {
+		data, err := json.Marshal(evt)
+		if err != nil {
+			return err
+		}
+		msgs = append(msgs, &sarama.ProducerMessage{
+			Topic: TopicCommentEvent,
+			Key:   sarama.StringEncoder(strconv.FormatInt(evt.Cid, 10)),
+			Value: sarama.ByteEncoder(data),
+		})
+	}
+	return s.client.SendMessages(msgs)
+}
+
+// MentionEvent 评论里面 @ 了别人，通知或者 feed 的消费者拿去提醒被 @ 的人
+type MentionEvent struct {
+	// Cid 评论的 id
+	Cid int64 `json:"cid"`
+	// Uid 发评论的人
+	Uid   int64  `json:"uid"`
+	Biz   string `json:"biz"`
+	BizId int64  `json:"biz_id"`
+	// Mentioned 被 @ 的人，不会有重复的，也不会有发评论的人自己
+	Mentioned []int64 `json:"mentioned"`
+	// Ctime 毫秒数
+	Ctime int64 `json:"ctime"`
+}
+
+// CntEvent 资源下面的评论数，带的是变了之后的总数，不是增量，重复消费也没关系
+type CntEvent struct {
+	Biz   string `json:"biz"`
+	BizId int64  `json:"biz_id"`
+	Cnt   int64  `json:"cnt"`
+}
+
+// 评论事件的类型
+const (
+	CommentEventTypeCreated = "created"
+	CommentEventTypeEdited  = "edited"
+	CommentEventTypeDeleted = "deleted"
+)
+
+// CommentEvent 评论的变化，搜索拿去建索引，feed 拿去提醒被回复的人。
+// 审核通过之后才算新建，没有审核通过的评论不会有事件
+type CommentEvent struct {
+	Type  string `json:"type"`
+	Cid   int64  `json:"cid"`
+	Uid   int64  `json:"uid"`
+	Biz   string `json:"biz"`
+	BizId int64  `json:"biz_id"`
+	// ParentId、RootId 和 ParentUid 是 0 表示根评论，ParentUid 是被回复的人
+	ParentId  int64 `json:"parent_id"`
+	RootId    int64 `json:"root_id"`
+	ParentUid int64 `json:"parent_uid"`
+	// Content 删除的时候是空的
+	Content string `json:"content"`
+	// Visible 评论现在能不能被看到，修改之后要重新审核的时候是 false
+	Visible bool `json:"visible"`
+	// Ctime 事件发生的时间，毫秒数
+	Ctime int64 `json:"ctime"`
+}
@@ -3,58 +3,209 @@ package grpc
 
 import (
 	"context"
+	"errors"
 	commentv1 "github.com/daidai53/webook/api/proto/gen/comment/v1"
 	"github.com/daidai53/webook/comment/domain"
+	"github.com/daidai53/webook/comment/repository"
 	"github.com/daidai53/webook/comment/service"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
+	"strconv"
+	"strings"
 )
 
+var ErrInvalidCursor = errors.New("翻页的游标不对")
+
 type CommentServiceServer struct {
 	commentv1.UnimplementedCommentServiceServer
 	svc service.CommentService
 }
 
+func NewCommentServiceServer(svc service.CommentService) *CommentServiceServer {
+	return &CommentServiceServer{
+		svc: svc,
+	}
+}
+
+func (c *CommentServiceServer) Register(s *grpc.Server) {
+	commentv1.RegisterCommentServiceServer(s, c)
+}
+
 func (c *CommentServiceServer) GetCommentList(ctx context.Context, request *commentv1.CommentListRequest) (*commentv1.CommentListResponse, error) {
-	comments, err := c.svc.GetCommentList(ctx, request.GetBiz(), request.GetBizid(), request.GetMinId(), request.GetLimit())
+	cursor, err := c.parseCursor(request.GetCursor())
 	if err != nil {
-		return &commentv1.CommentListResponse{}, err
+		return &commentv1.CommentListResponse{}, toStatus(err)
 	}
-	return &commentv1.CommentListResponse{
+	// 兼容以前只传 min_id 的
+	if request.GetCursor() == "" && request.GetSort() == commentv1.CommentSort_CommentSortNewest {
+		cursor.Id = request.GetMinId()
+	}
+	comments, err := c.svc.GetCommentList(ctx, request.GetBiz(), request.GetBizid(),
+		domain.CommentSort(request.GetSort()), cursor, request.GetLimit())
+	if err != nil {
+		return &commentv1.CommentListResponse{}, toStatus(err)
+	}
+	resp := &commentv1.CommentListResponse{
 		Comments: c.toDTO(comments),
-	}, nil
+	}
+	// 置顶的评论不算在 limit 里面
+	cnt := 0
+	for _, cmt := range comments {
+		if !cmt.Pinned {
+			cnt++
+		}
+	}
+	if cnt > 0 && int64(cnt) == request.GetLimit() {
+		resp.NextCursor = c.formatCursor(comments[len(comments)-1])
+	}
+	return resp, nil
 }
 
 func (c *CommentServiceServer) DeleteComment(ctx context.Context, request *commentv1.DeleteCommentRequest) (*commentv1.DeleteCommentResponse, error) {
-	err := c.svc.DeleteComment(ctx, request.GetId())
-	return &commentv1.DeleteCommentResponse{}, err
+	err := c.svc.DeleteComment(ctx, request.GetId(), request.GetUid())
+	return &commentv1.DeleteCommentResponse{}, toStatus(err)
 }
 
 func (c *CommentServiceServer) CreateComment(ctx context.Context, request *commentv1.CreateCommentRequest) (*commentv1.CreateCommentResponse, error) {
-	err := c.svc.CreateComment(ctx, c.ToDomain(request.GetComment()))
-	return &commentv1.CreateCommentResponse{}, err
+	cmt, err := c.svc.CreateComment(ctx, c.ToDomain(request.GetComment()))
+	return &commentv1.CreateCommentResponse{
+		Id:     cmt.Id,
+		Status: commentv1.CommentStatus(cmt.Status),
+	}, toStatus(err)
 }
 
 func (c *CommentServiceServer) GetMoreReplies(ctx context.Context, request *commentv1.GetMoreRepliesRequest) (*commentv1.GetMoreRepliesResponse, error) {
 	replies, err := c.svc.GetMoreReplies(ctx, request.GetRid(), request.GetMaxId(), request.GetLimit())
 	if err != nil {
-		return &commentv1.GetMoreRepliesResponse{}, err
+		return &commentv1.GetMoreRepliesResponse{}, toStatus(err)
 	}
 	return &commentv1.GetMoreRepliesResponse{
 		Replies: c.toDTO(replies),
 	}, nil
 }
 
+func (c *CommentServiceServer) EditComment(ctx context.Context, request *commentv1.EditCommentRequest) (*commentv1.EditCommentResponse, error) {
+	status, err := c.svc.EditComment(ctx, request.GetId(), request.GetUid(), request.GetContent())
+	return &commentv1.EditCommentResponse{
+		Status: commentv1.CommentStatus(status),
+	}, toStatus(err)
+}
+
+func (c *CommentServiceServer) ListPendingComments(ctx context.Context, request *commentv1.ListPendingCommentsRequest) (*commentv1.ListPendingCommentsResponse, error) {
+	comments, err := c.svc.ListPendingComments(ctx, request.GetUid(), request.GetMinId(), request.GetLimit())
+	if err != nil {
+		return &commentv1.ListPendingCommentsResponse{}, toStatus(err)
+	}
+	return &commentv1.ListPendingCommentsResponse{
+		Comments: c.toDTO(comments),
+	}, nil
+}
+
+func (c *CommentServiceServer) ReviewComment(ctx context.Context, request *commentv1.ReviewCommentRequest) (*commentv1.ReviewCommentResponse, error) {
+	err := c.svc.ReviewComment(ctx, request.GetId(), request.GetUid(), request.GetApproved())
+	return &commentv1.ReviewCommentResponse{}, toStatus(err)
+}
+
+func (c *CommentServiceServer) PinComment(ctx context.Context, request *commentv1.PinCommentRequest) (*commentv1.PinCommentResponse, error) {
+	err := c.svc.PinComment(ctx, request.GetId(), request.GetUid())
+	return &commentv1.PinCommentResponse{}, toStatus(err)
+}
+
+func (c *CommentServiceServer) UnpinComment(ctx context.Context, request *commentv1.UnpinCommentRequest) (*commentv1.UnpinCommentResponse, error) {
+	err := c.svc.UnpinComment(ctx, request.GetId(), request.GetUid())
+	return &commentv1.UnpinCommentResponse{}, toStatus(err)
+}
+
+func (c *CommentServiceServer) GetCommentCnts(ctx context.Context, request *commentv1.GetCommentCntsRequest) (*commentv1.GetCommentCntsResponse, error) {
+	cnts, err := c.svc.GetCommentCnts(ctx, request.GetBiz(), request.GetBizIds())
+	if err != nil {
+		return &commentv1.GetCommentCntsResponse{}, toStatus(err)
+	}
+	return &commentv1.GetCommentCntsResponse{
+		Cnts: cnts,
+	}, nil
+}
+
+// toStatus 业务错误转成对应的 gRPC 错误码，调用方按照错误码区分是请求的问题还是系统的问题
+func toStatus(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, service.ErrEmptyContent),
+		errors.Is(err, service.ErrTooManyIds),
+		errors.Is(err, repository.ErrInvalidParent),
+		errors.Is(err, ErrInvalidCursor):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, service.ErrPermissionDenied),
+		errors.Is(err, service.ErrBlocked):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, service.ErrCommentRejected),
+		errors.Is(err, service.ErrTooManyPinned):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, repository.ErrCommentNotFound):
+		return status.Error(codes.NotFound, "评论不存在")
+	default:
+		return err
+	}
+}
+
+// formatCursor 游标是 id_热度，热度要原样带回来，不能丢精度
+func (c *CommentServiceServer) formatCursor(last domain.Comment) string {
+	return strconv.FormatInt(last.Id, 10) + "_" + strconv.FormatFloat(last.HotScore, 'g', -1, 64)
+}
+
+func (c *CommentServiceServer) parseCursor(cursor string) (domain.Cursor, error) {
+	if cursor == "" {
+		return domain.Cursor{}, nil
+	}
+	id, score, ok := strings.Cut(cursor, "_")
+	if !ok {
+		return domain.Cursor{}, ErrInvalidCursor
+	}
+	var (
+		res domain.Cursor
+		err error
+	)
+	res.Id, err = strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return domain.Cursor{}, ErrInvalidCursor
+	}
+	res.HotScore, err = strconv.ParseFloat(score, 64)
+	if err != nil {
+		return domain.Cursor{}, ErrInvalidCursor
+	}
+	return res, nil
+}
+
 func (c *CommentServiceServer) toDTO(domainComments []domain.Comment) []*commentv1.Comment {
 	rpcComments := make([]*commentv1.Comment, 0, len(domainComments))
 	for _, domainComment := range domainComments {
 		rpcComment := &commentv1.Comment{
-			Id:      domainComment.Id,
-			Uid:     domainComment.Commentator.Id,
-			Biz:     domainComment.Biz,
-			Bizid:   domainComment.BizId,
-			Content: domainComment.Content,
-			Ctime:   timestamppb.New(domainComment.CTime),
-			Utime:   timestamppb.New(domainComment.UTime),
+			Id:               domainComment.Id,
+			Uid:              domainComment.Commentator.Id,
+			Biz:              domainComment.Biz,
+			Bizid:            domainComment.BizId,
+			Content:          domainComment.Content,
+			Edited:           domainComment.Edited,
+			Deleted:          domainComment.Deleted,
+			Pinned:           domainComment.Pinned,
+			IsAuthor:         domainComment.IsAuthor,
+			LikeCnt:          domainComment.LikeCnt,
+			ReplyCnt:         domainComment.ReplyCnt,
+			Status:           commentv1.CommentStatus(domainComment.Status),
+			ModerationReason: domainComment.ModerationReason,
+			Ctime:            timestamppb.New(domainComment.CTime),
+			Utime:            timestamppb.New(domainComment.UTime),
+		}
+		for _, mention := range domainComment.Mentions {
+			rpcComment.Mentions = append(rpcComment.Mentions, &commentv1.Mention{
+				Uid:      mention.Uid,
+				Nickname: mention.Nickname,
+				Start:    int32(mention.Start),
+				Length:   int32(mention.Length),
+			})
 		}
 		if domainComment.RootComment != nil {
 			rpcComment.RootComment = &commentv1.Comment{
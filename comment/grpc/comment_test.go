@@ -0,0 +1,77 @@
+// Copyright@daidai53 2024
+package grpc
+
+import (
+	"errors"
+	"fmt"
+	"github.com/daidai53/webook/comment/repository"
+	"github.com/daidai53/webook/comment/service"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"testing"
+)
+
+func TestToStatus(t *testing.T) {
+	testCases := []struct {
+		name string
+		err  error
+
+		wantCode codes.Code
+	}{
+		{
+			name:     "成功",
+			wantCode: codes.OK,
+		},
+		{
+			name:     "内容为空",
+			err:      service.ErrEmptyContent,
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "游标不对",
+			err:      ErrInvalidCursor,
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "回复的评论不对",
+			err:      fmt.Errorf("创建评论失败 %w", repository.ErrInvalidParent),
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "没有权限",
+			err:      service.ErrPermissionDenied,
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name:     "被拉黑了",
+			err:      service.ErrBlocked,
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name:     "没有通过审核",
+			err:      service.ErrCommentRejected,
+			wantCode: codes.FailedPrecondition,
+		},
+		{
+			name:     "置顶太多了",
+			err:      service.ErrTooManyPinned,
+			wantCode: codes.FailedPrecondition,
+		},
+		{
+			name:     "评论不存在",
+			err:      repository.ErrCommentNotFound,
+			wantCode: codes.NotFound,
+		},
+		{
+			name:     "系统错误",
+			err:      errors.New("mock error"),
+			wantCode: codes.Unknown,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.wantCode, status.Code(toStatus(tc.err)))
+		})
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright@daidai53 2024
+package job
+
+import (
+	"context"
+	"github.com/daidai53/webook/comment/service"
+	"time"
+)
+
+// OutboxJob 定时把发件箱里面的评论事件发出去，一直发到发件箱空了或者超时
+type OutboxJob struct {
+	svc       service.OutboxService
+	batchSize int
+	timeout   time.Duration
+}
+
+func NewOutboxJob(svc service.OutboxService, batchSize int, timeout time.Duration) *OutboxJob {
+	return &OutboxJob{
+		svc:       svc,
+		batchSize: batchSize,
+		timeout:   timeout,
+	}
+}
+
+func (o *OutboxJob) Name() string {
+	return "comment_outbox"
+}
+
+func (o *OutboxJob) Run() error {
+	ctx, cancel := context.WithTimeout(context.Background(), o.timeout)
+	defer cancel()
+	for {
+		cnt, err := o.svc.Relay(ctx, o.batchSize)
+		if err != nil {
+			// Kafka 挂了的话事件还在发件箱里面，下次再发
+			return err
+		}
+		if cnt < o.batchSize {
+			return nil
+		}
+	}
+}
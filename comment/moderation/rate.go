@@ -0,0 +1,31 @@
+// Copyright@daidai53 2024
+package moderation
+
+import (
+	"context"
+	"fmt"
+	"github.com/daidai53/webook/comment/domain"
+	"github.com/daidai53/webook/pkg/limiter"
+)
+
+// RateChecker 同一个人发评论太频繁就拒绝
+type RateChecker struct {
+	limiter limiter.Limiter
+}
+
+func NewRateChecker(limiter limiter.Limiter) *RateChecker {
+	return &RateChecker{
+		limiter: limiter,
+	}
+}
+
+func (r *RateChecker) Check(ctx context.Context, cmt domain.Comment) (Result, error) {
+	limited, err := r.limiter.Limit(ctx, fmt.Sprintf("comment:moderation:uid:%d", cmt.Commentator.Id))
+	if err != nil {
+		return Result{}, err
+	}
+	if limited {
+		return Rejected("评论太频繁"), nil
+	}
+	return Approved(), nil
+}
@@ -0,0 +1,55 @@
+// Copyright@daidai53 2024
+package moderation
+
+import (
+	"context"
+	"github.com/daidai53/webook/comment/domain"
+	"github.com/daidai53/webook/pkg/ahocorasick"
+)
+
+// SensitiveWordChecker 敏感词，两个词库建在同一个自动机里面，扫一遍就够了。
+// 扫到违禁词就停下来，敏感词只记第一个
+type SensitiveWordChecker struct {
+	matcher *ahocorasick.Matcher
+	// rejected 出现了就直接拒绝，其它的词出现了要人工审核
+	rejected map[string]struct{}
+}
+
+func NewSensitiveWordChecker(rejectedWords, reviewWords []string) *SensitiveWordChecker {
+	rejected := make(map[string]struct{}, len(rejectedWords))
+	for _, word := range rejectedWords {
+		rejected[word] = struct{}{}
+	}
+	words := make([]string, 0, len(rejectedWords)+len(reviewWords))
+	words = append(words, rejectedWords...)
+	words = append(words, reviewWords...)
+	return &SensitiveWordChecker{
+		matcher:  ahocorasick.New(words),
+		rejected: rejected,
+	}
+}
+
+func (s *SensitiveWordChecker) Check(ctx context.Context, cmt domain.Comment) (Result, error) {
+	var (
+		rejected string
+		review   string
+	)
+	s.matcher.Scan(cmt.Content, func(match ahocorasick.Match) bool {
+		if _, ok := s.rejected[match.Word]; ok {
+			rejected = match.Word
+			return false
+		}
+		if review == "" {
+			review = match.Word
+		}
+		return true
+	})
+	switch {
+	case rejected != "":
+		return Rejected("包含违禁词：" + rejected), nil
+	case review != "":
+		return Pending("包含敏感词：" + review), nil
+	default:
+		return Approved(), nil
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright@daidai53 2024
+package moderation
+
+import (
+	"context"
+	"github.com/daidai53/webook/comment/domain"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSensitiveWordChecker_Check(t *testing.T) {
+	checker := NewSensitiveWordChecker([]string{"赌博"}, []string{"加微信", "私聊"})
+	testCases := []struct {
+		name    string
+		content string
+
+		wantRes Result
+	}{
+		{
+			name:    "没有敏感词",
+			content: "写得很好",
+			wantRes: Approved(),
+		},
+		{
+			name:    "违禁词直接拒绝",
+			content: "来赌博吧",
+			wantRes: Rejected("包含违禁词：赌博"),
+		},
+		{
+			name:    "敏感词要人工审核",
+			content: "有问题加微信",
+			wantRes: Pending("包含敏感词：加微信"),
+		},
+		{
+			name:    "两种都有的时候按照拒绝算",
+			content: "加微信一起赌博",
+			wantRes: Rejected("包含违禁词：赌博"),
+		},
+		{
+			name:    "好几个敏感词的时候报第一个",
+			content: "私聊或者加微信",
+			wantRes: Pending("包含敏感词：私聊"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := checker.Check(context.Background(), domain.Comment{Content: tc.content})
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantRes, res)
+		})
+	}
+}
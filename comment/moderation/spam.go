@@ -0,0 +1,56 @@
+// Copyright@daidai53 2024
+package moderation
+
+import (
+	"context"
+	"github.com/daidai53/webook/comment/domain"
+	"regexp"
+)
+
+var linkRegexp = regexp.MustCompile(`(?i)(https?://|www\.)\S+`)
+
+// SpamChecker 根据链接和重复的字符判断是不是广告或者刷屏
+type SpamChecker struct {
+	// maxLinks 超过这么多链接直接拒绝，有链接但是没超过的要人工审核
+	maxLinks int
+	// maxRepeat 同一个字符连续出现超过这么多次要人工审核
+	maxRepeat int
+}
+
+func NewSpamChecker(maxLinks, maxRepeat int) *SpamChecker {
+	return &SpamChecker{
+		maxLinks:  maxLinks,
+		maxRepeat: maxRepeat,
+	}
+}
+
+func (s *SpamChecker) Check(ctx context.Context, cmt domain.Comment) (Result, error) {
+	links := len(linkRegexp.FindAllStringIndex(cmt.Content, s.maxLinks+1))
+	if links > s.maxLinks {
+		return Rejected("链接太多"), nil
+	}
+	if links > 0 {
+		return Pending("包含链接"), nil
+	}
+	if s.longestRepeat(cmt.Content) > s.maxRepeat {
+		return Pending("重复的字符太多"), nil
+	}
+	return Approved(), nil
+}
+
+func (s *SpamChecker) longestRepeat(content string) int {
+	var (
+		last    rune
+		cnt     int
+		longest int
+	)
+	for _, r := range content {
+		if r == last {
+			cnt++
+		} else {
+			last, cnt = r, 1
+		}
+		longest = max(longest, cnt)
+	}
+	return longest
+}
@@ -0,0 +1,52 @@
+// Copyright@daidai53 2024
+package moderation
+
+import (
+	"context"
+	"github.com/daidai53/webook/comment/domain"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSpamChecker_Check(t *testing.T) {
+	checker := NewSpamChecker(2, 5)
+	testCases := []struct {
+		name    string
+		content string
+
+		wantRes Result
+	}{
+		{
+			name:    "正常评论",
+			content: "写得很好，学到了",
+			wantRes: Approved(),
+		},
+		{
+			name:    "有链接要人工审核",
+			content: "参考 https://example.com/a",
+			wantRes: Pending("包含链接"),
+		},
+		{
+			name:    "链接太多直接拒绝",
+			content: "https://a.com www.b.com HTTP://c.com",
+			wantRes: Rejected("链接太多"),
+		},
+		{
+			name:    "重复的字符太多",
+			content: "哈哈哈哈哈哈",
+			wantRes: Pending("重复的字符太多"),
+		},
+		{
+			name:    "重复的字符刚好没超过",
+			content: "哈哈哈哈哈",
+			wantRes: Approved(),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := checker.Check(context.Background(), domain.Comment{Content: tc.content})
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantRes, res)
+		})
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright@daidai53 2024
+package moderation
+
+import (
+	"context"
+	"github.com/daidai53/webook/comment/domain"
+)
+
+// Checker 审核一条评论，返回通过、待人工审核或者拒绝
+type Checker interface {
+	Check(ctx context.Context, cmt domain.Comment) (Result, error)
+}
+
+type Result struct {
+	Status domain.CommentStatus
+	// Reason 给审核员看的，通过的时候是空的
+	Reason string
+}
+
+func Approved() Result {
+	return Result{Status: domain.CommentStatusApproved}
+}
+
+func Pending(reason string) Result {
+	return Result{Status: domain.CommentStatusPending, Reason: reason}
+}
+
+func Rejected(reason string) Result {
+	return Result{Status: domain.CommentStatusRejected, Reason: reason}
+}
+
+// Chain 按顺序一个一个审核，有一个拒绝了就是拒绝，
+// 没有拒绝但是有要人工审核的，就是待审核，原因用第一个的
+type Chain []Checker
+
+func NewChain(checkers ...Checker) Chain {
+	return checkers
+}
+
+func (c Chain) Check(ctx context.Context, cmt domain.Comment) (Result, error) {
+	res := Approved()
+	for _, checker := range c {
+		r, err := checker.Check(ctx, cmt)
+		if err != nil {
+			return Result{}, err
+		}
+		switch r.Status {
+		case domain.CommentStatusRejected:
+			return r, nil
+		case domain.CommentStatusPending:
+			if res.Status != domain.CommentStatusPending {
+				res = r
+			}
+		}
+	}
+	return res, nil
+}
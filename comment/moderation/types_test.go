@@ -0,0 +1,72 @@
+// Copyright@daidai53 2024
+package moderation
+
+import (
+	"context"
+	"errors"
+	"github.com/daidai53/webook/comment/domain"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestChain_Check(t *testing.T) {
+	testCases := []struct {
+		name     string
+		checkers []Checker
+
+		wantRes Result
+		wantErr error
+	}{
+		{
+			name:     "都通过",
+			checkers: []Checker{fixedChecker{res: Approved()}, fixedChecker{res: Approved()}},
+			wantRes:  Approved(),
+		},
+		{
+			name:    "没有审核器也是通过",
+			wantRes: Approved(),
+		},
+		{
+			name: "有待审核的，原因用第一个的",
+			checkers: []Checker{
+				fixedChecker{res: Approved()},
+				fixedChecker{res: Pending("包含链接")},
+				fixedChecker{res: Pending("包含敏感词")},
+			},
+			wantRes: Pending("包含链接"),
+		},
+		{
+			name: "有拒绝的就是拒绝，后面的不用再审核",
+			checkers: []Checker{
+				fixedChecker{res: Pending("包含链接")},
+				fixedChecker{res: Rejected("链接太多")},
+				fixedChecker{err: errors.New("不应该调用")},
+			},
+			wantRes: Rejected("链接太多"),
+		},
+		{
+			name: "审核出错",
+			checkers: []Checker{
+				fixedChecker{res: Pending("包含链接")},
+				fixedChecker{err: errors.New("mock limiter error")},
+			},
+			wantErr: errors.New("mock limiter error"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := NewChain(tc.checkers...).Check(context.Background(), domain.Comment{})
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantRes, res)
+		})
+	}
+}
+
+type fixedChecker struct {
+	res Result
+	err error
+}
+
+func (f fixedChecker) Check(ctx context.Context, cmt domain.Comment) (Result, error) {
+	return f.res, f.err
+}
@@ -0,0 +1,75 @@
+// Copyright@daidai53 2024
+package cache
+
+import (
+	"context"
+	"fmt"
+	"github.com/redis/go-redis/v9"
+	"strconv"
+	"time"
+)
+
+// cntExpiration 评论数缓存的过期时间，评论数变了会直接删掉缓存
+const cntExpiration = time.Minute * 15
+
+type CommentCntCache interface {
+	// BatchGet 没有缓存的不在返回的 map 里面
+	BatchGet(ctx context.Context, biz string, bizIds []int64) (map[int64]int64, error)
+	BatchSet(ctx context.Context, biz string, cnts map[int64]int64) error
+	Del(ctx context.Context, biz string, bizId int64) error
+}
+
+type RedisCommentCntCache struct {
+	client redis.Cmdable
+}
+
+func NewRedisCommentCntCache(client redis.Cmdable) CommentCntCache {
+	return &RedisCommentCntCache{
+		client: client,
+	}
+}
+
+func (r *RedisCommentCntCache) BatchGet(ctx context.Context, biz string, bizIds []int64) (map[int64]int64, error) {
+	if len(bizIds) == 0 {
+		return map[int64]int64{}, nil
+	}
+	keys := make([]string, 0, len(bizIds))
+	for _, bizId := range bizIds {
+		keys = append(keys, r.key(biz, bizId))
+	}
+	vals, err := r.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[int64]int64, len(bizIds))
+	for idx, val := range vals {
+		str, ok := val.(string)
+		if !ok {
+			continue
+		}
+		cnt, err := strconv.ParseInt(str, 10, 64)
+		if err != nil {
+			continue
+		}
+		res[bizIds[idx]] = cnt
+	}
+	return res, nil
+}
+
+func (r *RedisCommentCntCache) BatchSet(ctx context.Context, biz string, cnts map[int64]int64) error {
+	_, err := r.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for bizId, cnt := range cnts {
+			pipe.Set(ctx, r.key(biz, bizId), cnt, cntExpiration)
+		}
+		return nil
+	})
+	return err
+}
+
+func (r *RedisCommentCntCache) Del(ctx context.Context, biz string, bizId int64) error {
+	return r.client.Del(ctx, r.key(biz, bizId)).Err()
+}
+
+func (r *RedisCommentCntCache) key(biz string, bizId int64) string {
+	return fmt.Sprintf("comment:cnt:%s:%d", biz, bizId)
+}
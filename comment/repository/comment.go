@@ -3,35 +3,66 @@ package repository
 
 import (
 	"context"
+	"database/sql"
 	"github.com/daidai53/webook/comment/domain"
+	"github.com/daidai53/webook/comment/repository/cache"
 	"github.com/daidai53/webook/comment/repository/dao"
+	"github.com/daidai53/webook/pkg/logger"
 	"golang.org/x/sync/errgroup"
 	"time"
 )
 
 type commentRepository struct {
-	dao dao.CommentDAO
+	dao   dao.CommentDAO
+	cache cache.CommentCntCache
+	l     logger.LoggerV1
 }
 
-func (c *commentRepository) FindByBiz(ctx context.Context, biz string, bizId, minId, limit int64) ([]domain.Comment, error) {
+func NewCommentRepository(dao dao.CommentDAO, cache cache.CommentCntCache, l logger.LoggerV1) CommentRepository {
+	return &commentRepository{
+		dao:   dao,
+		cache: cache,
+		l:     l,
+	}
+}
+
+func (c *commentRepository) FindByBiz(ctx context.Context, biz string, bizId int64, sort domain.CommentSort,
+	cursor domain.Cursor, limit int64) ([]domain.Comment, error) {
 	// 这里只找出来了根评论
-	daoComments, err := c.dao.FindByBiz(ctx, biz, bizId, minId, limit)
+	daoComments, err := c.dao.FindByBiz(ctx, biz, bizId, uint8(sort), dao.Cursor{
+		Id:       cursor.Id,
+		HotScore: cursor.HotScore,
+	}, limit)
 	if err != nil {
 		return nil, err
 	}
+	return c.withReplies(ctx, daoComments)
+}
 
+func (c *commentRepository) FindPinned(ctx context.Context, biz string, bizId int64) ([]domain.Comment, error) {
+	daoComments, err := c.dao.FindPinned(ctx, biz, bizId)
+	if err != nil {
+		return nil, err
+	}
+	return c.withReplies(ctx, daoComments)
+}
+
+// withReplies 根评论带上最早的几条回复
+func (c *commentRepository) withReplies(ctx context.Context, daoComments []dao.Comment) ([]domain.Comment, error) {
 	res := make([]domain.Comment, 0, len(daoComments))
+	for _, dc := range daoComments {
+		res = append(res, c.toDomain(dc))
+	}
 	// 下面要开始找子评论
 	var eg errgroup.Group
 	downgrade := ctx.Value("downgrade") == "true"
-	for _, dc := range daoComments {
-		newDc := dc
-		cm := c.toDomain(newDc)
-		if downgrade {
-			continue
-		}
+	if downgrade {
+		return res, c.fillMentions(ctx, res)
+	}
+	for i := range res {
+		cm := &res[i]
 		eg.Go(func() error {
-			subComments, err := c.dao.FindRepliesByPId(ctx, newDc.Id, 0, 3)
+			subComments, err := c.dao.FindRepliesByPId(ctx, cm.Id, 0, 3)
 			if err != nil {
 				return err
 			}
@@ -43,22 +74,138 @@ func (c *commentRepository) FindByBiz(ctx context.Context, biz string, bizId, mi
 			return nil
 		})
 	}
-	return res, eg.Wait()
+	err := eg.Wait()
+	if err != nil {
+		return nil, err
+	}
+	return res, c.fillMentions(ctx, res)
+}
+
+func (c *commentRepository) Pin(ctx context.Context, id int64, limit int) error {
+	return c.dao.Pin(ctx, id, limit)
+}
+
+func (c *commentRepository) Unpin(ctx context.Context, id int64) error {
+	return c.dao.Unpin(ctx, id)
 }
 
 func (c *commentRepository) DeleteComment(ctx context.Context, cmt domain.Comment) error {
-	//TODO implement me
-	panic("implement me")
+	err := c.dao.Delete(ctx, dao.Comment{
+		Id: cmt.Id,
+	})
+	if err != nil {
+		return err
+	}
+	c.invalidateCnt(ctx, cmt.Biz, cmt.BizId)
+	return nil
 }
 
-func (c *commentRepository) CreateComment(ctx context.Context, cmt domain.Comment) error {
-	//TODO implement me
-	panic("implement me")
+func (c *commentRepository) CreateComment(ctx context.Context, cmt domain.Comment) (int64, error) {
+	id, err := c.dao.Insert(ctx, c.toEntity(cmt), c.toMentionEntities(cmt.Mentions))
+	if err != nil {
+		return 0, err
+	}
+	c.invalidateCnt(ctx, cmt.Biz, cmt.BizId)
+	return id, nil
 }
 
 func (c *commentRepository) GetCommentByIds(ctx context.Context, id []int64) ([]domain.Comment, error) {
-	//TODO implement me
-	panic("implement me")
+	comments, err := c.dao.FindOneByIds(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]domain.Comment, 0, len(comments))
+	for _, cmt := range comments {
+		res = append(res, c.toDomain(cmt))
+	}
+	return res, c.fillMentions(ctx, res)
+}
+
+func (c *commentRepository) EditComment(ctx context.Context, cmt domain.Comment) error {
+	err := c.dao.UpdateContent(ctx, c.toEntity(cmt), c.toMentionEntities(cmt.Mentions))
+	if err != nil {
+		return err
+	}
+	// 改了之后可能要重新审核，就看不到了
+	c.invalidateCnt(ctx, cmt.Biz, cmt.BizId)
+	return nil
+}
+
+func (c *commentRepository) FindPending(ctx context.Context, minId, limit int64) ([]domain.Comment, error) {
+	comments, err := c.dao.FindByStatus(ctx, uint8(domain.CommentStatusPending), minId, limit)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]domain.Comment, 0, len(comments))
+	for _, cmt := range comments {
+		res = append(res, c.toDomain(cmt))
+	}
+	return res, c.fillMentions(ctx, res)
+}
+
+func (c *commentRepository) UpdateStatus(ctx context.Context, id int64, from, to domain.CommentStatus) error {
+	err := c.dao.UpdateStatus(ctx, id, uint8(from), uint8(to))
+	if err != nil {
+		return err
+	}
+	cmts, err := c.dao.FindOneByIds(ctx, []int64{id})
+	if err != nil {
+		return err
+	}
+	for _, cmt := range cmts {
+		c.invalidateCnt(ctx, cmt.Biz, cmt.BizId)
+	}
+	return nil
+}
+
+func (c *commentRepository) GetCnts(ctx context.Context, biz string, bizIds []int64) (map[int64]int64, error) {
+	res, err := c.cache.BatchGet(ctx, biz, bizIds)
+	if err != nil {
+		// 缓存出问题了就都去数据库查
+		res = make(map[int64]int64, len(bizIds))
+	}
+	missing := make([]int64, 0, len(bizIds))
+	for _, bizId := range bizIds {
+		if _, ok := res[bizId]; !ok {
+			missing = append(missing, bizId)
+		}
+	}
+	if len(missing) == 0 {
+		return res, nil
+	}
+	cnts, err := c.dao.FindCnts(ctx, biz, missing)
+	if err != nil {
+		return nil, err
+	}
+	// 没有评论过的也缓存起来，不然每次都要查数据库
+	loaded := make(map[int64]int64, len(missing))
+	for _, bizId := range missing {
+		loaded[bizId] = 0
+	}
+	for _, cnt := range cnts {
+		loaded[cnt.BizId] = cnt.Cnt
+	}
+	for bizId, cnt := range loaded {
+		res[bizId] = cnt
+	}
+	err = c.cache.BatchSet(ctx, biz, loaded)
+	if err != nil {
+		c.l.Error("回写评论数缓存失败",
+			logger.String("biz", biz),
+			logger.Error(err))
+	}
+	return res, nil
+}
+
+// invalidateCnt 评论数可能变了，删掉缓存。删失败了也就是过期之前数字不准
+func (c *commentRepository) invalidateCnt(ctx context.Context, biz string, bizId int64) {
+	err := c.cache.Del(ctx, biz, bizId)
+	if err != nil {
+		c.l.Error("删除评论数缓存失败",
+			logger.String("biz", biz),
+			logger.Int64("biz_id", bizId),
+			logger.Error(err))
+	}
 }
 
 func (c *commentRepository) GetMoreReplies(ctx context.Context, rid, maxId, limit int64) ([]domain.Comment, error) {
@@ -70,20 +217,131 @@ func (c *commentRepository) GetMoreReplies(ctx context.Context, rid, maxId, limi
 	for _, cmt := range comments {
 		res = append(res, c.toDomain(cmt))
 	}
+	return res, c.fillMentions(ctx, res)
+}
+
+func (c *commentRepository) IncrLikeCnt(ctx context.Context, topic string, partition int32, deltas []domain.LikeDelta) error {
+	res := make([]dao.LikeDelta, 0, len(deltas))
+	for _, delta := range deltas {
+		res = append(res, dao.LikeDelta{
+			Cid:    delta.Cid,
+			Delta:  delta.Delta,
+			Offset: delta.Offset,
+		})
+	}
+	return c.dao.IncrLikeCnt(ctx, topic, partition, res)
+}
+
+func (c *commentRepository) FindEvents(ctx context.Context, limit int) ([]domain.CommentEvent, error) {
+	evts, err := c.dao.FindEvents(ctx, limit)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]domain.CommentEvent, 0, len(evts))
+	for _, evt := range evts {
+		cmt := domain.Comment{
+			Id: evt.Cid,
+			Commentator: domain.User{
+				Id: evt.Uid,
+			},
+			Biz:     evt.Biz,
+			BizId:   evt.BizId,
+			Content: evt.Content,
+		}
+		if evt.ParentId > 0 {
+			cmt.ParentComment = &domain.Comment{Id: evt.ParentId}
+		}
+		if evt.RootId > 0 {
+			cmt.RootComment = &domain.Comment{Id: evt.RootId}
+		}
+		res = append(res, domain.CommentEvent{
+			Id:        evt.Id,
+			Type:      domain.CommentEventType(evt.Type),
+			Comment:   cmt,
+			ParentUid: evt.ParentUid,
+			Visible:   evt.Visible,
+			CTime:     time.UnixMilli(evt.CTime),
+		})
+	}
 	return res, nil
 }
 
+func (c *commentRepository) DeleteEvents(ctx context.Context, ids []int64) error {
+	return c.dao.DeleteEvents(ctx, ids)
+}
+
+// fillMentions 一次把这些评论和它们的子评论 @ 到的人都查出来
+func (c *commentRepository) fillMentions(ctx context.Context, comments []domain.Comment) error {
+	var cids []int64
+	for _, cmt := range comments {
+		cids = append(cids, cmt.Id)
+		for _, child := range cmt.Children {
+			cids = append(cids, child.Id)
+		}
+	}
+	if len(cids) == 0 {
+		return nil
+	}
+	mentions, err := c.dao.FindMentions(ctx, cids)
+	if err != nil {
+		return err
+	}
+	if len(mentions) == 0 {
+		return nil
+	}
+	m := make(map[int64][]domain.Mention, len(mentions))
+	for _, mention := range mentions {
+		m[mention.Cid] = append(m[mention.Cid], domain.Mention{
+			Uid:      mention.Uid,
+			Nickname: mention.Nickname,
+			Start:    mention.Start,
+			Length:   mention.Length,
+		})
+	}
+	for i := range comments {
+		comments[i].Mentions = m[comments[i].Id]
+		for j := range comments[i].Children {
+			comments[i].Children[j].Mentions = m[comments[i].Children[j].Id]
+		}
+	}
+	return nil
+}
+
+func (c *commentRepository) toMentionEntities(mentions []domain.Mention) []dao.CommentMention {
+	res := make([]dao.CommentMention, 0, len(mentions))
+	for _, mention := range mentions {
+		res = append(res, dao.CommentMention{
+			Uid:      mention.Uid,
+			Nickname: mention.Nickname,
+			Start:    mention.Start,
+			Length:   mention.Length,
+		})
+	}
+	return res
+}
+
 func (c *commentRepository) toDomain(daoComment dao.Comment) domain.Comment {
 	val := domain.Comment{
 		Id: daoComment.Id,
 		Commentator: domain.User{
 			Id: daoComment.Uid,
 		},
-		Biz:     daoComment.Biz,
-		BizId:   daoComment.BizId,
-		Content: daoComment.Content,
-		CTime:   time.UnixMilli(daoComment.CTime),
-		UTime:   time.UnixMilli(daoComment.UTime),
+		Biz:              daoComment.Biz,
+		BizId:            daoComment.BizId,
+		Content:          daoComment.Content,
+		Edited:           daoComment.Edited,
+		Deleted:          daoComment.Deleted,
+		Pinned:           daoComment.PinnedAt > 0,
+		Status:           domain.CommentStatus(daoComment.Status),
+		ModerationReason: daoComment.ModerationReason,
+		LikeCnt:          daoComment.LikeCnt,
+		ReplyCnt:         daoComment.ReplyCnt,
+		HotScore:         daoComment.HotScore,
+		CTime:            time.UnixMilli(daoComment.CTime),
+		UTime:            time.UnixMilli(daoComment.UTime),
+	}
+	if daoComment.Deleted {
+		val.Content = domain.DeletedContent
 	}
 	if daoComment.ParentId.Valid {
 		val.ParentComment = &domain.Comment{
@@ -97,3 +355,28 @@ func (c *commentRepository) toDomain(daoComment dao.Comment) domain.Comment {
 	}
 	return val
 }
+
+func (c *commentRepository) toEntity(domainComment domain.Comment) dao.Comment {
+	val := dao.Comment{
+		Id:               domainComment.Id,
+		Uid:              domainComment.Commentator.Id,
+		Biz:              domainComment.Biz,
+		BizId:            domainComment.BizId,
+		Content:          domainComment.Content,
+		Status:           uint8(domainComment.Status),
+		ModerationReason: domainComment.ModerationReason,
+	}
+	if domainComment.ParentComment != nil {
+		val.ParentId = sql.NullInt64{
+			Int64: domainComment.ParentComment.Id,
+			Valid: true,
+		}
+	}
+	if domainComment.RootComment != nil {
+		val.RootId = sql.NullInt64{
+			Int64: domainComment.RootComment.Id,
+			Valid: true,
+		}
+	}
+	return val
+}
@@ -3,50 +3,503 @@ package dao
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"fmt"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"math"
+	"slices"
+	"time"
 )
 
+// hotEpoch 算热度的起点，2024-01-01 的毫秒数
+const hotEpoch = 1704067200000
+
+// hotScore 热度，互动多了十倍相当于晚发表了 12.5 个小时。
+// 只和时间的先后有关，不会随着时间衰减，所以存下来的热度不会过期。
+// 要和 incrCnt 里面的 SQL 保持一致
+func hotScore(likeCnt, replyCnt int64, ctime int64) float64 {
+	return math.Log10(float64(max(likeCnt+2*replyCnt, 1))) + float64(ctime-hotEpoch)/45000000
+}
+
 type gormCommentDAO struct {
 	db *gorm.DB
 }
 
-func (d *gormCommentDAO) Insert(ctx context.Context, comment Comment) error {
-	//TODO implement me
-	panic("implement me")
+func NewCommentDAO(db *gorm.DB) CommentDAO {
+	return &gormCommentDAO{
+		db: db,
+	}
+}
+
+// visible 评论列表里面能看到的评论，删掉了但是还有回复的也要
+func visible(db *gorm.DB) *gorm.DB {
+	return db.Where("status=? AND (deleted=? OR reply_cnt>0)", CommentStatusApproved, false)
+}
+
+func (d *gormCommentDAO) Insert(ctx context.Context, comment Comment, mentions []CommentMention) (int64, error) {
+	now := time.Now().UnixMilli()
+	comment.CTime = now
+	comment.UTime = now
+	comment.LikeCnt = 0
+	comment.ReplyCnt = 0
+	comment.HotScore = hotScore(0, 0, now)
+	err := d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		comment.RootId = sql.NullInt64{}
+		var parent Comment
+		if comment.ParentId.Valid {
+			// 锁住，防止插进去之前回复的评论被删了，后面还要更新它的回复数
+			err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+				Where("id=? AND status=? AND deleted=?", comment.ParentId.Int64, CommentStatusApproved, false).
+				First(&parent).Error
+			if err != nil {
+				return err
+			}
+			if parent.Biz != comment.Biz || parent.BizId != comment.BizId {
+				return ErrInvalidParent
+			}
+			comment.RootId = parent.RootId
+			if !parent.RootId.Valid {
+				comment.RootId = sql.NullInt64{Int64: parent.Id, Valid: true}
+			}
+		}
+		err := tx.Create(&comment).Error
+		if err != nil {
+			return err
+		}
+		if len(mentions) > 0 {
+			for i := range mentions {
+				mentions[i].Cid = comment.Id
+				mentions[i].CTime = now
+			}
+			err = tx.Create(&mentions).Error
+			if err != nil {
+				return err
+			}
+		}
+		if comment.Status != CommentStatusApproved {
+			return nil
+		}
+		err = d.recordEvent(tx, EventTypeCreated, comment, parent.Uid)
+		if err != nil {
+			return err
+		}
+		return d.adjustCnt(tx, comment, 1)
+	})
+	return comment.Id, err
+}
+
+// recordEvent 写发件箱，cmt 是变了之后的样子。parentUid 是 0 的话，回复要自己去查回复的是谁
+func (d *gormCommentDAO) recordEvent(tx *gorm.DB, eventType uint8, cmt Comment, parentUid int64) error {
+	if cmt.ParentId.Valid && parentUid == 0 {
+		err := tx.Model(&Comment{}).Where("id=?", cmt.ParentId.Int64).
+			Select("uid").Scan(&parentUid).Error
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Create(&CommentEvent{
+		Type:      eventType,
+		Cid:       cmt.Id,
+		Uid:       cmt.Uid,
+		Biz:       cmt.Biz,
+		BizId:     cmt.BizId,
+		ParentId:  cmt.ParentId.Int64,
+		RootId:    cmt.RootId.Int64,
+		ParentUid: parentUid,
+		Content:   cmt.Content,
+		Visible:   cmt.Status == CommentStatusApproved && !cmt.Deleted,
+		CTime:     time.Now().UnixMilli(),
+	}).Error
+}
+
+// adjustCnt cmt 能不能被看到变了，资源的评论数和回复数都要跟着改
+func (d *gormCommentDAO) adjustCnt(tx *gorm.DB, cmt Comment, delta int64) error {
+	err := d.incrBizCnt(tx, cmt.Biz, cmt.BizId, delta)
+	if err != nil {
+		return err
+	}
+	return d.incrReplyCnt(tx, cmt, delta)
+}
+
+func (d *gormCommentDAO) incrBizCnt(tx *gorm.DB, biz string, bizId int64, delta int64) error {
+	now := time.Now().UnixMilli()
+	return tx.Clauses(clause.OnConflict{
+		DoUpdates: clause.Assignments(map[string]any{
+			"cnt":    gorm.Expr("GREATEST(`cnt`+?, 0)", delta),
+			"u_time": now,
+		}),
+	}).Create(&CommentCnt{
+		Biz:   biz,
+		BizId: bizId,
+		Cnt:   max(delta, 0),
+		CTime: now,
+		UTime: now,
+	}).Error
+}
+
+// incrReplyCnt cmt 是回复的话，根评论和它直接回复的评论的回复数都要加
+func (d *gormCommentDAO) incrReplyCnt(tx *gorm.DB, cmt Comment, delta int64) error {
+	if !cmt.RootId.Valid {
+		return nil
+	}
+	err := d.incrCnt(tx, cmt.RootId.Int64, "reply_cnt", delta)
+	if err != nil || cmt.ParentId.Int64 == cmt.RootId.Int64 {
+		return err
+	}
+	return d.incrCnt(tx, cmt.ParentId.Int64, "reply_cnt", delta)
 }
 
-func (d *gormCommentDAO) FindByBiz(ctx context.Context, biz string, bizId, minId, limit int64) ([]Comment, error) {
+// changeStatus 改状态，审核通过和没通过之间变了要调整回复数。
+// eventType 是内容没变的时候要记的事件，审核通过之后第一次能被看到的算新建
+func (d *gormCommentDAO) changeStatus(tx *gorm.DB, cmt Comment, status uint8, updates map[string]any,
+	eventType uint8) error {
+	wasApproved, isApproved := cmt.Status == CommentStatusApproved, status == CommentStatusApproved
+	updates["status"] = status
+	if !isApproved && cmt.PinnedAt > 0 {
+		// 看不到了就不再置顶，不然会一直占着置顶的名额，重新通过审核之后要作者再置顶
+		updates["pinned_at"] = 0
+	}
+	err := tx.Model(&Comment{}).Where("id=?", cmt.Id).Updates(updates).Error
+	if err != nil {
+		return err
+	}
+	if wasApproved || isApproved {
+		if !wasApproved {
+			eventType = EventTypeCreated
+		}
+		updated := cmt
+		updated.Status = status
+		if content, ok := updates["content"].(string); ok {
+			updated.Content = content
+		}
+		err = d.recordEvent(tx, eventType, updated, 0)
+		if err != nil {
+			return err
+		}
+	}
+	switch {
+	case !wasApproved && isApproved:
+		return d.adjustCnt(tx, cmt, 1)
+	case wasApproved && !isApproved:
+		return d.adjustCnt(tx, cmt, -1)
+	default:
+		return nil
+	}
+}
+
+// incrCnt 计数加 delta，热度跟着重新算。
+// 热度要放在 SET 的最后，MySQL 会用更新之后的计数来算
+func (d *gormCommentDAO) incrCnt(tx *gorm.DB, id int64, column string, delta int64) error {
+	return tx.Exec(fmt.Sprintf("UPDATE `comments` SET `%[1]s`=GREATEST(`%[1]s`+?, 0), "+
+		"`hot_score`=LOG10(GREATEST(`like_cnt`+2*`reply_cnt`, 1))+(`c_time`-?)/45000000 WHERE `id`=?", column),
+		delta, hotEpoch, id).Error
+}
+
+func (d *gormCommentDAO) FindCnts(ctx context.Context, biz string, bizIds []int64) ([]CommentCnt, error) {
+	var res []CommentCnt
+	err := d.db.WithContext(ctx).Where("biz=? AND biz_id IN ?", biz, bizIds).Find(&res).Error
+	return res, err
+}
+
+func (d *gormCommentDAO) IncrLikeCnt(ctx context.Context, topic string, partition int32, deltas []LikeDelta) error {
+	if len(deltas) == 0 {
+		return nil
+	}
+	now := time.Now().UnixMilli()
+	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 第一次消费这个分区的时候还没有记录，先占个位置，一条消息都没处理过
+		err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&ConsumedOffset{
+			Topic:     topic,
+			Partition: partition,
+			Offset:    -1,
+			CTime:     now,
+			UTime:     now,
+		}).Error
+		if err != nil {
+			return err
+		}
+		var consumed ConsumedOffset
+		err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("topic=? AND `partition`=?", topic, partition).
+			First(&consumed).Error
+		if err != nil {
+			return err
+		}
+		last := consumed.Offset
+		cnts := make(map[int64]int64, len(deltas))
+		for _, delta := range deltas {
+			if delta.Offset <= consumed.Offset {
+				continue
+			}
+			cnts[delta.Cid] += delta.Delta
+			last = max(last, delta.Offset)
+		}
+		if last == consumed.Offset {
+			return nil
+		}
+		// 按照 id 的顺序更新，不同分区同时更新同一批评论的时候不会死锁
+		ids := make([]int64, 0, len(cnts))
+		for id, cnt := range cnts {
+			if cnt != 0 {
+				ids = append(ids, id)
+			}
+		}
+		slices.Sort(ids)
+		for _, id := range ids {
+			err = d.incrCnt(tx, id, "like_cnt", cnts[id])
+			if err != nil {
+				return err
+			}
+		}
+		return tx.Model(&ConsumedOffset{}).Where("id=?", consumed.Id).
+			Updates(map[string]any{
+				"offset": last,
+				"u_time": now,
+			}).Error
+	})
+}
+
+func (d *gormCommentDAO) FindByBiz(ctx context.Context, biz string, bizId int64, sort uint8, cursor Cursor, limit int64) ([]Comment, error) {
 	var comments []Comment
-	err := d.db.WithContext(ctx).
-		Where("biz=? AND biz_id=? AND id<? AND parent_id IS NULL", biz, bizId, minId).
-		Limit(int(limit)).
-		Find(&comments).Error
+	builder := d.db.WithContext(ctx).Scopes(visible).
+		Where("biz=? AND biz_id=? AND parent_id IS NULL AND pinned_at=0", biz, bizId)
+	switch sort {
+	case SortOldest:
+		if cursor.Id > 0 {
+			builder = builder.Where("id>?", cursor.Id)
+		}
+		builder = builder.Order("id ASC")
+	case SortHot:
+		// 热度一样的按照 id 排，翻页的时候不会重复也不会漏
+		if cursor.Id > 0 {
+			builder = builder.Where("hot_score<? OR (hot_score=? AND id<?)",
+				cursor.HotScore, cursor.HotScore, cursor.Id)
+		}
+		builder = builder.Order("hot_score DESC, id DESC")
+	default:
+		if cursor.Id > 0 {
+			builder = builder.Where("id<?", cursor.Id)
+		}
+		builder = builder.Order("id DESC")
+	}
+	err := builder.Limit(int(limit)).Find(&comments).Error
 	return comments, err
 }
 
+func (d *gormCommentDAO) FindPinned(ctx context.Context, biz string, bizId int64) ([]Comment, error) {
+	var res []Comment
+	err := d.db.WithContext(ctx).Scopes(visible).
+		Where("biz=? AND biz_id=? AND parent_id IS NULL AND pinned_at>0", biz, bizId).
+		Order("pinned_at DESC").
+		Find(&res).Error
+	return res, err
+}
+
+func (d *gormCommentDAO) Pin(ctx context.Context, id int64, limit int) error {
+	now := time.Now().UnixMilli()
+	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var cmt Comment
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id=? AND parent_id IS NULL AND status=? AND deleted=?", id, CommentStatusApproved, false).
+			First(&cmt).Error
+		if err != nil {
+			return err
+		}
+		if cmt.PinnedAt > 0 {
+			return nil
+		}
+		// 锁住资源的评论数那一行，同一个资源下面的置顶一个一个来，不然并发置顶不同的评论会超过 limit
+		err = d.incrBizCnt(tx, cmt.Biz, cmt.BizId, 0)
+		if err != nil {
+			return err
+		}
+		var cnt int64
+		err = tx.Model(&Comment{}).
+			Where("biz=? AND biz_id=? AND parent_id IS NULL AND pinned_at>0 AND status=? AND deleted=?",
+				cmt.Biz, cmt.BizId, CommentStatusApproved, false).
+			Count(&cnt).Error
+		if err != nil {
+			return err
+		}
+		if cnt >= int64(limit) {
+			return ErrTooManyPinned
+		}
+		return tx.Model(&Comment{}).Where("id=?", id).Updates(map[string]any{
+			"pinned_at": now,
+			"u_time":    now,
+		}).Error
+	})
+}
+
+func (d *gormCommentDAO) Unpin(ctx context.Context, id int64) error {
+	return d.db.WithContext(ctx).Model(&Comment{}).
+		Where("id=? AND pinned_at>0", id).
+		Updates(map[string]any{
+			"pinned_at": 0,
+			"u_time":    time.Now().UnixMilli(),
+		}).Error
+}
+
 func (d *gormCommentDAO) FindCommentList(ctx context.Context, u Comment) ([]Comment, error) {
-	//TODO implement me
-	panic("implement me")
+	var res []Comment
+	builder := d.db.WithContext(ctx).Scopes(visible)
+	if u.Id == 0 {
+		builder = builder.Where("biz=? AND biz_id=? AND root_id IS NULL", u.Biz, u.BizId)
+	} else {
+		builder = builder.Where("root_id=? OR id=?", u.Id, u.Id)
+	}
+	err := builder.Order("id ASC").Find(&res).Error
+	return res, err
 }
 
 func (d *gormCommentDAO) FindRepliesByPId(ctx context.Context, pid int64, offset, limit int) ([]Comment, error) {
-	//TODO implement me
-	panic("implement me")
+	var res []Comment
+	err := d.db.WithContext(ctx).Scopes(visible).
+		Where("parent_id=?", pid).
+		Order("id ASC").
+		Offset(offset).
+		Limit(limit).
+		Find(&res).Error
+	return res, err
 }
 
 func (d *gormCommentDAO) Delete(ctx context.Context, comment Comment) error {
-	//TODO implement me
-	panic("implement me")
+	now := time.Now().UnixMilli()
+	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var cmt Comment
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id=? AND deleted=?", comment.Id, false).First(&cmt).Error
+		if errors.Is(err, ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		err = tx.Create(&CommentHistory{
+			Cid:     cmt.Id,
+			Content: cmt.Content,
+			CTime:   now,
+		}).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Where("cid=?", cmt.Id).Delete(&CommentMention{}).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Model(&Comment{}).Where("id=?", cmt.Id).Updates(map[string]any{
+			"deleted":   true,
+			"content":   "",
+			"pinned_at": 0,
+			"u_time":    now,
+		}).Error
+		if err != nil || cmt.Status != CommentStatusApproved {
+			return err
+		}
+		deleted := cmt
+		deleted.Content = ""
+		deleted.Deleted = true
+		err = d.recordEvent(tx, EventTypeDeleted, deleted, 0)
+		if err != nil {
+			return err
+		}
+		// 删掉的不算回复数和评论数了，它自己下面的回复还算
+		return d.adjustCnt(tx, cmt, -1)
+	})
 }
 
 func (d *gormCommentDAO) FindOneByIds(ctx context.Context, ids []int64) ([]Comment, error) {
-	//TODO implement me
-	panic("implement me")
+	var res []Comment
+	err := d.db.WithContext(ctx).Where("id IN ?", ids).Find(&res).Error
+	return res, err
+}
+
+func (d *gormCommentDAO) FindMentions(ctx context.Context, cids []int64) ([]CommentMention, error) {
+	var res []CommentMention
+	err := d.db.WithContext(ctx).Where("cid IN ?", cids).Order("id ASC").Find(&res).Error
+	return res, err
 }
 
 func (d *gormCommentDAO) FindRepliesByRid(ctx context.Context, rid int64, offset, limit int64) ([]Comment, error) {
 	var res []Comment
-	err := d.db.WithContext(ctx).Where("root_id=? AND id >?", rid, offset).
+	err := d.db.WithContext(ctx).Scopes(visible).Where("root_id=? AND id >?", rid, offset).
 		Order("id ASC").Limit(int(limit)).Find(&res).Error
 	return res, err
 }
+
+func (d *gormCommentDAO) UpdateContent(ctx context.Context, comment Comment, mentions []CommentMention) error {
+	now := time.Now().UnixMilli()
+	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var cmt Comment
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id=? AND uid=? AND deleted=?", comment.Id, comment.Uid, false).First(&cmt).Error
+		if err != nil {
+			return err
+		}
+		if cmt.Content == comment.Content {
+			return nil
+		}
+		err = tx.Create(&CommentHistory{
+			Cid:     cmt.Id,
+			Content: cmt.Content,
+			CTime:   now,
+		}).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Where("cid=?", cmt.Id).Delete(&CommentMention{}).Error
+		if err != nil {
+			return err
+		}
+		if len(mentions) > 0 {
+			for i := range mentions {
+				mentions[i].Cid = cmt.Id
+				mentions[i].CTime = now
+			}
+			err = tx.Create(&mentions).Error
+			if err != nil {
+				return err
+			}
+		}
+		return d.changeStatus(tx, cmt, comment.Status, map[string]any{
+			"content":           comment.Content,
+			"edited":            true,
+			"moderation_reason": comment.ModerationReason,
+			"u_time":            now,
+		}, EventTypeEdited)
+	})
+}
+
+func (d *gormCommentDAO) FindByStatus(ctx context.Context, status uint8, minId, limit int64) ([]Comment, error) {
+	var res []Comment
+	err := d.db.WithContext(ctx).
+		Where("status=? AND deleted=? AND id>?", status, false, minId).
+		Order("id ASC").
+		Limit(int(limit)).
+		Find(&res).Error
+	return res, err
+}
+
+func (d *gormCommentDAO) UpdateStatus(ctx context.Context, id int64, from, to uint8) error {
+	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var cmt Comment
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id=? AND status=? AND deleted=?", id, from, false).First(&cmt).Error
+		if err != nil {
+			return err
+		}
+		return d.changeStatus(tx, cmt, to, map[string]any{}, EventTypeEdited)
+	})
+}
+
+func (d *gormCommentDAO) FindEvents(ctx context.Context, limit int) ([]CommentEvent, error) {
+	var res []CommentEvent
+	err := d.db.WithContext(ctx).Order("id ASC").Limit(limit).Find(&res).Error
+	return res, err
+}
+
+func (d *gormCommentDAO) DeleteEvents(ctx context.Context, ids []int64) error {
+	return d.db.WithContext(ctx).Where("id IN ?", ids).Delete(&CommentEvent{}).Error
+}
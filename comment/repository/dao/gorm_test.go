@@ -0,0 +1,364 @@
+// Copyright@daidai53 2024
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"testing"
+)
+
+func TestGormCommentDAO_changeStatus(t *testing.T) {
+	reply := Comment{
+		Id:       3,
+		Uid:      123,
+		Biz:      "article",
+		BizId:    1,
+		ParentId: sql.NullInt64{Int64: 2, Valid: true},
+		RootId:   sql.NullInt64{Int64: 1, Valid: true},
+	}
+	root := Comment{
+		Id:     1,
+		Uid:    123,
+		Biz:    "article",
+		BizId:  1,
+		Status: CommentStatusApproved,
+	}
+	testCases := []struct {
+		name   string
+		mock   func(mock sqlmock.Sqlmock)
+		cmt    Comment
+		status uint8
+	}{
+		{
+			name: "回复审核通过，算新建，评论数和两层回复数都加一",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("UPDATE `comments` SET `status`=\\? WHERE id=\\?").
+					WithArgs(CommentStatusApproved, int64(3)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("SELECT `uid` FROM `comments` WHERE id=\\?").
+					WithArgs(int64(2)).
+					WillReturnRows(sqlmock.NewRows([]string{"uid"}).AddRow(456))
+				mock.ExpectExec("INSERT INTO `comment_events` .*").
+					WithArgs(EventTypeCreated, int64(3), int64(123), "article", int64(1), int64(2), int64(1),
+						int64(456), "", true, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO `comment_cnts` .* ON DUPLICATE KEY UPDATE `cnt`=GREATEST\\(`cnt`\\+\\?, 0\\).*").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("UPDATE `comments` SET `reply_cnt`=GREATEST\\(`reply_cnt`\\+\\?, 0\\).* WHERE `id`=\\?").
+					WithArgs(int64(1), hotEpoch, int64(1)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE `comments` SET `reply_cnt`=GREATEST\\(`reply_cnt`\\+\\?, 0\\).* WHERE `id`=\\?").
+					WithArgs(int64(1), hotEpoch, int64(2)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			cmt: func() Comment {
+				c := reply
+				c.Status = CommentStatusPending
+				return c
+			}(),
+			status: CommentStatusApproved,
+		},
+		{
+			name: "根评论被拒绝，看不到了，评论数减一",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("UPDATE `comments` SET `status`=\\? WHERE id=\\?").
+					WithArgs(CommentStatusRejected, int64(1)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO `comment_events` .*").
+					WithArgs(EventTypeEdited, int64(1), int64(123), "article", int64(1), int64(0), int64(0),
+						int64(0), "", false, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO `comment_cnts` .*").
+					WithArgs("article", int64(1), int64(0), sqlmock.AnyArg(), sqlmock.AnyArg(),
+						int64(-1), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+			cmt:    root,
+			status: CommentStatusRejected,
+		},
+		{
+			name: "置顶的根评论被拒绝，不再置顶",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("UPDATE `comments` SET `pinned_at`=\\?,`status`=\\? WHERE id=\\?").
+					WithArgs(0, CommentStatusRejected, int64(1)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO `comment_events` .*").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO `comment_cnts` .*").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+			cmt: func() Comment {
+				c := root
+				c.PinnedAt = 123
+				return c
+			}(),
+			status: CommentStatusRejected,
+		},
+		{
+			name: "一直看不到，计数不变，也不记事件",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("UPDATE `comments` SET `status`=\\? WHERE id=\\?").
+					WithArgs(CommentStatusRejected, int64(3)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			cmt: func() Comment {
+				c := reply
+				c.Status = CommentStatusPending
+				return c
+			}(),
+			status: CommentStatusRejected,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sqlDB, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			tc.mock(mock)
+			d := &gormCommentDAO{db: newMockDB(t, sqlDB)}
+			err = d.changeStatus(d.db, tc.cmt, tc.status, map[string]any{}, EventTypeEdited)
+			assert.NoError(t, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestGormCommentDAO_adjustCnt(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(mock sqlmock.Sqlmock)
+		cmt  Comment
+	}{
+		{
+			name: "根评论只改资源的评论数",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("INSERT INTO `comment_cnts` .*").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+			cmt: Comment{Id: 1, Biz: "article", BizId: 1},
+		},
+		{
+			name: "直接回复根评论，根评论的回复数只改一次",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("INSERT INTO `comment_cnts` .*").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("UPDATE `comments` SET `reply_cnt`=.* WHERE `id`=\\?").
+					WithArgs(int64(-1), hotEpoch, int64(1)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			cmt: Comment{
+				Id:       2,
+				Biz:      "article",
+				BizId:    1,
+				ParentId: sql.NullInt64{Int64: 1, Valid: true},
+				RootId:   sql.NullInt64{Int64: 1, Valid: true},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sqlDB, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			tc.mock(mock)
+			d := &gormCommentDAO{db: newMockDB(t, sqlDB)}
+			err = d.adjustCnt(d.db, tc.cmt, -1)
+			assert.NoError(t, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestGormCommentDAO_Pin(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(mock sqlmock.Sqlmock)
+
+		wantErr error
+	}{
+		{
+			name: "先锁住资源，再数看得到的置顶",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT \\* FROM `comments` WHERE id=\\? .* FOR UPDATE").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "biz", "biz_id"}).AddRow(1, "article", 2))
+				mock.ExpectExec("INSERT INTO `comment_cnts` .* ON DUPLICATE KEY UPDATE `cnt`=GREATEST\\(`cnt`\\+\\?, 0\\).*").
+					WithArgs("article", int64(2), int64(0), sqlmock.AnyArg(), sqlmock.AnyArg(),
+						int64(0), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("SELECT count\\(\\*\\) FROM `comments` WHERE biz=\\? AND biz_id=\\? AND parent_id IS NULL "+
+					"AND pinned_at>0 AND status=\\? AND deleted=\\?").
+					WithArgs("article", int64(2), CommentStatusApproved, false).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+				mock.ExpectExec("UPDATE `comments` SET `pinned_at`=\\?,`u_time`=\\? WHERE id=\\?").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "置顶满了",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT \\* FROM `comments` .*").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "biz", "biz_id"}).AddRow(1, "article", 2))
+				mock.ExpectExec("INSERT INTO `comment_cnts` .*").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("SELECT count\\(\\*\\) FROM `comments` .*").
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+				mock.ExpectRollback()
+			},
+			wantErr: ErrTooManyPinned,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sqlDB, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			tc.mock(mock)
+			d := &gormCommentDAO{db: newMockDB(t, sqlDB)}
+			err = d.Pin(context.Background(), 1, 3)
+			assert.Equal(t, tc.wantErr, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestGormCommentDAO_IncrLikeCnt(t *testing.T) {
+	testCases := []struct {
+		name   string
+		mock   func(mock sqlmock.Sqlmock)
+		deltas []LikeDelta
+	}{
+		{
+			name: "处理过的跳过，剩下的合并之后按照 id 的顺序加",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO `consumed_offsets` .* ON DUPLICATE KEY UPDATE `id`=`id`").
+					WithArgs("interactive_like", int32(1), int64(-1), sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery("SELECT \\* FROM `consumed_offsets` WHERE topic=\\? AND `partition`=\\? "+
+					"ORDER BY `consumed_offsets`.`id` LIMIT 1 FOR UPDATE").
+					WithArgs("interactive_like", int32(1)).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "offset"}).AddRow(7, 10))
+				mock.ExpectExec("UPDATE `comments` SET `like_cnt`=.* WHERE `id`=\\?").
+					WithArgs(int64(2), hotEpoch, int64(1)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE `comments` SET `like_cnt`=.* WHERE `id`=\\?").
+					WithArgs(int64(-1), hotEpoch, int64(3)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE `consumed_offsets` SET `offset`=\\?,`u_time`=\\? WHERE id=\\?").
+					WithArgs(int64(13), sqlmock.AnyArg(), int64(7)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			deltas: []LikeDelta{
+				{Cid: 1, Delta: 1, Offset: 10},
+				{Cid: 3, Delta: -1, Offset: 11},
+				{Cid: 1, Delta: 1, Offset: 12},
+				{Cid: 1, Delta: 1, Offset: 13},
+			},
+		},
+		{
+			name: "整批都处理过了",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO `consumed_offsets` .*").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery("SELECT \\* FROM `consumed_offsets` .*").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "offset"}).AddRow(7, 10))
+				mock.ExpectCommit()
+			},
+			deltas: []LikeDelta{
+				{Cid: 1, Delta: 1, Offset: 9},
+				{Cid: 1, Delta: 1, Offset: 10},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sqlDB, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			tc.mock(mock)
+			d := &gormCommentDAO{db: newMockDB(t, sqlDB)}
+			err = d.IncrLikeCnt(context.Background(), "interactive_like", 1, tc.deltas)
+			assert.NoError(t, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestGormCommentDAO_FindByBiz(t *testing.T) {
+	testCases := []struct {
+		name   string
+		mock   func(mock sqlmock.Sqlmock)
+		sort   uint8
+		cursor Cursor
+	}{
+		{
+			name: "最新的在前面",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT \\* FROM `comments` WHERE \\(biz=\\? AND biz_id=\\? .*\\) AND id<\\? AND \\(status=\\? .*\\) ORDER BY id DESC LIMIT 10").
+					WithArgs("article", int64(1), int64(100), CommentStatusApproved, false).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			},
+			sort:   SortNewest,
+			cursor: Cursor{Id: 100},
+		},
+		{
+			name: "最早的在前面",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT \\* FROM `comments` WHERE \\(biz=\\? AND biz_id=\\? .*\\) AND id>\\? AND \\(status=\\? .*\\) ORDER BY id ASC LIMIT 10").
+					WithArgs("article", int64(1), int64(100), CommentStatusApproved, false).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			},
+			sort:   SortOldest,
+			cursor: Cursor{Id: 100},
+		},
+		{
+			name: "热度一样的按照 id 排",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT \\* FROM `comments` WHERE \\(biz=\\? AND biz_id=\\? .*\\) AND \\(hot_score<\\? OR \\(hot_score=\\? AND id<\\?\\)\\) "+
+					"AND \\(status=\\? .*\\) ORDER BY hot_score DESC, id DESC LIMIT 10").
+					WithArgs("article", int64(1), 1.5, 1.5, int64(100), CommentStatusApproved, false).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			},
+			sort:   SortHot,
+			cursor: Cursor{Id: 100, HotScore: 1.5},
+		},
+		{
+			name: "第一页没有游标",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT \\* FROM `comments` WHERE \\(.* AND pinned_at=0\\) AND \\(status=\\? .*\\) ORDER BY hot_score DESC, id DESC LIMIT 10").
+					WithArgs("article", int64(1), CommentStatusApproved, false).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			},
+			sort: SortHot,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sqlDB, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			tc.mock(mock)
+			d := NewCommentDAO(newMockDB(t, sqlDB))
+			_, err = d.FindByBiz(context.Background(), "article", 1, tc.sort, tc.cursor, 10)
+			assert.NoError(t, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func newMockDB(t *testing.T, sqlDB *sql.DB) *gorm.DB {
+	db, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      sqlDB,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{
+		DisableAutomaticPing:   true,
+		SkipDefaultTransaction: true,
+	})
+	require.NoError(t, err)
+	return db
+}
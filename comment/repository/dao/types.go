@@ -4,16 +4,97 @@ package dao
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"gorm.io/gorm"
 )
 
+var (
+	ErrRecordNotFound = gorm.ErrRecordNotFound
+	// ErrInvalidParent 回复的评论和自己不是同一个 biz 下面的
+	ErrInvalidParent = errors.New("回复的评论不对")
+	// ErrTooManyPinned 置顶的评论已经够多了
+	ErrTooManyPinned = errors.New("置顶的评论太多了")
+)
+
+func InitTables(db *gorm.DB) error {
+	return db.AutoMigrate(
+		&Comment{},
+		&CommentHistory{},
+		&CommentMention{},
+		&CommentCnt{},
+		&CommentEvent{},
+		&ConsumedOffset{},
+	)
+}
+
 type CommentDAO interface {
-	Insert(ctx context.Context, comment Comment) error
-	FindByBiz(ctx context.Context, biz string, bizId, minId, limit int64) ([]Comment, error)
+	// Insert 回复的话 root_id 会按照 parent_id 重新算，不用传，回复的评论要是审核通过的。
+	// mentions 和评论一起插入，Cid 不用传。返回评论的 id
+	Insert(ctx context.Context, comment Comment, mentions []CommentMention) (int64, error)
+	// FindByBiz 找没有置顶的根评论，按照 sort 排序，从 cursor 后面开始
+	FindByBiz(ctx context.Context, biz string, bizId int64, sort uint8, cursor Cursor, limit int64) ([]Comment, error)
+	// FindCommentList u.Id 是 0 的时候找 biz 下面所有的根评论，
+	// 不然找 u.Id 这条根评论和它下面所有的回复
 	FindCommentList(ctx context.Context, u Comment) ([]Comment, error)
 	FindRepliesByPId(ctx context.Context, pid int64, offset, limit int) ([]Comment, error)
+	// Delete 软删除，只清掉内容，回复都还在。本来就没有或者已经删了也算成功
 	Delete(ctx context.Context, comment Comment) error
 	FindOneByIds(ctx context.Context, ids []int64) ([]Comment, error)
+	// FindMentions 这些评论里面 @ 到的人
+	FindMentions(ctx context.Context, cids []int64) ([]CommentMention, error)
 	FindRepliesByRid(ctx context.Context, rid int64, offset, limit int64) ([]Comment, error)
+	// UpdateContent 只有自己能改，用的是 comment 里面的 Id、Uid、Content 和审核结果。
+	// 旧的内容记到 CommentHistory 里面，@ 到的人整个换成 mentions。
+	// 评论不存在、删掉了或者不是 uid 的返回 ErrRecordNotFound
+	UpdateContent(ctx context.Context, comment Comment, mentions []CommentMention) error
+	// FindByStatus 按照 id 从小到大，从 minId 后面开始
+	FindByStatus(ctx context.Context, status uint8, minId, limit int64) ([]Comment, error)
+	// UpdateStatus 状态是 from 的才改成 to，不然返回 ErrRecordNotFound
+	UpdateStatus(ctx context.Context, id int64, from, to uint8) error
+	// FindPinned 置顶的根评论，最后置顶的在前面
+	FindPinned(ctx context.Context, biz string, bizId int64) ([]Comment, error)
+	// Pin 置顶审核通过的根评论，同一个 biz 下面最多置顶 limit 条，多了返回 ErrTooManyPinned。
+	// 评论不存在或者不能置顶的返回 ErrRecordNotFound，已经置顶了也算成功
+	Pin(ctx context.Context, id int64, limit int) error
+	Unpin(ctx context.Context, id int64) error
+	// FindCnts 这些资源下面的评论数，没有评论过的不返回
+	FindCnts(ctx context.Context, biz string, bizIds []int64) ([]CommentCnt, error)
+	// IncrLikeCnt 点赞数加 delta，热度跟着重新算。
+	// 和 topic 的 partition 消费到的位置在同一个事务里面更新，Offset 不比记下来的大的跳过
+	IncrLikeCnt(ctx context.Context, topic string, partition int32, deltas []LikeDelta) error
+	// FindEvents 还没有发出去的事件，最早的在前面
+	FindEvents(ctx context.Context, limit int) ([]CommentEvent, error)
+	// DeleteEvents 发出去了就删掉
+	DeleteEvents(ctx context.Context, ids []int64) error
+}
+
+// 评论事件的类型，只有能被看到的评论，或者本来能被看到的评论才有事件
+const (
+	EventTypeUnknown uint8 = iota
+	EventTypeCreated
+	EventTypeEdited
+	EventTypeDeleted
+)
+
+const (
+	CommentStatusUnknown uint8 = iota
+	// CommentStatusApproved 审核通过了，只有这种能被看到，回复数也只算这种
+	CommentStatusApproved
+	CommentStatusPending
+	CommentStatusRejected
+)
+
+// 根评论的排序方式
+const (
+	SortNewest uint8 = iota
+	SortOldest
+	SortHot
+)
+
+// Cursor 上一页最后一条评论，Id 是 0 表示从头开始
+type Cursor struct {
+	Id       int64
+	HotScore float64
 }
 
 // 评论
@@ -21,15 +102,98 @@ type Comment struct {
 	Id  int64 `gorm:"autoIncrement,primaryKey"`
 	Uid int64 `gorm:"index"`
 
-	Biz   string `gorm:"index:biz_biz_id"`
-	BizId int64  `gorm:"index:biz_biz_id"`
+	Biz   string `gorm:"index:biz_biz_id;index:biz_biz_id_hot,priority:1"`
+	BizId int64  `gorm:"index:biz_biz_id;index:biz_biz_id_hot,priority:2"`
 
 	ParentId sql.NullInt64 `gorm:"index"`
 	RootId   sql.NullInt64 `gorm:"index"`
 	Content  string
+	// Edited 修改过内容
+	Edited bool
+	// Deleted 删掉了，内容挪到 CommentHistory 里面。
+	// 还有回复的话要留着占位，不然整个楼都看不到了
+	Deleted bool
+	// Status 以前的评论都是直接能看到的，所以默认是审核通过
+	Status           uint8 `gorm:"default:1;index"`
+	ModerationReason string
+
+	LikeCnt int64
+	// ReplyCnt 根评论是整个楼里面的回复数，回复是直接回复它的数量
+	ReplyCnt int64
+	// PinnedAt 置顶的时间，0 是没有置顶。只有根评论能置顶
+	PinnedAt int64
+	// HotScore 热度，点赞数、回复数和 CTime 变了都要重新算，见 hotScore
+	HotScore float64 `gorm:"index:biz_biz_id_hot,priority:3"`
+
+	ParentComment *Comment `gorm:"ForeignKey:ParentId;AssociationForeignKey:Id"`
 
-	ParentComment *Comment `gorm:"ForeignKey:PID;AssociationForeignKey:ID;constraint:OnDelete:CASCADE"`
+	CTime int64
+	UTime int64
+}
+
+// CommentHistory 评论修改或者删除之前的内容
+type CommentHistory struct {
+	Id      int64 `gorm:"autoIncrement,primaryKey"`
+	Cid     int64 `gorm:"index"`
+	Content string
+	CTime   int64
+}
+
+// CommentMention 评论里面 @ 到的人
+type CommentMention struct {
+	Id  int64 `gorm:"autoIncrement,primaryKey"`
+	Cid int64 `gorm:"index"`
+	// Uid 被 @ 的人
+	Uid      int64 `gorm:"index"`
+	Nickname string
+	// Start 和 Length 按照字符算
+	Start  int
+	Length int
+	CTime  int64
+}
 
+// CommentCnt 每个资源下面能看到的评论数，回复也算
+type CommentCnt struct {
+	Id    int64  `gorm:"autoIncrement,primaryKey"`
+	Biz   string `gorm:"type:varchar(128);uniqueIndex:biz_biz_id"`
+	BizId int64  `gorm:"uniqueIndex:biz_biz_id"`
+	Cnt   int64
 	CTime int64
 	UTime int64
 }
+
+// CommentEvent 发件箱，和评论的变化在同一个事务里面写进去，再由 job 发到 Kafka。
+// Kafka 挂了也不会丢事件
+type CommentEvent struct {
+	Id    int64 `gorm:"autoIncrement,primaryKey"`
+	Type  uint8
+	Cid   int64
+	Uid   int64
+	Biz   string
+	BizId int64
+	// ParentId、RootId 和 ParentUid 是 0 表示根评论
+	ParentId  int64
+	RootId    int64
+	ParentUid int64
+	Content   string
+	// Visible 评论现在能不能被看到
+	Visible bool
+	CTime   int64
+}
+
+// ConsumedOffset Kafka 的分区消费到了哪里，和消费的结果在同一个事务里面更新。
+// 重复消费的时候比它小的消息都处理过了
+type ConsumedOffset struct {
+	Id        int64  `gorm:"autoIncrement,primaryKey"`
+	Topic     string `gorm:"type:varchar(128);uniqueIndex:topic_partition"`
+	Partition int32  `gorm:"uniqueIndex:topic_partition"`
+	Offset    int64
+	CTime     int64
+	UTime     int64
+}
+
+type LikeDelta struct {
+	Cid    int64
+	Delta  int64
+	Offset int64
+}
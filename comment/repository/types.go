@@ -4,12 +4,41 @@ package repository
 import (
 	"context"
 	"github.com/daidai53/webook/comment/domain"
+	"github.com/daidai53/webook/comment/repository/dao"
+)
+
+var (
+	ErrCommentNotFound = dao.ErrRecordNotFound
+	ErrInvalidParent   = dao.ErrInvalidParent
+	ErrTooManyPinned   = dao.ErrTooManyPinned
 )
 
 type CommentRepository interface {
-	FindByBiz(ctx context.Context, biz string, bizId, minId, limit int64) ([]domain.Comment, error)
+	// FindByBiz 没有置顶的根评论，每条带上最早的几条回复
+	FindByBiz(ctx context.Context, biz string, bizId int64, sort domain.CommentSort, cursor domain.Cursor, limit int64) ([]domain.Comment, error)
+	// FindPinned 置顶的根评论，最后置顶的在前面，也带上最早的几条回复
+	FindPinned(ctx context.Context, biz string, bizId int64) ([]domain.Comment, error)
+	// Pin 同一个 biz 下面最多置顶 limit 条，不是审核通过的根评论返回 ErrCommentNotFound
+	Pin(ctx context.Context, id int64, limit int) error
+	Unpin(ctx context.Context, id int64) error
+	// DeleteComment 软删除，回复都还在
 	DeleteComment(ctx context.Context, cmt domain.Comment) error
-	CreateComment(ctx context.Context, cmt domain.Comment) error
+	// CreateComment 连同 @ 到的人一起保存，返回评论的 id
+	CreateComment(ctx context.Context, cmt domain.Comment) (int64, error)
 	GetCommentByIds(ctx context.Context, id []int64) ([]domain.Comment, error)
 	GetMoreReplies(ctx context.Context, rid, maxId, limit int64) ([]domain.Comment, error)
+	// EditComment 用的是 cmt 里面的 Id、Commentator、Content、Mentions 和审核结果，
+	// @ 到的人会整个换掉。评论不存在或者不是自己的返回 ErrCommentNotFound
+	EditComment(ctx context.Context, cmt domain.Comment) error
+	// FindPending 等人工审核的，最早的在前面，从 minId 后面开始
+	FindPending(ctx context.Context, minId, limit int64) ([]domain.Comment, error)
+	// UpdateStatus 状态是 from 的才改成 to，不然返回 ErrCommentNotFound
+	UpdateStatus(ctx context.Context, id int64, from, to domain.CommentStatus) error
+	// GetCnts 这些资源下面能看到的评论数，回复也算，没有评论的是 0
+	GetCnts(ctx context.Context, biz string, bizIds []int64) (map[int64]int64, error)
+	// IncrLikeCnt 把 topic 的 partition 里面的一批点赞事件加到评论的点赞数上，重复消费的事件会被跳过
+	IncrLikeCnt(ctx context.Context, topic string, partition int32, deltas []domain.LikeDelta) error
+	// FindEvents 发件箱里面还没有发出去的事件，最早的在前面
+	FindEvents(ctx context.Context, limit int) ([]domain.CommentEvent, error)
+	DeleteEvents(ctx context.Context, ids []int64) error
 }
@@ -0,0 +1,53 @@
+// Copyright@daidai53 2024
+package service
+
+import (
+	"context"
+	"github.com/daidai53/webook/comment/domain"
+	"github.com/daidai53/webook/pkg/logger"
+)
+
+// BlockChecker 拉黑关系，由关注服务那边实现
+type BlockChecker interface {
+	// Blockers 返回 candidates 里面拉黑了 uid 的人
+	Blockers(ctx context.Context, uid int64, candidates []int64) ([]int64, error)
+}
+
+// isBlocked 被资源的作者或者被回复的人拉黑了就不能评论。
+// 查不到的时候放过去，和 @ 一样不影响发评论
+func (c *commentService) isBlocked(ctx context.Context, cmt domain.Comment) bool {
+	uid := cmt.Commentator.Id
+	candidates := make([]int64, 0, 2)
+	owner, err := c.owners.Owner(ctx, cmt.Biz, cmt.BizId)
+	if err != nil {
+		c.l.Error("查询资源的作者失败",
+			logger.String("biz", cmt.Biz),
+			logger.Int64("biz_id", cmt.BizId),
+			logger.Error(err))
+	}
+	if owner != 0 && owner != uid {
+		candidates = append(candidates, owner)
+	}
+	if cmt.ParentComment != nil && cmt.ParentComment.Id != 0 {
+		parents, err := c.repo.GetCommentByIds(ctx, []int64{cmt.ParentComment.Id})
+		if err != nil {
+			c.l.Error("查询被回复的评论失败",
+				logger.Int64("pid", cmt.ParentComment.Id),
+				logger.Error(err))
+		}
+		if len(parents) > 0 && parents[0].Commentator.Id != uid {
+			candidates = append(candidates, parents[0].Commentator.Id)
+		}
+	}
+	if len(candidates) == 0 {
+		return false
+	}
+	blockers, err := c.blocks.Blockers(ctx, uid, candidates)
+	if err != nil {
+		c.l.Error("查询拉黑关系失败",
+			logger.Int64("uid", uid),
+			logger.Error(err))
+		return false
+	}
+	return len(blockers) > 0
+}
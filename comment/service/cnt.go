@@ -0,0 +1,17 @@
+// Copyright@daidai53 2024
+package service
+
+import "context"
+
+// maxCntBatchSize 一次最多查这么多个资源的评论数
+const maxCntBatchSize = 100
+
+func (c *commentService) GetCommentCnts(ctx context.Context, biz string, bizIds []int64) (map[int64]int64, error) {
+	if len(bizIds) > maxCntBatchSize {
+		return nil, ErrTooManyIds
+	}
+	if len(bizIds) == 0 {
+		return map[int64]int64{}, nil
+	}
+	return c.repo.GetCnts(ctx, biz, bizIds)
+}
@@ -4,31 +4,229 @@ package service
 import (
 	"context"
 	"github.com/daidai53/webook/comment/domain"
+	"github.com/daidai53/webook/comment/events"
+	"github.com/daidai53/webook/comment/moderation"
 	"github.com/daidai53/webook/comment/repository"
+	"github.com/daidai53/webook/pkg/logger"
+	"strings"
+	"time"
 )
 
 type commentService struct {
-	repo repository.CommentRepository
+	repo       repository.CommentRepository
+	checker    moderation.Checker
+	resolver   MentionResolver
+	owners     BizOwnerResolver
+	blocks     BlockChecker
+	producer   events.Producer
+	admins     map[int64]struct{}
+	moderators map[int64]struct{}
+	l          logger.LoggerV1
 }
 
-func (c *commentService) GetCommentList(ctx context.Context, biz string, bizId, minId, limit int64) ([]domain.Comment, error) {
-	list, err := c.repo.FindByBiz(ctx, biz, bizId, minId, limit)
+// NewCommentService admins 是管理员的 uid，moderators 是审核员的 uid，管理员也能审核
+func NewCommentService(repo repository.CommentRepository, checker moderation.Checker, resolver MentionResolver,
+	owners BizOwnerResolver, blocks BlockChecker, producer events.Producer, admins []int64, moderators []int64,
+	l logger.LoggerV1) CommentService {
+	return &commentService{
+		repo:       repo,
+		checker:    checker,
+		resolver:   resolver,
+		owners:     owners,
+		blocks:     blocks,
+		producer:   producer,
+		admins:     uidSet(admins),
+		moderators: uidSet(moderators),
+		l:          l,
+	}
+}
+
+func (c *commentService) GetCommentList(ctx context.Context, biz string, bizId int64, sort domain.CommentSort,
+	cursor domain.Cursor, limit int64) ([]domain.Comment, error) {
+	list, err := c.repo.FindByBiz(ctx, biz, bizId, sort, cursor, limit)
 	if err != nil {
 		return nil, err
 	}
+	if cursor.Id == 0 {
+		pinned, err := c.repo.FindPinned(ctx, biz, bizId)
+		if err != nil {
+			return nil, err
+		}
+		list = append(pinned, list...)
+	}
+	c.markAuthor(ctx, biz, bizId, list)
 	return list, nil
 }
 
-func (c *commentService) DeleteComment(ctx context.Context, id int64) error {
-	return c.repo.DeleteComment(ctx, domain.Comment{
-		Id: id,
-	})
+func (c *commentService) DeleteComment(ctx context.Context, id, uid int64) error {
+	cmts, err := c.repo.GetCommentByIds(ctx, []int64{id})
+	if err != nil {
+		return err
+	}
+	if len(cmts) == 0 || cmts[0].Deleted {
+		return nil
+	}
+	ok, err := c.canDelete(ctx, cmts[0], uid)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrPermissionDenied
+	}
+	return c.repo.DeleteComment(ctx, cmts[0])
+}
+
+func (c *commentService) CreateComment(ctx context.Context, cmt domain.Comment) (domain.Comment, error) {
+	if strings.TrimSpace(cmt.Content) == "" {
+		return domain.Comment{}, ErrEmptyContent
+	}
+	if c.isBlocked(ctx, cmt) {
+		return domain.Comment{}, ErrBlocked
+	}
+	res := c.moderate(ctx, cmt)
+	if res.Status == domain.CommentStatusRejected {
+		return domain.Comment{}, ErrCommentRejected
+	}
+	cmt.Status = res.Status
+	cmt.ModerationReason = res.Reason
+	cmt.Mentions = c.resolveMentions(ctx, cmt.Commentator.Id, cmt.Content)
+	id, err := c.repo.CreateComment(ctx, cmt)
+	if err != nil {
+		return domain.Comment{}, err
+	}
+	cmt.Id = id
+	if cmt.Status == domain.CommentStatusApproved {
+		c.produceMentionEvent(cmt, nil)
+	}
+	return cmt, nil
+}
+
+// moderate 自动审核出错了就转人工
+func (c *commentService) moderate(ctx context.Context, cmt domain.Comment) moderation.Result {
+	res, err := c.checker.Check(ctx, cmt)
+	if err != nil {
+		c.l.Error("自动审核评论失败",
+			logger.Int64("uid", cmt.Commentator.Id),
+			logger.Error(err))
+		return moderation.Pending("自动审核失败")
+	}
+	return res
+}
+
+func (c *commentService) ListPendingComments(ctx context.Context, uid, minId, limit int64) ([]domain.Comment, error) {
+	if !c.isModerator(uid) {
+		return nil, ErrPermissionDenied
+	}
+	return c.repo.FindPending(ctx, minId, limit)
 }
 
-func (c *commentService) CreateComment(ctx context.Context, cmt domain.Comment) error {
-	return c.repo.CreateComment(ctx, cmt)
+func (c *commentService) ReviewComment(ctx context.Context, id, uid int64, approved bool) error {
+	if !c.isModerator(uid) {
+		return ErrPermissionDenied
+	}
+	status := domain.CommentStatusRejected
+	if approved {
+		status = domain.CommentStatusApproved
+	}
+	err := c.repo.UpdateStatus(ctx, id, domain.CommentStatusPending, status)
+	if err != nil || !approved {
+		return err
+	}
+	// 审核通过了才通知被 @ 的人
+	cmts, err := c.repo.GetCommentByIds(ctx, []int64{id})
+	if err != nil {
+		c.l.Error("查询审核通过的评论失败",
+			logger.Int64("cid", id),
+			logger.Error(err))
+		return nil
+	}
+	if len(cmts) > 0 {
+		c.produceMentionEvent(cmts[0], nil)
+	}
+	return nil
 }
 
 func (c *commentService) GetMoreReplies(ctx context.Context, rid, maxId, limit int64) ([]domain.Comment, error) {
-	return c.repo.GetMoreReplies(ctx, rid, maxId, limit)
+	list, err := c.repo.GetMoreReplies(ctx, rid, maxId, limit)
+	if err != nil || len(list) == 0 {
+		return list, err
+	}
+	c.markAuthor(ctx, list[0].Biz, list[0].BizId, list)
+	return list, nil
+}
+
+func (c *commentService) EditComment(ctx context.Context, id, uid int64, content string) (domain.CommentStatus, error) {
+	if strings.TrimSpace(content) == "" {
+		return domain.CommentStatusUnknown, ErrEmptyContent
+	}
+	olds, err := c.repo.GetCommentByIds(ctx, []int64{id})
+	if err != nil {
+		return domain.CommentStatusUnknown, err
+	}
+	if len(olds) == 0 || olds[0].Commentator.Id != uid || olds[0].Deleted {
+		return domain.CommentStatusUnknown, repository.ErrCommentNotFound
+	}
+	old := olds[0]
+	cmt := old
+	cmt.Content = content
+	res := c.moderate(ctx, cmt)
+	switch {
+	case res.Status == domain.CommentStatusRejected:
+		return domain.CommentStatusUnknown, ErrCommentRejected
+	case old.Status == domain.CommentStatusPending && res.Status == domain.CommentStatusApproved:
+		// 还没有人工审核过的，改了也还是要人工审核
+		res = moderation.Pending(old.ModerationReason)
+	}
+	cmt.Status = res.Status
+	cmt.ModerationReason = res.Reason
+	cmt.Mentions = c.resolveMentions(ctx, uid, content)
+	err = c.repo.EditComment(ctx, cmt)
+	if err != nil {
+		return domain.CommentStatusUnknown, err
+	}
+	if cmt.Status == domain.CommentStatusApproved {
+		// 之前已经 @ 过的人不用再通知一次
+		var notified []domain.Mention
+		if old.Status == domain.CommentStatusApproved {
+			notified = old.Mentions
+		}
+		c.produceMentionEvent(cmt, notified)
+	}
+	return cmt.Status, nil
+}
+
+// produceMentionEvent notified 里面的人已经通知过了
+func (c *commentService) produceMentionEvent(cmt domain.Comment, notified []domain.Mention) {
+	seen := make(map[int64]struct{}, len(cmt.Mentions)+len(notified))
+	for _, mention := range notified {
+		seen[mention.Uid] = struct{}{}
+	}
+	var uids []int64
+	for _, mention := range cmt.Mentions {
+		if _, ok := seen[mention.Uid]; ok {
+			continue
+		}
+		seen[mention.Uid] = struct{}{}
+		uids = append(uids, mention.Uid)
+	}
+	if len(uids) == 0 {
+		return
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		err := c.producer.ProduceMentionEvent(ctx, events.MentionEvent{
+			Cid:       cmt.Id,
+			Uid:       cmt.Commentator.Id,
+			Biz:       cmt.Biz,
+			BizId:     cmt.BizId,
+			Mentioned: uids,
+			Ctime:     time.Now().UnixMilli(),
+		})
+		if err != nil {
+			c.l.Error("发送 @ 事件失败",
+				logger.Int64("cid", cmt.Id),
+				logger.Error(err))
+		}
+	}()
 }
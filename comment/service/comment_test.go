@@ -0,0 +1,105 @@
+// Copyright@daidai53 2024
+package service
+
+import (
+	"context"
+	"github.com/daidai53/webook/comment/domain"
+	"github.com/daidai53/webook/comment/repository"
+	repomocks "github.com/daidai53/webook/comment/repository/mocks"
+	"github.com/daidai53/webook/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+)
+
+func TestCommentService_ListPendingComments(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) repository.CommentRepository
+		uid  int64
+
+		wantCmts []domain.Comment
+		wantErr  error
+	}{
+		{
+			name: "审核员查询",
+			mock: func(ctrl *gomock.Controller) repository.CommentRepository {
+				repo := repomocks.NewMockCommentRepository(ctrl)
+				repo.EXPECT().FindPending(gomock.Any(), int64(0), int64(10)).
+					Return([]domain.Comment{{Id: 1}}, nil)
+				return repo
+			},
+			uid:      2,
+			wantCmts: []domain.Comment{{Id: 1}},
+		},
+		{
+			name: "管理员也能查",
+			mock: func(ctrl *gomock.Controller) repository.CommentRepository {
+				repo := repomocks.NewMockCommentRepository(ctrl)
+				repo.EXPECT().FindPending(gomock.Any(), int64(0), int64(10)).
+					Return([]domain.Comment{{Id: 1}}, nil)
+				return repo
+			},
+			uid:      1,
+			wantCmts: []domain.Comment{{Id: 1}},
+		},
+		{
+			name: "不是审核员",
+			mock: func(ctrl *gomock.Controller) repository.CommentRepository {
+				return repomocks.NewMockCommentRepository(ctrl)
+			},
+			uid:     3,
+			wantErr: ErrPermissionDenied,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			svc := NewCommentService(tc.mock(ctrl), nil, nil, nil, nil, nil,
+				[]int64{1}, []int64{2}, logger.NewNopLogger())
+			cmts, err := svc.ListPendingComments(context.Background(), tc.uid, 0, 10)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantCmts, cmts)
+		})
+	}
+}
+
+func TestCommentService_ReviewComment(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) repository.CommentRepository
+		uid  int64
+
+		wantErr error
+	}{
+		{
+			name: "审核员拒绝",
+			mock: func(ctrl *gomock.Controller) repository.CommentRepository {
+				repo := repomocks.NewMockCommentRepository(ctrl)
+				repo.EXPECT().UpdateStatus(gomock.Any(), int64(10),
+					domain.CommentStatusPending, domain.CommentStatusRejected).Return(nil)
+				return repo
+			},
+			uid: 2,
+		},
+		{
+			name: "不是审核员",
+			mock: func(ctrl *gomock.Controller) repository.CommentRepository {
+				return repomocks.NewMockCommentRepository(ctrl)
+			},
+			uid:     3,
+			wantErr: ErrPermissionDenied,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			svc := NewCommentService(tc.mock(ctrl), nil, nil, nil, nil, nil,
+				[]int64{1}, []int64{2}, logger.NewNopLogger())
+			err := svc.ReviewComment(context.Background(), 10, tc.uid, false)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
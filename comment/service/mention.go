@@ -0,0 +1,67 @@
+// Copyright@daidai53 2024
+package service
+
+import (
+	"context"
+	"github.com/daidai53/webook/comment/domain"
+	"github.com/daidai53/webook/pkg/logger"
+	"regexp"
+	"unicode/utf8"
+)
+
+// maxMentions 一条评论最多 @ 这么多人，多了的不算
+const maxMentions = 10
+
+// mentionRegexp 昵称到空白或者标点为止
+var mentionRegexp = regexp.MustCompile(`@([^\s@,，.。:：;；!！?？、()（）\[\]【】"'“”‘’]+)`)
+
+// MentionResolver 把昵称换成用户 id，由用户服务那边实现
+type MentionResolver interface {
+	// Resolve 返回 uid 可以 @ 的人，key 是昵称。
+	// 找不到的、重名的、拉黑了 uid 的都不返回
+	Resolve(ctx context.Context, uid int64, nicknames []string) (map[string]int64, error)
+}
+
+// parseMentions 找出内容里面的 @昵称，这时候还没有 uid
+func parseMentions(content string) []domain.Mention {
+	matches := mentionRegexp.FindAllStringSubmatchIndex(content, maxMentions)
+	res := make([]domain.Mention, 0, len(matches))
+	for _, match := range matches {
+		res = append(res, domain.Mention{
+			Nickname: content[match[2]:match[3]],
+			Start:    utf8.RuneCountInString(content[:match[0]]),
+			Length:   utf8.RuneCountInString(content[match[0]:match[1]]),
+		})
+	}
+	return res
+}
+
+// resolveMentions 找不到的人直接忽略，用户服务出问题了也不影响发评论
+func (c *commentService) resolveMentions(ctx context.Context, uid int64, content string) []domain.Mention {
+	mentions := parseMentions(content)
+	if len(mentions) == 0 {
+		return nil
+	}
+	nicknames := make([]string, 0, len(mentions))
+	for _, mention := range mentions {
+		nicknames = append(nicknames, mention.Nickname)
+	}
+	uids, err := c.resolver.Resolve(ctx, uid, nicknames)
+	if err != nil {
+		c.l.Error("查询 @ 的人失败",
+			logger.Int64("uid", uid),
+			logger.Error(err))
+		return nil
+	}
+	res := make([]domain.Mention, 0, len(mentions))
+	for _, mention := range mentions {
+		mentioned, ok := uids[mention.Nickname]
+		// 自己 @ 自己也不算
+		if !ok || mentioned == uid {
+			continue
+		}
+		mention.Uid = mentioned
+		res = append(res, mention)
+	}
+	return res
+}
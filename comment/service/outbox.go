@@ -0,0 +1,113 @@
+// Copyright@daidai53 2024
+package service
+
+import (
+	"context"
+	"github.com/daidai53/webook/comment/domain"
+	"github.com/daidai53/webook/comment/events"
+	"github.com/daidai53/webook/comment/repository"
+)
+
+// OutboxService 把发件箱里面的评论事件发到 Kafka
+type OutboxService interface {
+	// Relay 发一批，返回发了多少条。发失败了事件还留在发件箱里面，下次再发
+	Relay(ctx context.Context, limit int) (int, error)
+}
+
+type outboxService struct {
+	repo     repository.CommentRepository
+	producer events.Producer
+}
+
+func NewOutboxService(repo repository.CommentRepository, producer events.Producer) OutboxService {
+	return &outboxService{
+		repo:     repo,
+		producer: producer,
+	}
+}
+
+func (o *outboxService) Relay(ctx context.Context, limit int) (int, error) {
+	evts, err := o.repo.FindEvents(ctx, limit)
+	if err != nil || len(evts) == 0 {
+		return 0, err
+	}
+	msgs := make([]events.CommentEvent, 0, len(evts))
+	ids := make([]int64, 0, len(evts))
+	for _, evt := range evts {
+		msgs = append(msgs, o.toEvent(evt))
+		ids = append(ids, evt.Id)
+	}
+	err = o.producer.ProduceCommentEvents(ctx, msgs)
+	if err != nil {
+		return 0, err
+	}
+	err = o.produceCntEvents(ctx, evts)
+	if err != nil {
+		return 0, err
+	}
+	// 删失败了下次会重复发，消费者要能处理重复的事件
+	return len(evts), o.repo.DeleteEvents(ctx, ids)
+}
+
+// produceCntEvents 评论的变化都可能改变评论数，每个资源查一下最新的总数发出去，给互动服务那边同步。
+// 发的是总数，重复发也没关系
+func (o *outboxService) produceCntEvents(ctx context.Context, evts []domain.CommentEvent) error {
+	bizIds := make(map[string][]int64, 1)
+	seen := make(map[string]map[int64]struct{}, 1)
+	for _, evt := range evts {
+		biz, bizId := evt.Comment.Biz, evt.Comment.BizId
+		if seen[biz] == nil {
+			seen[biz] = make(map[int64]struct{}, len(evts))
+		}
+		if _, ok := seen[biz][bizId]; ok {
+			continue
+		}
+		seen[biz][bizId] = struct{}{}
+		bizIds[biz] = append(bizIds[biz], bizId)
+	}
+	for biz, ids := range bizIds {
+		cnts, err := o.repo.GetCnts(ctx, biz, ids)
+		if err != nil {
+			return err
+		}
+		for _, bizId := range ids {
+			err = o.producer.ProduceCntEvent(ctx, events.CntEvent{
+				Biz:   biz,
+				BizId: bizId,
+				Cnt:   cnts[bizId],
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (o *outboxService) toEvent(evt domain.CommentEvent) events.CommentEvent {
+	res := events.CommentEvent{
+		Cid:       evt.Comment.Id,
+		Uid:       evt.Comment.Commentator.Id,
+		Biz:       evt.Comment.Biz,
+		BizId:     evt.Comment.BizId,
+		ParentUid: evt.ParentUid,
+		Content:   evt.Comment.Content,
+		Visible:   evt.Visible,
+		Ctime:     evt.CTime.UnixMilli(),
+	}
+	switch evt.Type {
+	case domain.CommentEventTypeCreated:
+		res.Type = events.CommentEventTypeCreated
+	case domain.CommentEventTypeEdited:
+		res.Type = events.CommentEventTypeEdited
+	case domain.CommentEventTypeDeleted:
+		res.Type = events.CommentEventTypeDeleted
+	}
+	if evt.Comment.ParentComment != nil {
+		res.ParentId = evt.Comment.ParentComment.Id
+	}
+	if evt.Comment.RootComment != nil {
+		res.RootId = evt.Comment.RootComment.Id
+	}
+	return res
+}
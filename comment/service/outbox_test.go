@@ -0,0 +1,82 @@
+// Copyright@daidai53 2024
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/daidai53/webook/comment/domain"
+	"github.com/daidai53/webook/comment/events"
+	"github.com/daidai53/webook/comment/repository"
+	repomocks "github.com/daidai53/webook/comment/repository/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+)
+
+func TestOutboxService_Relay(t *testing.T) {
+	evts := []domain.CommentEvent{
+		{Id: 1, Type: domain.CommentEventTypeCreated, Comment: domain.Comment{Id: 11, Biz: "article", BizId: 1}},
+		{Id: 2, Type: domain.CommentEventTypeDeleted, Comment: domain.Comment{Id: 12, Biz: "article", BizId: 1}},
+	}
+	testCases := []struct {
+		name   string
+		mock   func(ctrl *gomock.Controller) repository.CommentRepository
+		cntErr error
+
+		wantCnt    int
+		wantCntEvt []events.CntEvent
+		wantErr    error
+	}{
+		{
+			name: "同一个资源的评论数只发一次",
+			mock: func(ctrl *gomock.Controller) repository.CommentRepository {
+				repo := repomocks.NewMockCommentRepository(ctrl)
+				repo.EXPECT().FindEvents(gomock.Any(), 10).Return(evts, nil)
+				repo.EXPECT().GetCnts(gomock.Any(), "article", []int64{1}).Return(map[int64]int64{1: 5}, nil)
+				repo.EXPECT().DeleteEvents(gomock.Any(), []int64{1, 2}).Return(nil)
+				return repo
+			},
+			wantCnt:    2,
+			wantCntEvt: []events.CntEvent{{Biz: "article", BizId: 1, Cnt: 5}},
+		},
+		{
+			name: "评论数没发出去，事件留着下次再发",
+			mock: func(ctrl *gomock.Controller) repository.CommentRepository {
+				repo := repomocks.NewMockCommentRepository(ctrl)
+				repo.EXPECT().FindEvents(gomock.Any(), 10).Return(evts, nil)
+				repo.EXPECT().GetCnts(gomock.Any(), "article", []int64{1}).Return(map[int64]int64{1: 5}, nil)
+				return repo
+			},
+			cntErr:     errors.New("mock error"),
+			wantCntEvt: []events.CntEvent{{Biz: "article", BizId: 1, Cnt: 5}},
+			wantErr:    errors.New("mock error"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			producer := &stubProducer{cntErr: tc.cntErr}
+			svc := NewOutboxService(tc.mock(ctrl), producer)
+			cnt, err := svc.Relay(context.Background(), 10)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantCnt, cnt)
+			assert.Equal(t, tc.wantCntEvt, producer.cntEvts)
+		})
+	}
+}
+
+type stubProducer struct {
+	events.Producer
+	cntErr  error
+	cntEvts []events.CntEvent
+}
+
+func (s *stubProducer) ProduceCntEvent(ctx context.Context, evt events.CntEvent) error {
+	s.cntEvts = append(s.cntEvts, evt)
+	return s.cntErr
+}
+
+func (s *stubProducer) ProduceCommentEvents(ctx context.Context, evts []events.CommentEvent) error {
+	return nil
+}
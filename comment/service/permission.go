@@ -0,0 +1,76 @@
+// Copyright@daidai53 2024
+package service
+
+import (
+	"context"
+	"github.com/daidai53/webook/comment/domain"
+	"github.com/daidai53/webook/pkg/logger"
+)
+
+// BizOwnerResolver 找出被评论的资源的作者，由对应的业务实现
+type BizOwnerResolver interface {
+	// Owner 资源不存在的时候返回 0
+	Owner(ctx context.Context, biz string, bizId int64) (int64, error)
+}
+
+func (c *commentService) isAdmin(uid int64) bool {
+	_, ok := c.admins[uid]
+	return ok
+}
+
+// isModerator 审核员和管理员都能人工审核评论
+func (c *commentService) isModerator(uid int64) bool {
+	_, ok := c.moderators[uid]
+	return ok || c.isAdmin(uid)
+}
+
+func uidSet(uids []int64) map[int64]struct{} {
+	res := make(map[int64]struct{}, len(uids))
+	for _, uid := range uids {
+		res[uid] = struct{}{}
+	}
+	return res
+}
+
+// isBizOwner uid 是不是被评论的资源的作者
+func (c *commentService) isBizOwner(ctx context.Context, biz string, bizId, uid int64) (bool, error) {
+	owner, err := c.owners.Owner(ctx, biz, bizId)
+	if err != nil {
+		return false, err
+	}
+	return owner != 0 && owner == uid, nil
+}
+
+func (c *commentService) canDelete(ctx context.Context, cmt domain.Comment, uid int64) (bool, error) {
+	if uid == 0 {
+		return false, nil
+	}
+	if cmt.Commentator.Id == uid || c.isAdmin(uid) {
+		return true, nil
+	}
+	return c.isBizOwner(ctx, cmt.Biz, cmt.BizId, uid)
+}
+
+// markAuthor 标出资源的作者发的评论，查不到作者的时候就不标了
+func (c *commentService) markAuthor(ctx context.Context, biz string, bizId int64, comments []domain.Comment) {
+	if len(comments) == 0 {
+		return
+	}
+	owner, err := c.owners.Owner(ctx, biz, bizId)
+	if err != nil {
+		c.l.Error("查询资源的作者失败",
+			logger.String("biz", biz),
+			logger.Int64("biz_id", bizId),
+			logger.Error(err))
+		return
+	}
+	if owner == 0 {
+		return
+	}
+	for i := range comments {
+		comments[i].IsAuthor = comments[i].Commentator.Id == owner
+		for j := range comments[i].Children {
+			comments[i].Children[j].IsAuthor = comments[i].Children[j].Commentator.Id == owner
+		}
+	}
+}
@@ -0,0 +1,45 @@
+// Copyright@daidai53 2024
+package service
+
+import (
+	"context"
+	"github.com/daidai53/webook/comment/repository"
+)
+
+// maxPinned 同一个资源下面最多置顶这么多条评论
+const maxPinned = 3
+
+func (c *commentService) PinComment(ctx context.Context, id, uid int64) error {
+	err := c.checkBizOwner(ctx, id, uid)
+	if err != nil {
+		return err
+	}
+	return c.repo.Pin(ctx, id, maxPinned)
+}
+
+func (c *commentService) UnpinComment(ctx context.Context, id, uid int64) error {
+	err := c.checkBizOwner(ctx, id, uid)
+	if err != nil {
+		return err
+	}
+	return c.repo.Unpin(ctx, id)
+}
+
+// checkBizOwner uid 要是评论 id 所在的资源的作者
+func (c *commentService) checkBizOwner(ctx context.Context, id, uid int64) error {
+	cmts, err := c.repo.GetCommentByIds(ctx, []int64{id})
+	if err != nil {
+		return err
+	}
+	if len(cmts) == 0 {
+		return repository.ErrCommentNotFound
+	}
+	ok, err := c.isBizOwner(ctx, cmts[0].Biz, cmts[0].BizId, uid)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrPermissionDenied
+	}
+	return nil
+}
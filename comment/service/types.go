@@ -3,12 +3,46 @@ package service
 
 import (
 	"context"
+	"errors"
 	"github.com/daidai53/webook/comment/domain"
+	"github.com/daidai53/webook/comment/repository"
+)
+
+var (
+	ErrEmptyContent = errors.New("评论内容不能为空")
+	// ErrCommentRejected 没有通过自动审核，不会保存
+	ErrCommentRejected = errors.New("评论没有通过审核")
+	// ErrPermissionDenied 不是评论的作者、被评论的资源的作者，也不是管理员，或者不是审核员
+	ErrPermissionDenied = errors.New("没有权限")
+	ErrTooManyPinned    = repository.ErrTooManyPinned
+	ErrTooManyIds       = errors.New("一次查询的资源太多了")
+	// ErrBlocked 被资源的作者或者被回复的人拉黑了
+	ErrBlocked = errors.New("已经被拉黑了")
 )
 
 type CommentService interface {
-	GetCommentList(ctx context.Context, biz string, bizId, minId, limit int64) ([]domain.Comment, error)
-	DeleteComment(ctx context.Context, id int64) error
-	CreateComment(ctx context.Context, cmt domain.Comment) error
+	// GetCommentList 根评论，按照 sort 排序，从 cursor 后面开始。
+	// 第一页前面额外带上置顶的评论，不算在 limit 里面
+	GetCommentList(ctx context.Context, biz string, bizId int64, sort domain.CommentSort, cursor domain.Cursor, limit int64) ([]domain.Comment, error)
+	// DeleteComment uid 是操作的人，评论的作者、被评论的资源的作者和管理员能删。
+	// 软删除，回复都还在。评论本来就没有也算成功
+	DeleteComment(ctx context.Context, id, uid int64) error
+	// CreateComment 被资源的作者或者被回复的人拉黑了返回 ErrBlocked。
+	// 再过一遍自动审核，拒绝了返回 ErrCommentRejected。
+	// 内容里面的 @昵称 会换成用户 id 存下来，审核通过了再发 @ 事件。
+	// 返回保存的评论，Status 说明是不是要等人工审核
+	CreateComment(ctx context.Context, cmt domain.Comment) (domain.Comment, error)
 	GetMoreReplies(ctx context.Context, rid, maxId, limit int64) ([]domain.Comment, error)
+	// PinComment 只有被评论的资源的作者能置顶，只能置顶审核通过的根评论，最多置顶 maxPinned 条
+	PinComment(ctx context.Context, id, uid int64) error
+	UnpinComment(ctx context.Context, id, uid int64) error
+	// EditComment 只能改自己的评论，改之前的内容会留一份历史。
+	// 改了的内容也要审核，返回改完之后的状态
+	EditComment(ctx context.Context, id, uid int64, content string) (domain.CommentStatus, error)
+	// GetCommentCnts 资源下面能看到的评论数，回复也算，一次最多查 maxCntBatchSize 个
+	GetCommentCnts(ctx context.Context, biz string, bizIds []int64) (map[int64]int64, error)
+	// ListPendingComments 审核员用，最早的在前面，从 minId 后面开始。uid 不是审核员返回 ErrPermissionDenied
+	ListPendingComments(ctx context.Context, uid, minId, limit int64) ([]domain.Comment, error)
+	// ReviewComment 审核员用，只能审核待审核的评论。uid 不是审核员返回 ErrPermissionDenied
+	ReviewComment(ctx context.Context, id, uid int64, approved bool) error
 }
@@ -0,0 +1,71 @@
+// Copyright@daidai53 2024
+package domain
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+)
+
+var ErrInvalidCursor = errors.New("非法的翻页游标")
+
+// FeedCursor 翻页用的游标，指向上一页的最后一条
+// 所有事件按照 (Ctime, Origin, ID) 降序排列，下一页只要严格排在游标后面的事件
+// 只用 Ctime 的话，同一毫秒的事件在翻页的时候会重复或者遗漏；
+// 收件箱和发件箱的 ID 会重复，所以要先用 Origin 区分
+type FeedCursor struct {
+	// Ctime 毫秒数
+	Ctime  int64
+	Origin EventOrigin
+	ID     int64
+}
+
+// CursorOf 事件在排序里面的位置
+func CursorOf(evt FeedEvent) FeedCursor {
+	return FeedCursor{
+		Ctime:  evt.Ctime.UnixMilli(),
+		Origin: evt.Origin,
+		ID:     evt.ID,
+	}
+}
+
+// IsZero 零值游标就是从最新的开始
+func (c FeedCursor) IsZero() bool {
+	return c == FeedCursor{}
+}
+
+// Less c 排在 o 后面
+func (c FeedCursor) Less(o FeedCursor) bool {
+	if c.Ctime != o.Ctime {
+		return c.Ctime < o.Ctime
+	}
+	if c.Origin != o.Origin {
+		return c.Origin < o.Origin
+	}
+	return c.ID < o.ID
+}
+
+// Encode 对客户端来说游标是不透明的，不要让客户端依赖里面的结构
+func (c FeedCursor) Encode() string {
+	if c.IsZero() {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf("%d:%d:%d", c.Ctime, c.Origin, c.ID)))
+}
+
+// DecodeFeedCursor 空字符串就是零值游标
+func DecodeFeedCursor(s string) (FeedCursor, error) {
+	var c FeedCursor
+	if s == "" {
+		return c, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return c, fmt.Errorf("%w, %s", ErrInvalidCursor, s)
+	}
+	_, err = fmt.Sscanf(string(data), "%d:%d:%d", &c.Ctime, &c.Origin, &c.ID)
+	if err != nil {
+		return FeedCursor{}, fmt.Errorf("%w, %s", ErrInvalidCursor, s)
+	}
+	return c, nil
+}
@@ -0,0 +1,67 @@
+// Copyright@daidai53 2024
+package domain
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestFeedCursor(t *testing.T) {
+	testCases := []struct {
+		name   string
+		cursor string
+
+		want    FeedCursor
+		wantErr error
+	}{
+		{
+			name:   "空字符串是零值",
+			cursor: "",
+			want:   FeedCursor{},
+		},
+		{
+			name:   "编码之后能解回来",
+			cursor: FeedCursor{Ctime: 1700000000000, Origin: EventOriginPull, ID: 12}.Encode(),
+			want:   FeedCursor{Ctime: 1700000000000, Origin: EventOriginPull, ID: 12},
+		},
+		{
+			name:    "不是 base64",
+			cursor:  "@@@",
+			wantErr: ErrInvalidCursor,
+		},
+		{
+			name:    "格式不对",
+			cursor:  "YWJj",
+			wantErr: ErrInvalidCursor,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, err := DecodeFeedCursor(tc.cursor)
+			assert.ErrorIs(t, err, tc.wantErr)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, tc.want, c)
+		})
+	}
+}
+
+func TestFeedCursor_Less(t *testing.T) {
+	// 同一毫秒的事件先靠 Origin 区分，再靠 ID 区分先后
+	a := FeedCursor{Ctime: 100, Origin: EventOriginPush, ID: 2}
+	b := FeedCursor{Ctime: 100, Origin: EventOriginPush, ID: 3}
+	c := FeedCursor{Ctime: 100, Origin: EventOriginPull, ID: 1}
+	d := FeedCursor{Ctime: 101, Origin: EventOriginPush, ID: 1}
+	require.True(t, a.Less(b))
+	require.True(t, b.Less(c))
+	require.True(t, c.Less(d))
+	require.False(t, a.Less(a))
+	require.False(t, d.Less(a))
+	// 收件箱和发件箱的 ID 一样也不会被当成同一个
+	push := FeedCursor{Ctime: 100, Origin: EventOriginPush, ID: 2}
+	pull := FeedCursor{Ctime: 100, Origin: EventOriginPull, ID: 2}
+	require.True(t, push.Less(pull))
+	require.False(t, pull.Less(push))
+}
@@ -13,10 +13,53 @@ type FeedEvent struct {
 	// 以 A 发表了一篇文章为例
 	// 如果是 Pull Event，也就是拉模型，那么 Uid 是 A 的id
 	// 如果是 Push Event，也就是推模型，那么 Uid 是 A 的某个粉丝的 id
-	Uid   int64
-	Type  string
-	Ctime time.Time
-	Ext   ExtendFields
+	Uid int64
+	// Source 事件是谁产生的，以 A 发表了一篇文章为例，Source 就是 A 的 id
+	Source int64
+	Type   string
+	Ctime  time.Time
+	Ext    ExtendFields
+	// Key 事件的业务标识，同一个收件人收到的同一个 Source 的同一个 Key 只保留一条
+	// 为空的不去重
+	Key string
+	// Origin 从收件箱还是发件箱查出来的，两张表的 ID 会重复
+	Origin EventOrigin
+
+	// 下面是聚合之后才有的字段
+	// 例如 "A 和其他 12 个人赞了你的文章"，Count 就是 13，Actors 里面是 A 等几个人
+	// 没有被聚合的事件 Count 为 1
+	Count  int64
+	Actors []int64
+	// Earliest 被聚合的事件中最早的那个的时间，Ctime 则是最新的那个
+	Earliest time.Time
+
+	// Unread 比用户上一次读到的位置新
+	Unread bool
+}
+
+type EventOrigin uint8
+
+const (
+	EventOriginUnknown EventOrigin = iota
+	EventOriginPush
+	EventOriginPull
+)
+
+// ReadCursor 用户上一次读 feed 读到了哪里
+type ReadCursor struct {
+	Uid int64
+	// ReadTime 之后的事件都是未读的，零值说明从来没读过
+	ReadTime time.Time
+	// PushUnread 收件箱里面的未读数，拉模型的未读数要读的时候再算
+	PushUnread int64
+}
+
+// FollowChange 关注关系的变化
+type FollowChange struct {
+	Follower int64
+	Followee int64
+	// Active 为 true 是关注，false 是取消关注
+	Active bool
 }
 
 type ExtendFields map[string]string
@@ -27,7 +70,7 @@ func (f ExtendFields) Get(key string) ekit.AnyValue {
 	val, ok := f[key]
 	if !ok {
 		return ekit.AnyValue{
-			Err: fmt.Errorf("%w, key %s", errKeyNotFound),
+			Err: fmt.Errorf("%w, key %s", errKeyNotFound, key),
 		}
 	}
 	return ekit.AnyValue{Val: val}
@@ -0,0 +1,75 @@
+// Copyright@daidai53 2024
+package events
+
+import (
+	"context"
+	"github.com/IBM/sarama"
+	"github.com/daidai53/webook/feed/domain"
+	"github.com/daidai53/webook/feed/service"
+	"github.com/daidai53/webook/pkg/logger"
+	"github.com/daidai53/webook/pkg/saramax"
+	"strconv"
+	"time"
+)
+
+// CommentEvent 评论服务的 comment_events，只取 feed 用得到的字段
+type CommentEvent struct {
+	Type      string `json:"type"`
+	Cid       int64  `json:"cid"`
+	Uid       int64  `json:"uid"`
+	Biz       string `json:"biz"`
+	BizId     int64  `json:"biz_id"`
+	ParentId  int64  `json:"parent_id"`
+	ParentUid int64  `json:"parent_uid"`
+}
+
+// CommentEventConsumer 有人回复了评论，提醒被回复的人
+type CommentEventConsumer struct {
+	client sarama.Client
+	l      logger.LoggerV1
+	svc    service.FeedService
+}
+
+func NewCommentEventConsumer(client sarama.Client, l logger.LoggerV1, svc service.FeedService) *CommentEventConsumer {
+	return &CommentEventConsumer{
+		client: client,
+		l:      l,
+		svc:    svc,
+	}
+}
+
+func (c *CommentEventConsumer) Start() error {
+	consumerGroup, err := sarama.NewConsumerGroupFromClient("feed_comment_event", c.client)
+	if err != nil {
+		return err
+	}
+	go func() {
+		err := consumerGroup.Consume(context.Background(),
+			[]string{"comment_events"},
+			saramax.NewHandler[CommentEvent](c.l, c.Consume))
+		if err != nil {
+			c.l.Error("退出消费循环异常", logger.Error(err))
+		}
+	}()
+	return err
+}
+
+// Consume 只关心新的回复，自己回复自己的不用提醒
+func (c *CommentEventConsumer) Consume(msg *sarama.ConsumerMessage, evt CommentEvent) error {
+	if evt.Type != "created" || evt.ParentUid <= 0 || evt.ParentUid == evt.Uid {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	return c.svc.CreateFeedEvent(ctx, domain.FeedEvent{
+		Type: service.ReplyEventName,
+		Ext: domain.ExtendFields{
+			"replier":  strconv.FormatInt(evt.Uid, 10),
+			"replied":  strconv.FormatInt(evt.ParentUid, 10),
+			"biz":      evt.Biz,
+			"bizId":    strconv.FormatInt(evt.BizId, 10),
+			"cid":      strconv.FormatInt(evt.Cid, 10),
+			"parentId": strconv.FormatInt(evt.ParentId, 10),
+		},
+	})
+}
@@ -0,0 +1,97 @@
+// Copyright@daidai53 2024
+package events
+
+import (
+	"context"
+	"github.com/IBM/sarama"
+	"github.com/daidai53/webook/feed/domain"
+	"github.com/daidai53/webook/feed/service"
+	"github.com/daidai53/webook/pkg/canalx"
+	"github.com/daidai53/webook/pkg/logger"
+	"github.com/daidai53/webook/pkg/saramax"
+	"github.com/prometheus/client_golang/prometheus"
+	"time"
+)
+
+// FollowRelation binlog 里面 follow_relations 表的一行，只取 feed 用得到的字段
+type FollowRelation struct {
+	Follower int64
+	Followee int64
+	Status   uint8
+}
+
+const (
+	followRelationStatusActive   uint8 = 1
+	followRelationStatusInactive uint8 = 2
+)
+
+// FollowRelationConsumer 监听关注关系的变化，回填或者清理收件箱
+type FollowRelationConsumer struct {
+	client sarama.Client
+	l      logger.LoggerV1
+	svc    service.FeedService
+}
+
+func NewFollowRelationConsumer(client sarama.Client, l logger.LoggerV1, svc service.FeedService) *FollowRelationConsumer {
+	return &FollowRelationConsumer{
+		client: client,
+		l:      l,
+		svc:    svc,
+	}
+}
+
+func (f *FollowRelationConsumer) Start() error {
+	consumerGroup, err := sarama.NewConsumerGroupFromClient("feed_follow_relation", f.client)
+	if err != nil {
+		return err
+	}
+	go func() {
+		// 批量消费，一批消息里面的回填合并成批量写
+		err := consumerGroup.Consume(context.Background(),
+			[]string{"webook_binlog"},
+			saramax.NewBatchHandler[canalx.Message[FollowRelation]](f.BatchConsume, f.l,
+				prometheus.CounterOpts{
+					Namespace: "daidai53",
+					Subsystem: "webook",
+					Name:      "feed_follow_relation",
+				}))
+		if err != nil {
+			f.l.Error("退出消费循环异常", logger.Error(err))
+		}
+	}()
+	return err
+}
+
+func (f *FollowRelationConsumer) BatchConsume(msgs []*sarama.ConsumerMessage,
+	vals []canalx.Message[FollowRelation]) error {
+	changes := make([]domain.FollowChange, 0, len(vals))
+	for _, val := range vals {
+		if val.Table != "follow_relations" {
+			continue
+		}
+		if val.Type != "INSERT" && val.Type != "UPDATE" {
+			continue
+		}
+		for _, row := range val.Data {
+			switch row.Status {
+			case followRelationStatusActive, followRelationStatusInactive:
+				changes = append(changes, domain.FollowChange{
+					Follower: row.Follower,
+					Followee: row.Followee,
+					Active:   row.Status == followRelationStatusActive,
+				})
+			default:
+				f.l.Error("未知状态",
+					logger.Uint8("status", row.Status),
+					logger.Int64("follower", row.Follower),
+					logger.Int64("followee", row.Followee))
+			}
+		}
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	return f.svc.SyncFollowRelations(ctx, changes)
+}
@@ -0,0 +1,76 @@
+// Copyright@daidai53 2024
+package grpc
+
+import (
+	"context"
+	feedv1 "github.com/daidai53/webook/api/proto/gen/feed/v1"
+	"github.com/daidai53/webook/feed/domain"
+	"github.com/daidai53/webook/feed/service"
+	"github.com/ecodeclub/ekit/slice"
+	"google.golang.org/grpc"
+)
+
+type FeedEventGrpcSvc struct {
+	feedv1.UnimplementedFeedSvcServer
+	svc service.FeedService
+}
+
+func NewFeedEventGrpcSvc(svc service.FeedService) *FeedEventGrpcSvc {
+	return &FeedEventGrpcSvc{svc: svc}
+}
+
+func (f *FeedEventGrpcSvc) Register(s *grpc.Server) {
+	feedv1.RegisterFeedSvcServer(s, f)
+}
+
+func (f *FeedEventGrpcSvc) CreateFeedEvent(ctx context.Context, request *feedv1.CreateFeedEventRequest) (*feedv1.CreateFeedEventResponse, error) {
+	err := f.svc.CreateFeedEvent(ctx, domain.FeedEvent{
+		Type: request.GetType(),
+		Ext:  request.GetExt(),
+	})
+	return &feedv1.CreateFeedEventResponse{}, err
+}
+
+func (f *FeedEventGrpcSvc) FindFeedEvents(ctx context.Context, request *feedv1.FindFeedEventsRequest) (*feedv1.FindFeedEventsResponse, error) {
+	events, next, err := f.svc.GetFeedEventList(ctx, request.GetUid(), request.GetCursor(), request.GetLimit())
+	if err != nil {
+		return nil, err
+	}
+	return &feedv1.FindFeedEventsResponse{
+		FeedEvents: slice.Map(events, func(idx int, src domain.FeedEvent) *feedv1.FeedEvent {
+			return f.toDTO(src)
+		}),
+		Next: next,
+	}, nil
+}
+
+func (f *FeedEventGrpcSvc) GetUnreadCount(ctx context.Context, request *feedv1.GetUnreadCountRequest) (*feedv1.GetUnreadCountResponse, error) {
+	cnt, err := f.svc.GetUnreadCount(ctx, request.GetUid())
+	if err != nil {
+		return nil, err
+	}
+	return &feedv1.GetUnreadCountResponse{Count: cnt}, nil
+}
+
+func (f *FeedEventGrpcSvc) MarkRead(ctx context.Context, request *feedv1.MarkReadRequest) (*feedv1.MarkReadResponse, error) {
+	err := f.svc.MarkRead(ctx, request.GetUid(), request.GetTimestamp())
+	return &feedv1.MarkReadResponse{}, err
+}
+
+func (f *FeedEventGrpcSvc) toDTO(evt domain.FeedEvent) *feedv1.FeedEvent {
+	res := &feedv1.FeedEvent{
+		Id:     evt.ID,
+		Uid:    evt.Uid,
+		Source: evt.Source,
+		Type:   evt.Type,
+		Ctime:  evt.Ctime.UnixMilli(),
+		Ext:    evt.Ext,
+		Count:  evt.Count,
+		Actors: evt.Actors,
+		Unread: evt.Unread,
+	}
+	if !evt.Earliest.IsZero() {
+		res.Earliest = evt.Earliest.UnixMilli()
+	}
+	return res
+}
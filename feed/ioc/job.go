@@ -0,0 +1,47 @@
+// Copyright@daidai53 2024
+package ioc
+
+import (
+	"github.com/daidai53/webook/feed/job"
+	"github.com/daidai53/webook/feed/repository"
+	"github.com/daidai53/webook/feed/repository/dao"
+	"github.com/daidai53/webook/feed/service"
+	"github.com/daidai53/webook/pkg/logger"
+	rlock "github.com/gotomicro/redis-lock"
+	"github.com/spf13/viper"
+	"gorm.io/gorm"
+	"time"
+)
+
+func InitRetentionService(db *gorm.DB) service.RetentionService {
+	type Config struct {
+		// Archive 为 true 的时候，先归档再删除
+		Archive bool                    `yaml:"archive"`
+		Push    service.RetentionConfig `yaml:"push"`
+		Pull    service.RetentionConfig `yaml:"pull"`
+	}
+	cfg := Config{
+		Push: service.RetentionConfig{
+			MaxAge:    time.Hour * 24 * 30,
+			KeepN:     1000,
+			BatchSize: 100,
+			Interval:  time.Millisecond * 100,
+		},
+		Pull: service.RetentionConfig{
+			MaxAge:    time.Hour * 24 * 90,
+			BatchSize: 100,
+			Interval:  time.Millisecond * 100,
+		},
+	}
+	err := viper.UnmarshalKey("feed.retention", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	push := repository.NewRetentionRepository(dao.NewGORMPushRetentionDAO(db, cfg.Archive))
+	pull := repository.NewRetentionRepository(dao.NewGORMPullRetentionDAO(db, cfg.Archive))
+	return service.NewRetentionService(push, pull, cfg.Push, cfg.Pull)
+}
+
+func InitRetentionJob(svc service.RetentionService, l logger.LoggerV1, client *rlock.Client) *job.RetentionJob {
+	return job.NewRetentionJob(svc, time.Hour, l, client)
+}
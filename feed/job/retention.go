@@ -0,0 +1,65 @@
+// Copyright@daidai53 2024
+package job
+
+import (
+	"context"
+	"github.com/daidai53/webook/feed/service"
+	"github.com/daidai53/webook/pkg/logger"
+	rlock "github.com/gotomicro/redis-lock"
+	"time"
+)
+
+// RetentionJob 定时清理收件箱和发件箱，每个节点都会调度，抢到分布式锁的那个才清理
+type RetentionJob struct {
+	svc     service.RetentionService
+	timeout time.Duration
+	client  *rlock.Client
+	key     string
+	l       logger.LoggerV1
+}
+
+func NewRetentionJob(svc service.RetentionService, timeout time.Duration, l logger.LoggerV1,
+	client *rlock.Client) *RetentionJob {
+	return &RetentionJob{
+		svc:     svc,
+		timeout: timeout,
+		client:  client,
+		key:     "job:feed_retention",
+		l:       l,
+	}
+}
+
+func (r *RetentionJob) Name() string {
+	return "feed_retention"
+}
+
+func (r *RetentionJob) Run() error {
+	lockCtx, lockCancel := context.WithTimeout(context.Background(), time.Second*4)
+	defer lockCancel()
+	// 一天只跑一次，不用像 RankingJob 那样一直占着锁，跑完就释放
+	lock, err := r.client.Lock(lockCtx, r.key, r.timeout, &rlock.FixIntervalRetry{
+		Interval: time.Millisecond * 100,
+		Max:      3,
+	}, time.Second)
+	if err != nil {
+		r.l.Warn("获取分布式锁失败，别的节点在清理", logger.Error(err))
+		return nil
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		er := lock.Unlock(ctx)
+		if er != nil {
+			r.l.Error("释放分布式锁失败", logger.Error(er))
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+	// 收件箱清理失败了，也继续清理发件箱
+	err = r.svc.CleanPushEvents(ctx)
+	if err != nil {
+		r.l.Error("清理收件箱失败", logger.Error(err))
+	}
+	return r.svc.CleanPullEvents(ctx)
+}
@@ -0,0 +1,18 @@
+-- 某个用户的未读数
+local key = KEYS[1]
+local field = ARGV[1]
+local delta = tonumber(ARGV[2])
+-- 事件的时间，比用户已读的位置还早的事件不算未读
+local ctime = tonumber(ARGV[3])
+local cursor = redis.call("HGET", key, "cursor")
+
+if cursor == false then
+    -- 缓存里面没有，等读的时候再从数据库里面算
+    return 0
+end
+
+if ctime > tonumber(cursor) then
+    redis.call("HINCRBY", key, field, delta)
+    return 1
+end
+return 0
@@ -0,0 +1,83 @@
+// Copyright@daidai53 2024
+package cache
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"github.com/daidai53/webook/feed/domain"
+	"github.com/redis/go-redis/v9"
+	"strconv"
+	"time"
+)
+
+var ErrKeyNotFound = errors.New("没有记录")
+
+var (
+	//go:embed lua/incr_unread.lua
+	luaIncrUnread string
+)
+
+const fieldCursor = "cursor"
+const fieldPushCnt = "push_cnt"
+
+type UnreadCache interface {
+	// IncrPushCntIfPresent 收件箱里面多了事件，缓存里面有并且事件比已读的位置新才加
+	IncrPushCntIfPresent(ctx context.Context, uid int64, ctime time.Time) error
+	Get(ctx context.Context, uid int64) (domain.ReadCursor, error)
+	Set(ctx context.Context, cursor domain.ReadCursor) error
+	Del(ctx context.Context, uid int64) error
+}
+
+type UnreadRedisCache struct {
+	client     redis.Cmdable
+	expiration time.Duration
+}
+
+func NewUnreadRedisCache(client redis.Cmdable) UnreadCache {
+	return &UnreadRedisCache{
+		client:     client,
+		expiration: time.Hour * 24 * 3,
+	}
+}
+
+func (u *UnreadRedisCache) IncrPushCntIfPresent(ctx context.Context, uid int64, ctime time.Time) error {
+	return u.client.Eval(ctx, luaIncrUnread, []string{u.key(uid)}, fieldPushCnt, 1, ctime.UnixMilli()).Err()
+}
+
+func (u *UnreadRedisCache) Get(ctx context.Context, uid int64) (domain.ReadCursor, error) {
+	res, err := u.client.HGetAll(ctx, u.key(uid)).Result()
+	if err != nil {
+		return domain.ReadCursor{}, err
+	}
+	if len(res) == 0 {
+		return domain.ReadCursor{}, ErrKeyNotFound
+	}
+	cursor, _ := strconv.ParseInt(res[fieldCursor], 10, 64)
+	pushCnt, _ := strconv.ParseInt(res[fieldPushCnt], 10, 64)
+	return domain.ReadCursor{
+		Uid:        uid,
+		ReadTime:   time.UnixMilli(cursor),
+		PushUnread: pushCnt,
+	}, nil
+}
+
+func (u *UnreadRedisCache) Set(ctx context.Context, cursor domain.ReadCursor) error {
+	key := u.key(cursor.Uid)
+	err := u.client.HSet(ctx, key,
+		fieldCursor, cursor.ReadTime.UnixMilli(),
+		fieldPushCnt, cursor.PushUnread).Err()
+	if err != nil {
+		return err
+	}
+	return u.client.Expire(ctx, key, u.expiration).Err()
+}
+
+func (u *UnreadRedisCache) Del(ctx context.Context, uid int64) error {
+	return u.client.Del(ctx, u.key(uid)).Err()
+}
+
+func (u *UnreadRedisCache) key(uid int64) string {
+	return fmt.Sprintf("feed:unread:%d", uid)
+}
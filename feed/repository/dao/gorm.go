@@ -0,0 +1,174 @@
+// Copyright@daidai53 2024
+package dao
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+type GORMFeedPushEventDAO struct {
+	db *gorm.DB
+	// 批量插入的时候，每一批多少条
+	batchSize int
+}
+
+func NewGORMFeedPushEventDAO(db *gorm.DB) FeedPushEventDAO {
+	return &GORMFeedPushEventDAO{
+		db:        db,
+		batchSize: 200,
+	}
+}
+
+func (g *GORMFeedPushEventDAO) CreatePushEvents(ctx context.Context, events []FeedPushEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+	// 已经有了的就跳过，重复回填不会多出来
+	return g.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).
+		CreateInBatches(events, g.batchSize).Error
+}
+
+func (g *GORMFeedPushEventDAO) GetPushEvents(ctx context.Context, uid int64, cursor Cursor, limit int64) ([]FeedPushEvent, error) {
+	var res []FeedPushEvent
+	err := cursor.apply(g.db.WithContext(ctx).Where("uid = ?", uid), OriginPush).
+		Limit(int(limit)).
+		Find(&res).Error
+	return res, err
+}
+
+func (g *GORMFeedPushEventDAO) GetPushEventsWithTyp(ctx context.Context, typ string, uid int64, cursor Cursor, limit int64) ([]FeedPushEvent, error) {
+	var res []FeedPushEvent
+	err := cursor.apply(g.db.WithContext(ctx).Where("uid = ? AND type = ?", uid, typ), OriginPush).
+		Limit(int(limit)).
+		Find(&res).Error
+	return res, err
+}
+
+func (g *GORMFeedPushEventDAO) GetPushEventsBySource(ctx context.Context, typ string, source, limit int64) ([]FeedPushEvent, error) {
+	var res []FeedPushEvent
+	db := g.db.WithContext(ctx)
+	// 同一批推出去的事件 CTime 是一样的，每个 CTime 取一条就可以了
+	sub := db.Model(&FeedPushEvent{}).
+		Select("MAX(id)").
+		Where("source = ? AND type = ?", source, typ).
+		Group("c_time")
+	err := db.Where("id IN (?)", sub).
+		Order("c_time DESC").
+		Limit(int(limit)).
+		Find(&res).Error
+	return res, err
+}
+
+func (g *GORMFeedPushEventDAO) DeleteBySource(ctx context.Context, uid int64, sources []int64, limit int) (int64, error) {
+	// GORM 的 Delete 不会带上 LIMIT，所以先查出一批 id 再按照 id 删，避免一次锁住太多行
+	var ids []int64
+	err := g.db.WithContext(ctx).Model(&FeedPushEvent{}).
+		Where("uid = ? AND source IN ?", uid, sources).
+		Limit(limit).
+		Pluck("id", &ids).Error
+	if err != nil || len(ids) == 0 {
+		return 0, err
+	}
+	res := g.db.WithContext(ctx).
+		Where("id IN ?", ids).
+		Delete(&FeedPushEvent{})
+	return res.RowsAffected, res.Error
+}
+
+func (g *GORMFeedPushEventDAO) CountPushEvents(ctx context.Context, uid, since int64) (int64, error) {
+	var res int64
+	err := g.db.WithContext(ctx).Model(&FeedPushEvent{}).
+		Where("uid = ? AND c_time > ?", uid, since).
+		Count(&res).Error
+	return res, err
+}
+
+type GORMFeedPullEventDAO struct {
+	db *gorm.DB
+}
+
+func NewGORMFeedPullEventDAO(db *gorm.DB) FeedPullEventDAO {
+	return &GORMFeedPullEventDAO{
+		db: db,
+	}
+}
+
+// CreatePullEvent 同一个 uid 下 EventKey 一样的已经有了就跳过
+func (g *GORMFeedPullEventDAO) CreatePullEvent(ctx context.Context, event FeedPullEvent) error {
+	return g.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&event).Error
+}
+
+func (g *GORMFeedPullEventDAO) FindPullEventList(ctx context.Context, uids []int64, cursor Cursor, limit int64) ([]FeedPullEvent, error) {
+	var res []FeedPullEvent
+	err := cursor.apply(g.db.WithContext(ctx).Where("uid IN ?", uids), OriginPull).
+		Limit(int(limit)).
+		Find(&res).Error
+	return res, err
+}
+
+func (g *GORMFeedPullEventDAO) FindPullEventListWithTyp(ctx context.Context, typ string, uids []int64, cursor Cursor, limit int64) ([]FeedPullEvent, error) {
+	var res []FeedPullEvent
+	err := cursor.apply(g.db.WithContext(ctx).Where("uid IN ? AND type = ?", uids, typ), OriginPull).
+		Limit(int(limit)).
+		Find(&res).Error
+	return res, err
+}
+
+func (g *GORMFeedPullEventDAO) CountPullEventsWithTyp(ctx context.Context, typ string, uids []int64, since int64) (int64, error) {
+	var res int64
+	err := g.db.WithContext(ctx).Model(&FeedPullEvent{}).
+		Where("uid IN ? AND type = ? AND c_time > ?", uids, typ, since).
+		Count(&res).Error
+	return res, err
+}
+
+type GORMReadCursorDAO struct {
+	db *gorm.DB
+}
+
+func NewGORMReadCursorDAO(db *gorm.DB) ReadCursorDAO {
+	return &GORMReadCursorDAO{
+		db: db,
+	}
+}
+
+func (g *GORMReadCursorDAO) Upsert(ctx context.Context, uid, readTime int64) error {
+	now := time.Now().UnixMilli()
+	return g.db.WithContext(ctx).Clauses(clause.OnConflict{
+		DoUpdates: clause.Assignments(map[string]any{
+			"read_time": readTime,
+			"u_time":    now,
+		}),
+	}).Create(&FeedReadCursor{
+		Uid:      uid,
+		ReadTime: readTime,
+		CTime:    now,
+		UTime:    now,
+	}).Error
+}
+
+func (g *GORMReadCursorDAO) Get(ctx context.Context, uid int64) (FeedReadCursor, error) {
+	var res FeedReadCursor
+	err := g.db.WithContext(ctx).Where("uid = ?", uid).First(&res).Error
+	return res, err
+}
+
+// apply 加上游标的条件和排序，origin 是当前查的这张表。
+// 一张表里面 origin 都一样，所以同一毫秒的事件只要比较游标的 origin 就知道整张表是在前面还是后面
+func (c Cursor) apply(db *gorm.DB, origin uint8) *gorm.DB {
+	if c != (Cursor{}) {
+		switch {
+		case origin < c.Origin:
+			// 同一毫秒的都排在游标后面
+			db = db.Where("c_time <= ?", c.CTime)
+		case origin == c.Origin:
+			db = db.Where("(c_time < ? OR (c_time = ? AND id < ?))", c.CTime, c.CTime, c.Id)
+		default:
+			db = db.Where("c_time < ?", c.CTime)
+		}
+	}
+	return db.Order("c_time DESC, id DESC")
+}
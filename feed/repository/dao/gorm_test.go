@@ -0,0 +1,171 @@
+// Copyright@daidai53 2024
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"testing"
+)
+
+func TestGORMFeedPushEventDAO_DeleteBySource(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(mock sqlmock.Sqlmock)
+
+		wantCnt int64
+	}{
+		{
+			name: "一次只删一批",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT `id` FROM `feed_push_events` WHERE uid = \\? AND source IN \\(\\?,\\?\\) LIMIT 2").
+					WithArgs(int64(1), int64(2), int64(3)).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(10).AddRow(11))
+				mock.ExpectExec("DELETE FROM `feed_push_events` WHERE id IN \\(\\?,\\?\\)").
+					WithArgs(int64(10), int64(11)).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+			},
+			wantCnt: 2,
+		},
+		{
+			name: "没有了就不删",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT `id` FROM `feed_push_events` .*").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sqlDB, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			tc.mock(mock)
+			dao := NewGORMFeedPushEventDAO(newMockDB(t, sqlDB))
+			cnt, err := dao.DeleteBySource(context.Background(), 1, []int64{2, 3}, 2)
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantCnt, cnt)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestGORMFeedPushEventDAO_CreatePushEvents(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	// 重复回填的时候撞了唯一索引就跳过
+	mock.ExpectExec("INSERT INTO `feed_push_events` \\(`uid`,`source`,`type`,`event_key`,`content`,`c_time`\\) "+
+		"VALUES \\(\\?,\\?,\\?,\\?,\\?,\\?\\) ON DUPLICATE KEY UPDATE `id`=`id`").
+		WithArgs(int64(1), int64(2), "article_event", "123", "{}", int64(123)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	dao := NewGORMFeedPushEventDAO(newMockDB(t, sqlDB))
+	err = dao.CreatePushEvents(context.Background(), []FeedPushEvent{
+		{
+			Uid:      1,
+			Source:   2,
+			Type:     "article_event",
+			Content:  "{}",
+			EventKey: sql.NullString{String: "123", Valid: true},
+			CTime:    123,
+		},
+	})
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGORMFeedPullEventDAO_CreatePullEvent(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	// 重复消费的时候撞了唯一索引就跳过
+	mock.ExpectExec("INSERT INTO `feed_pull_events` \\(`uid`,`type`,`event_key`,`content`,`c_time`\\) "+
+		"VALUES \\(\\?,\\?,\\?,\\?,\\?\\) ON DUPLICATE KEY UPDATE `id`=`id`").
+		WithArgs(int64(1), "reply_event", "reply_event:3", "{}", int64(123)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	dao := NewGORMFeedPullEventDAO(newMockDB(t, sqlDB))
+	err = dao.CreatePullEvent(context.Background(), FeedPullEvent{
+		Uid:      1,
+		Type:     "reply_event",
+		EventKey: sql.NullString{String: "reply_event:3", Valid: true},
+		Content:  "{}",
+		CTime:    123,
+	})
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func newMockDB(t *testing.T, sqlDB *sql.DB) *gorm.DB {
+	db, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      sqlDB,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{
+		DisableAutomaticPing:   true,
+		SkipDefaultTransaction: true,
+	})
+	require.NoError(t, err)
+	return db
+}
+
+func TestCursor_apply(t *testing.T) {
+	testCases := []struct {
+		name   string
+		cursor Cursor
+		// 查收件箱
+		wantSQL  string
+		wantArgs []driver.Value
+	}{
+		{
+			name:    "零值从最新的开始",
+			wantSQL: "SELECT \\* FROM `feed_push_events` WHERE uid = \\? ORDER BY c_time DESC, id DESC LIMIT 10",
+			wantArgs: []driver.Value{
+				int64(1),
+			},
+		},
+		{
+			name:    "游标在收件箱，同一毫秒的比 id",
+			cursor:  Cursor{CTime: 100, Origin: OriginPush, Id: 5},
+			wantSQL: "WHERE uid = \\? AND \\(\\(c_time < \\? OR \\(c_time = \\? AND id < \\?\\)\\)\\) ORDER BY",
+			wantArgs: []driver.Value{
+				int64(1), int64(100), int64(100), int64(5),
+			},
+		},
+		{
+			name:    "游标在发件箱，同一毫秒的收件箱事件都排在后面",
+			cursor:  Cursor{CTime: 100, Origin: OriginPull, Id: 5},
+			wantSQL: "WHERE uid = \\? AND c_time <= \\? ORDER BY",
+			wantArgs: []driver.Value{
+				int64(1), int64(100),
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sqlDB, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			mock.ExpectQuery(tc.wantSQL).
+				WithArgs(tc.wantArgs...).
+				WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			dao := NewGORMFeedPushEventDAO(newMockDB(t, sqlDB))
+			_, err = dao.GetPushEvents(context.Background(), 1, tc.cursor, 10)
+			require.NoError(t, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestCursor_applyPull(t *testing.T) {
+	// 游标在收件箱，同一毫秒的发件箱事件都排在前面，已经看过了
+	sqlDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	mock.ExpectQuery("WHERE uid IN \\(\\?\\) AND c_time < \\? ORDER BY c_time DESC, id DESC").
+		WithArgs(int64(1), int64(100)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	dao := NewGORMFeedPullEventDAO(newMockDB(t, sqlDB))
+	_, err = dao.FindPullEventList(context.Background(), []int64{1},
+		Cursor{CTime: 100, Origin: OriginPush, Id: 5}, 10)
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
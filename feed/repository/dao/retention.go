@@ -0,0 +1,111 @@
+// Copyright@daidai53 2024
+package dao
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type GORMRetentionDAO struct {
+	db *gorm.DB
+	// 要清理的表
+	model any
+	// 归档表，为 nil 就是直接删除
+	archive any
+}
+
+// NewGORMPushRetentionDAO 清理收件箱
+func NewGORMPushRetentionDAO(db *gorm.DB, archive bool) RetentionDAO {
+	res := &GORMRetentionDAO{
+		db:    db,
+		model: &FeedPushEvent{},
+	}
+	if archive {
+		res.archive = &FeedPushEventArchive{}
+	}
+	return res
+}
+
+// NewGORMPullRetentionDAO 清理发件箱
+func NewGORMPullRetentionDAO(db *gorm.DB, archive bool) RetentionDAO {
+	res := &GORMRetentionDAO{
+		db:    db,
+		model: &FeedPullEvent{},
+	}
+	if archive {
+		res.archive = &FeedPullEventArchive{}
+	}
+	return res
+}
+
+func (g *GORMRetentionDAO) Uids(ctx context.Context, minUid int64, limit int) ([]int64, error) {
+	var res []int64
+	err := g.db.WithContext(ctx).Model(g.model).
+		Distinct("uid").
+		Where("uid > ?", minUid).
+		Order("uid ASC").
+		Limit(limit).
+		Pluck("uid", &res).Error
+	return res, err
+}
+
+func (g *GORMRetentionDAO) NthCTime(ctx context.Context, uid int64, n int) (int64, error) {
+	var res []int64
+	err := g.db.WithContext(ctx).Model(g.model).
+		Where("uid = ?", uid).
+		Order("c_time DESC").
+		Offset(n-1).
+		Limit(1).
+		Pluck("c_time", &res).Error
+	if err != nil || len(res) == 0 {
+		return 0, err
+	}
+	return res[0], nil
+}
+
+func (g *GORMRetentionDAO) IdsBefore(ctx context.Context, uid, before int64, limit int) ([]int64, error) {
+	var res []int64
+	db := g.db.WithContext(ctx).Model(g.model).Where("c_time < ?", before)
+	if uid > 0 {
+		db = db.Where("uid = ?", uid)
+	}
+	// 按照 c_time 排序才能用上 c_time 的索引，二级索引里面带着主键，所以 id 也不用回表
+	err := db.Order("c_time ASC, id ASC").Limit(limit).Pluck("id", &res).Error
+	return res, err
+}
+
+func (g *GORMRetentionDAO) Remove(ctx context.Context, ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	if g.archive == nil {
+		return g.db.WithContext(ctx).Where("id IN ?", ids).Delete(g.model).Error
+	}
+	return g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		src, err := g.tableName(tx, g.model)
+		if err != nil {
+			return err
+		}
+		dst, err := g.tableName(tx, g.archive)
+		if err != nil {
+			return err
+		}
+		// 归档表的主键和原表一样，重复执行也不会出问题
+		err = tx.Exec("INSERT IGNORE INTO ? SELECT * FROM ? WHERE id IN ?",
+			clause.Table{Name: dst}, clause.Table{Name: src}, ids).Error
+		if err != nil {
+			return err
+		}
+		return tx.Where("id IN ?", ids).Delete(g.model).Error
+	})
+}
+
+func (g *GORMRetentionDAO) tableName(db *gorm.DB, model any) (string, error) {
+	stmt := &gorm.Statement{DB: db}
+	err := stmt.Parse(model)
+	if err != nil {
+		return "", err
+	}
+	return stmt.Schema.Table, nil
+}
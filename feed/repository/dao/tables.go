@@ -1,23 +1,51 @@
 // Copyright@daidai53 2024
 package dao
 
+import "database/sql"
+
 // 对应的是收件箱
 type FeedPushEvent struct {
 	Id int64 `gorm:"primaryKey,autoIncrement"`
 	// 收件人
-	Uid  int64 `gorm:"index"`
-	Type string
+	Uid int64 `gorm:"index;index:uid_source;index:uid_ctime;uniqueIndex:uid_source_key"`
+	// 事件是谁产生的，例如发表文章的人
+	// 取消关注的时候，要按照它来清理收件箱
+	Source int64 `gorm:"index:uid_source;index:source_ctime;uniqueIndex:uid_source_key"`
+	Type   string
+	// 事件的业务标识，重复消费或者重复回填的时候靠它去重
+	// 没有标识的事件存 NULL，不参与去重
+	EventKey sql.NullString `gorm:"type:varchar(128);uniqueIndex:uid_source_key"`
 	// 扩展字段，不同的事件类型有不同的解析方式，取决于Type
 	Content string
-	CTime   int64
+	// 单独的 c_time 索引给清理任务按照时间找过期的用
+	CTime int64 `gorm:"index;index:source_ctime;index:uid_ctime"`
 	// 没有更新场景，不用定义UTime字段
 }
 
 // 对应的是发件箱
 type FeedPullEvent struct {
-	Id      int64 `gorm:"primaryKey,autoIncrement"`
-	Uid     int64
-	Type    string
-	Content string
-	CTime   int64
+	Id int64 `gorm:"primaryKey,autoIncrement"`
+	// 清理任务按照作者找第 N 新的事件
+	Uid  int64 `gorm:"index:uid_ctime;uniqueIndex:uid_key"`
+	Type string
+	// 和收件箱一样，重复消费的时候靠它去重
+	EventKey sql.NullString `gorm:"type:varchar(128);uniqueIndex:uid_key"`
+	Content  string
+	CTime    int64 `gorm:"index;index:uid_ctime"`
 }
+
+// FeedReadCursor 用户上一次看 feed 看到了哪里
+type FeedReadCursor struct {
+	Id  int64 `gorm:"primaryKey,autoIncrement"`
+	Uid int64 `gorm:"uniqueIndex"`
+	// ReadTime 这个时间之后的事件都算未读
+	ReadTime int64
+	CTime    int64
+	UTime    int64
+}
+
+// FeedPushEventArchive 收件箱的归档表，结构和收件箱一样
+type FeedPushEventArchive FeedPushEvent
+
+// FeedPullEventArchive 发件箱的归档表，结构和发件箱一样
+type FeedPullEventArchive FeedPullEvent
@@ -0,0 +1,72 @@
+// Copyright@daidai53 2024
+package dao
+
+import (
+	"context"
+	"gorm.io/gorm"
+)
+
+func InitTables(db *gorm.DB) error {
+	return db.AutoMigrate(
+		&FeedPushEvent{},
+		&FeedPullEvent{},
+		&FeedPushEventArchive{},
+		&FeedPullEventArchive{},
+		&FeedReadCursor{},
+	)
+}
+
+// Cursor 翻页的游标，按照 (c_time, origin, id) 降序排列，只查严格排在游标后面的
+// 零值就是从最新的开始
+type Cursor struct {
+	CTime int64
+	// Origin 游标指向的事件是哪张表的，同一毫秒的事件先按照表排，再按照 id 排
+	Origin uint8
+	Id     int64
+}
+
+// 和 domain.EventOrigin 一一对应
+const (
+	OriginUnknown uint8 = iota
+	OriginPush
+	OriginPull
+)
+
+type FeedPushEventDAO interface {
+	CreatePushEvents(ctx context.Context, events []FeedPushEvent) error
+	GetPushEvents(ctx context.Context, uid int64, cursor Cursor, limit int64) ([]FeedPushEvent, error)
+	GetPushEventsWithTyp(ctx context.Context, typ string, uid int64, cursor Cursor, limit int64) ([]FeedPushEvent, error)
+	// GetPushEventsBySource 找 source 最近产生的推事件
+	// 同一个事件会被推到很多个收件箱，这里按照 CTime 去重
+	GetPushEventsBySource(ctx context.Context, typ string, source, limit int64) ([]FeedPushEvent, error)
+	// DeleteBySource 删除 uid 收件箱里面由 sources 产生的事件
+	// 一次最多删除 limit 条，返回实际删除的条数
+	DeleteBySource(ctx context.Context, uid int64, sources []int64, limit int) (int64, error)
+	// CountPushEvents 统计 uid 收件箱里面 since 之后的事件
+	CountPushEvents(ctx context.Context, uid, since int64) (int64, error)
+}
+
+type FeedPullEventDAO interface {
+	CreatePullEvent(ctx context.Context, event FeedPullEvent) error
+	FindPullEventList(ctx context.Context, uids []int64, cursor Cursor, limit int64) ([]FeedPullEvent, error)
+	FindPullEventListWithTyp(ctx context.Context, typ string, uids []int64, cursor Cursor, limit int64) ([]FeedPullEvent, error)
+	// CountPullEventsWithTyp 统计 uids 发件箱里面 since 之后的某个类型的事件
+	CountPullEventsWithTyp(ctx context.Context, typ string, uids []int64, since int64) (int64, error)
+}
+
+type ReadCursorDAO interface {
+	Upsert(ctx context.Context, uid, readTime int64) error
+	Get(ctx context.Context, uid int64) (FeedReadCursor, error)
+}
+
+// RetentionDAO 清理收件箱或者发件箱，两张表的逻辑是一样的
+type RetentionDAO interface {
+	// Uids 按照 uid 升序，找出 uid > minUid 的用户
+	Uids(ctx context.Context, minUid int64, limit int) ([]int64, error)
+	// NthCTime uid 第 n 新的事件的时间，不足 n 条的时候返回 0
+	NthCTime(ctx context.Context, uid int64, n int) (int64, error)
+	// IdsBefore 找出 CTime < before 的事件，uid 大于 0 的时候只找这个用户的
+	IdsBefore(ctx context.Context, uid, before int64, limit int) ([]int64, error)
+	// Remove 删除这些事件，开启了归档的话先复制到归档表里
+	Remove(ctx context.Context, ids []int64) error
+}
@@ -0,0 +1,234 @@
+// Copyright@daidai53 2024
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"github.com/daidai53/webook/feed/domain"
+	"github.com/daidai53/webook/feed/repository/cache"
+	"github.com/daidai53/webook/feed/repository/dao"
+	"github.com/daidai53/webook/pkg/logger"
+	"github.com/ecodeclub/ekit/slice"
+	"gorm.io/gorm"
+	"time"
+)
+
+type feedEventRepo struct {
+	pullDao   dao.FeedPullEventDAO
+	pushDao   dao.FeedPushEventDAO
+	cursorDao dao.ReadCursorDAO
+	cache     cache.UnreadCache
+	l         logger.LoggerV1
+	// 清理收件箱的时候每一批删多少条
+	deleteBatch int
+}
+
+func NewFeedEventRepo(pullDao dao.FeedPullEventDAO, pushDao dao.FeedPushEventDAO,
+	cursorDao dao.ReadCursorDAO, cache cache.UnreadCache, l logger.LoggerV1) FeedEventRepo {
+	return &feedEventRepo{
+		pullDao:     pullDao,
+		pushDao:     pushDao,
+		cursorDao:   cursorDao,
+		cache:       cache,
+		l:           l,
+		deleteBatch: 500,
+	}
+}
+
+func (f *feedEventRepo) CreatePushEvents(ctx context.Context, events []domain.FeedEvent) error {
+	err := f.pushDao.CreatePushEvents(ctx, slice.Map(events, func(idx int, src domain.FeedEvent) dao.FeedPushEvent {
+		return f.toPushEntity(src)
+	}))
+	if err != nil {
+		return err
+	}
+	for _, evt := range events {
+		er := f.cache.IncrPushCntIfPresent(ctx, evt.Uid, evt.Ctime)
+		if er != nil {
+			// 未读数不准问题不大，下次标记已读的时候就对了
+			f.l.Error("更新未读数失败",
+				logger.Error(er),
+				logger.Int64("uid", evt.Uid))
+		}
+	}
+	return nil
+}
+
+func (f *feedEventRepo) CreatePullEvent(ctx context.Context, event domain.FeedEvent) error {
+	return f.pullDao.CreatePullEvent(ctx, f.toPullEntity(event))
+}
+
+func (f *feedEventRepo) FindPullEvents(ctx context.Context, uids []int64, cursor domain.FeedCursor, limit int64) ([]domain.FeedEvent, error) {
+	events, err := f.pullDao.FindPullEventList(ctx, uids, f.toDAOCursor(cursor), limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(events, func(idx int, src dao.FeedPullEvent) domain.FeedEvent {
+		return f.pullToDomain(src)
+	}), nil
+}
+
+func (f *feedEventRepo) FindPushEvents(ctx context.Context, uid int64, cursor domain.FeedCursor, limit int64) ([]domain.FeedEvent, error) {
+	events, err := f.pushDao.GetPushEvents(ctx, uid, f.toDAOCursor(cursor), limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(events, func(idx int, src dao.FeedPushEvent) domain.FeedEvent {
+		return f.pushToDomain(src)
+	}), nil
+}
+
+func (f *feedEventRepo) FindPullEventsWithTyp(ctx context.Context, typ string, uids []int64, cursor domain.FeedCursor, limit int64) ([]domain.FeedEvent, error) {
+	events, err := f.pullDao.FindPullEventListWithTyp(ctx, typ, uids, f.toDAOCursor(cursor), limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(events, func(idx int, src dao.FeedPullEvent) domain.FeedEvent {
+		return f.pullToDomain(src)
+	}), nil
+}
+
+func (f *feedEventRepo) FindPushEventsWithTyp(ctx context.Context, typ string, uid int64, cursor domain.FeedCursor, limit int64) ([]domain.FeedEvent, error) {
+	events, err := f.pushDao.GetPushEventsWithTyp(ctx, typ, uid, f.toDAOCursor(cursor), limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(events, func(idx int, src dao.FeedPushEvent) domain.FeedEvent {
+		return f.pushToDomain(src)
+	}), nil
+}
+
+func (f *feedEventRepo) FindPushEventsBySource(ctx context.Context, typ string, source, limit int64) ([]domain.FeedEvent, error) {
+	events, err := f.pushDao.GetPushEventsBySource(ctx, typ, source, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(events, func(idx int, src dao.FeedPushEvent) domain.FeedEvent {
+		return f.pushToDomain(src)
+	}), nil
+}
+
+func (f *feedEventRepo) DeletePushEventsBySource(ctx context.Context, uid int64, sources []int64) error {
+	for {
+		cnt, err := f.pushDao.DeleteBySource(ctx, uid, sources, f.deleteBatch)
+		if err != nil {
+			return err
+		}
+		if cnt < int64(f.deleteBatch) {
+			// 收件箱少了事件，未读数要重新算
+			return f.cache.Del(ctx, uid)
+		}
+	}
+}
+
+func (f *feedEventRepo) CountPullEventsWithTyp(ctx context.Context, typ string, uids []int64, since time.Time) (int64, error) {
+	return f.pullDao.CountPullEventsWithTyp(ctx, typ, uids, since.UnixMilli())
+}
+
+func (f *feedEventRepo) GetReadCursor(ctx context.Context, uid int64) (domain.ReadCursor, error) {
+	res, err := f.cache.Get(ctx, uid)
+	if err == nil {
+		return res, nil
+	}
+	res = domain.ReadCursor{Uid: uid}
+	cursor, err := f.cursorDao.Get(ctx, uid)
+	switch {
+	case err == nil:
+		res.ReadTime = time.UnixMilli(cursor.ReadTime)
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		// 从来没有读过
+	default:
+		return domain.ReadCursor{}, err
+	}
+	res.PushUnread, err = f.pushDao.CountPushEvents(ctx, uid, res.ReadTime.UnixMilli())
+	if err != nil {
+		return domain.ReadCursor{}, err
+	}
+	err = f.cache.Set(ctx, res)
+	if err != nil {
+		f.l.Error("回写未读数缓存失败",
+			logger.Error(err),
+			logger.Int64("uid", uid))
+	}
+	return res, nil
+}
+
+func (f *feedEventRepo) MarkRead(ctx context.Context, uid int64, readTime time.Time) error {
+	err := f.cursorDao.Upsert(ctx, uid, readTime.UnixMilli())
+	if err != nil {
+		return err
+	}
+	// 直接删掉，下次读的时候重新算
+	return f.cache.Del(ctx, uid)
+}
+
+func (f *feedEventRepo) toDAOCursor(cursor domain.FeedCursor) dao.Cursor {
+	return dao.Cursor{
+		CTime:  cursor.Ctime,
+		Origin: uint8(cursor.Origin),
+		Id:     cursor.ID,
+	}
+}
+
+func (f *feedEventRepo) toPushEntity(evt domain.FeedEvent) dao.FeedPushEvent {
+	content, _ := json.Marshal(evt.Ext)
+	return dao.FeedPushEvent{
+		Id:      evt.ID,
+		Uid:     evt.Uid,
+		Source:  evt.Source,
+		Type:    evt.Type,
+		Content: string(content),
+		CTime:   evt.Ctime.UnixMilli(),
+		EventKey: sql.NullString{
+			String: evt.Key,
+			Valid:  evt.Key != "",
+		},
+	}
+}
+
+func (f *feedEventRepo) toPullEntity(evt domain.FeedEvent) dao.FeedPullEvent {
+	content, _ := json.Marshal(evt.Ext)
+	return dao.FeedPullEvent{
+		Id:      evt.ID,
+		Uid:     evt.Uid,
+		Type:    evt.Type,
+		Content: string(content),
+		CTime:   evt.Ctime.UnixMilli(),
+		EventKey: sql.NullString{
+			String: evt.Key,
+			Valid:  evt.Key != "",
+		},
+	}
+}
+
+func (f *feedEventRepo) pushToDomain(evt dao.FeedPushEvent) domain.FeedEvent {
+	var ext domain.ExtendFields
+	_ = json.Unmarshal([]byte(evt.Content), &ext)
+	return domain.FeedEvent{
+		ID:     evt.Id,
+		Uid:    evt.Uid,
+		Source: evt.Source,
+		Type:   evt.Type,
+		Ctime:  time.UnixMilli(evt.CTime),
+		Ext:    ext,
+		Key:    evt.EventKey.String,
+		Origin: domain.EventOriginPush,
+	}
+}
+
+func (f *feedEventRepo) pullToDomain(evt dao.FeedPullEvent) domain.FeedEvent {
+	var ext domain.ExtendFields
+	_ = json.Unmarshal([]byte(evt.Content), &ext)
+	return domain.FeedEvent{
+		ID:     evt.Id,
+		Uid:    evt.Uid,
+		Source: evt.Uid,
+		Type:   evt.Type,
+		Ctime:  time.UnixMilli(evt.CTime),
+		Ext:    ext,
+		Key:    evt.EventKey.String,
+		Origin: domain.EventOriginPull,
+	}
+}
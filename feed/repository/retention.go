@@ -0,0 +1,44 @@
+// Copyright@daidai53 2024
+package repository
+
+import (
+	"context"
+	"github.com/daidai53/webook/feed/repository/dao"
+)
+
+// RetentionRepository 清理收件箱或者发件箱
+type RetentionRepository interface {
+	// Uids 按照 uid 升序，找出 uid > minUid 的用户
+	Uids(ctx context.Context, minUid int64, limit int) ([]int64, error)
+	// NthCTime uid 第 n 新的事件的时间（毫秒），不足 n 条的时候返回 0
+	NthCTime(ctx context.Context, uid int64, n int) (int64, error)
+	// IdsBefore 找出 before 之前的事件，uid 大于 0 的时候只找这个用户的
+	IdsBefore(ctx context.Context, uid, before int64, limit int) ([]int64, error)
+	Remove(ctx context.Context, ids []int64) error
+}
+
+type retentionRepository struct {
+	dao dao.RetentionDAO
+}
+
+func NewRetentionRepository(dao dao.RetentionDAO) RetentionRepository {
+	return &retentionRepository{
+		dao: dao,
+	}
+}
+
+func (r *retentionRepository) Uids(ctx context.Context, minUid int64, limit int) ([]int64, error) {
+	return r.dao.Uids(ctx, minUid, limit)
+}
+
+func (r *retentionRepository) NthCTime(ctx context.Context, uid int64, n int) (int64, error) {
+	return r.dao.NthCTime(ctx, uid, n)
+}
+
+func (r *retentionRepository) IdsBefore(ctx context.Context, uid, before int64, limit int) ([]int64, error) {
+	return r.dao.IdsBefore(ctx, uid, before, limit)
+}
+
+func (r *retentionRepository) Remove(ctx context.Context, ids []int64) error {
+	return r.dao.Remove(ctx, ids)
+}
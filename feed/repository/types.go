@@ -4,6 +4,7 @@ package repository
 import (
 	"context"
 	"github.com/daidai53/webook/feed/domain"
+	"time"
 )
 
 type FeedEventRepo interface {
@@ -12,11 +13,22 @@ type FeedEventRepo interface {
 	// CreatePullEvent 创建拉事件
 	CreatePullEvent(ctx context.Context, event domain.FeedEvent) error
 	// FindPullEvents 获取拉事件，也就是关注的人发件箱里面的事件
-	FindPullEvents(ctx context.Context, uids []int64, timestamp, limit int64) ([]domain.FeedEvent, error)
+	// 所有的 Find 方法都只返回严格排在 cursor 后面的事件，按照 (Ctime, Origin, ID) 降序
+	FindPullEvents(ctx context.Context, uids []int64, cursor domain.FeedCursor, limit int64) ([]domain.FeedEvent, error)
 	// FindPushEvents 获取推事件，也就是自己收件箱里面的事件
-	FindPushEvents(ctx context.Context, uid, timestamp, limit int64) ([]domain.FeedEvent, error)
+	FindPushEvents(ctx context.Context, uid int64, cursor domain.FeedCursor, limit int64) ([]domain.FeedEvent, error)
 	// FindPullEventsWithTyp 获取某个类型的拉事件，
-	FindPullEventsWithTyp(ctx context.Context, typ string, uids []int64, timestamp, limit int64) ([]domain.FeedEvent, error)
+	FindPullEventsWithTyp(ctx context.Context, typ string, uids []int64, cursor domain.FeedCursor, limit int64) ([]domain.FeedEvent, error)
 	// FindPushEvents 获取某个类型的推事件，也就
-	FindPushEventsWithTyp(ctx context.Context, typ string, uid, timestamp, limit int64) ([]domain.FeedEvent, error)
+	FindPushEventsWithTyp(ctx context.Context, typ string, uid int64, cursor domain.FeedCursor, limit int64) ([]domain.FeedEvent, error)
+	// FindPushEventsBySource 获取 source 最近产生的推事件，关注的时候用来回填收件箱
+	FindPushEventsBySource(ctx context.Context, typ string, source, limit int64) ([]domain.FeedEvent, error)
+	// DeletePushEventsBySource 清理 uid 收件箱里面由 sources 产生的事件，取消关注的时候用
+	DeletePushEventsBySource(ctx context.Context, uid int64, sources []int64) error
+	// CountPullEventsWithTyp 统计 uids 发件箱里面 since 之后的某个类型的事件，算拉模型的未读数用
+	CountPullEventsWithTyp(ctx context.Context, typ string, uids []int64, since time.Time) (int64, error)
+	// GetReadCursor 获取用户已读的位置，以及收件箱里面的未读数
+	GetReadCursor(ctx context.Context, uid int64) (domain.ReadCursor, error)
+	// MarkRead 把 readTime 之前的事件都标记为已读
+	MarkRead(ctx context.Context, uid int64, readTime time.Time) error
 }
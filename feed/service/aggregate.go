@@ -0,0 +1,86 @@
+// Copyright@daidai53 2024
+package service
+
+import (
+	"fmt"
+	"github.com/daidai53/webook/feed/domain"
+	"github.com/ecodeclub/ekit/slice"
+	"time"
+)
+
+// AggregateConfig 聚合的配置
+type AggregateConfig struct {
+	// Window 同一个目标上的同类事件，落在同一个 Window 里面的会被聚合成一条
+	// 小于等于 0 就是不聚合
+	Window time.Duration
+	// SampleSize 聚合之后最多保留多少个行为人
+	SampleSize int
+	// FetchFactor 聚合之后条目会变少，所以每次要多查一些原始事件
+	FetchFactor int64
+}
+
+var defaultAggregateConfig = AggregateConfig{
+	Window:      time.Hour,
+	SampleSize:  3,
+	FetchFactor: 5,
+}
+
+// aggregate events 必须已经按照游标的顺序排好了
+// 返回聚合之后的条目，以及下一页该用的游标
+// 只要原始事件被放进了某个条目，next 就一定会越过它，所以翻页不会重复也不会遗漏
+// 代价是一个聚合组可能在翻页的地方被切成两条
+func (f *feedService) aggregate(events []domain.FeedEvent, cursor domain.FeedCursor, limit int64) ([]domain.FeedEvent, domain.FeedCursor) {
+	res := make([]domain.FeedEvent, 0, limit)
+	// 聚合的 key 到 res 下标的映射
+	groups := make(map[string]int, limit)
+	next := cursor
+	for _, evt := range events {
+		key, actor, ok := f.aggregateKey(evt)
+		if ok {
+			if idx, exist := groups[key]; exist {
+				entry := &res[idx]
+				entry.Count++
+				entry.Earliest = evt.Ctime
+				if len(entry.Actors) < f.aggCfg.SampleSize && !slice.Contains(entry.Actors, actor) {
+					entry.Actors = append(entry.Actors, actor)
+				}
+				next = domain.CursorOf(evt)
+				continue
+			}
+		}
+		if int64(len(res)) >= limit {
+			break
+		}
+		evt.Count = 1
+		evt.Earliest = evt.Ctime
+		if ok {
+			evt.Actors = []int64{actor}
+			groups[key] = len(res)
+		}
+		res = append(res, evt)
+		next = domain.CursorOf(evt)
+	}
+	return res, next
+}
+
+func (f *feedService) aggregateKey(evt domain.FeedEvent) (string, int64, bool) {
+	window := f.aggCfg.Window.Milliseconds()
+	if window <= 0 {
+		return "", 0, false
+	}
+	handler, ok := f.handlerMap[evt.Type]
+	if !ok {
+		return "", 0, false
+	}
+	agg, ok := handler.(Aggregator)
+	if !ok {
+		return "", 0, false
+	}
+	target, actor, ok := agg.AggregateTarget(evt)
+	if !ok {
+		return "", 0, false
+	}
+	// 按照绝对时间切窗口，同一个事件无论出现在哪一页，算出来的 key 都是一样的
+	bucket := evt.Ctime.UnixMilli() / window
+	return fmt.Sprintf("%s:%s:%d", evt.Type, target, bucket), actor, true
+}
@@ -0,0 +1,103 @@
+// Copyright@daidai53 2024
+package service
+
+import (
+	"github.com/daidai53/webook/feed/domain"
+	"github.com/stretchr/testify/assert"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestFeedService_aggregate(t *testing.T) {
+	base := time.UnixMilli(10 * time.Hour.Milliseconds())
+	like := func(bizId, liker int64, before time.Duration) domain.FeedEvent {
+		return domain.FeedEvent{
+			Type:  likeEventName,
+			Ctime: base.Add(-before),
+			Ext: domain.ExtendFields{
+				"biz":   "article",
+				"bizId": itoa(bizId),
+				"liker": itoa(liker),
+			},
+		}
+	}
+	article := func(before time.Duration) domain.FeedEvent {
+		return domain.FeedEvent{
+			Type:  articleEvent,
+			Ctime: base.Add(-before),
+		}
+	}
+	testCases := []struct {
+		name   string
+		events []domain.FeedEvent
+		limit  int64
+
+		wantCounts []int64
+		wantActors [][]int64
+		wantNext   domain.FeedCursor
+	}{
+		{
+			name: "同一篇文章的点赞聚合成一条",
+			events: []domain.FeedEvent{
+				like(1, 11, time.Second),
+				like(1, 12, 2*time.Second),
+				article(3 * time.Second),
+				like(1, 13, 4*time.Second),
+				like(1, 14, 5*time.Second),
+			},
+			limit:      10,
+			wantCounts: []int64{4, 1},
+			wantActors: [][]int64{{11, 12, 13}, nil},
+			wantNext:   domain.FeedCursor{Ctime: base.Add(-5 * time.Second).UnixMilli()},
+		},
+		{
+			name: "不同窗口不聚合",
+			events: []domain.FeedEvent{
+				like(1, 11, time.Second),
+				like(1, 12, time.Hour+time.Second),
+			},
+			limit:      10,
+			wantCounts: []int64{1, 1},
+			wantActors: [][]int64{{11}, {12}},
+			wantNext:   domain.FeedCursor{Ctime: base.Add(-time.Hour - time.Second).UnixMilli()},
+		},
+		{
+			name: "满了之后只吸收已有分组的事件",
+			events: []domain.FeedEvent{
+				like(1, 11, time.Second),
+				like(2, 12, 2*time.Second),
+				like(1, 13, 3*time.Second),
+				article(4 * time.Second),
+				like(1, 14, 5*time.Second),
+			},
+			limit:      2,
+			wantCounts: []int64{2, 1},
+			wantActors: [][]int64{{11, 13}, {12}},
+			// 下一页从 article 开始
+			wantNext: domain.FeedCursor{Ctime: base.Add(-3 * time.Second).UnixMilli()},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := NewFeedService(nil, map[string]Handler{
+				likeEventName: &LikeEventHandler{},
+				articleEvent:  &ArticleEventHandler{},
+			}, nil, AggregateConfig{Window: time.Hour}, nil).(*feedService)
+			res, next := svc.aggregate(tc.events, domain.FeedCursor{}, tc.limit)
+			counts := make([]int64, 0, len(res))
+			actors := make([][]int64, 0, len(res))
+			for _, r := range res {
+				counts = append(counts, r.Count)
+				actors = append(actors, r.Actors)
+			}
+			assert.Equal(t, tc.wantCounts, counts)
+			assert.Equal(t, tc.wantActors, actors)
+			assert.Equal(t, tc.wantNext, next)
+		})
+	}
+}
+
+func itoa(i int64) string {
+	return strconv.FormatInt(i, 10)
+}
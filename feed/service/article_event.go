@@ -8,7 +8,7 @@ import (
 	"github.com/daidai53/webook/feed/repository"
 	"github.com/ecodeclub/ekit/slice"
 	"golang.org/x/sync/errgroup"
-	"sort"
+	"strconv"
 	"sync"
 	"time"
 )
@@ -22,6 +22,9 @@ type ArticleEventHandler struct {
 const threshold = 100
 const articleEvent = "article_event"
 
+// 关注之后回填多少条
+const backfillLimit = 20
+
 func (a *ArticleEventHandler) CreateFeedEvent(ctx context.Context, ext domain.ExtendFields) error {
 	followee, err := ext.Get("followee").AsInt64()
 	if err != nil {
@@ -35,12 +38,16 @@ func (a *ArticleEventHandler) CreateFeedEvent(ctx context.Context, ext domain.Ex
 		return err
 	}
 
+	// 同一批推出去的事件用同一个时间，它也就是这一批事件的 Key，重复回填的时候靠它去重
+	now := time.Now()
+	key := strconv.FormatInt(now.UnixMilli(), 10)
 	if resp.GetFollowStatic().GetFollowers() > threshold {
 		return a.repo.CreatePullEvent(ctx, domain.FeedEvent{
-			Uid:   followee,
-			Type:  articleEvent,
-			Ctime: time.Now(),
-			Ext:   ext,
+			Uid:    followee,
+			Source: followee,
+			Type:   articleEvent,
+			Ctime:  now,
+			Ext:    ext,
 		})
 	}
 
@@ -57,16 +64,53 @@ func (a *ArticleEventHandler) CreateFeedEvent(ctx context.Context, ext domain.Ex
 			return domain.FeedEvent{}
 		}
 		return domain.FeedEvent{
-			Uid:   src.Follower,
-			Type:  articleEvent,
-			Ctime: time.Now(),
-			Ext:   ext,
+			Uid:    src.Follower,
+			Source: followee,
+			Type:   articleEvent,
+			Ctime:  now,
+			Ext:    ext,
+			Key:    key,
 		}
 	})
 	return a.repo.CreatePushEvents(ctx, events)
 }
 
-func (a *ArticleEventHandler) FindFeedEvents(ctx context.Context, uid, timestamp, limit int64) ([]domain.FeedEvent, error) {
+// Backfill 只有推模型才需要回填，拉模型下读的时候本来就会去查被关注的人的发件箱
+func (a *ArticleEventHandler) Backfill(ctx context.Context, follower, followee int64) ([]domain.FeedEvent, error) {
+	events, err := a.repo.FindPushEventsBySource(ctx, articleEvent, followee, backfillLimit)
+	if err != nil {
+		return nil, err
+	}
+	for i := range events {
+		events[i].ID = 0
+		events[i].Uid = follower
+		if events[i].Key == "" {
+			// 以前没有 Key 的事件，按照推出去的时间补上
+			events[i].Key = strconv.FormatInt(events[i].Ctime.UnixMilli(), 10)
+		}
+	}
+	return events, nil
+}
+
+// CountUnread 推模型的部分已经算在收件箱的未读数里面了，这里只算关注的人发件箱里面的
+func (a *ArticleEventHandler) CountUnread(ctx context.Context, uid int64, since time.Time) (int64, error) {
+	resp, err := a.followClient.GetFollowee(ctx, &followv1.GetFolloweeRequest{
+		Follower: uid,
+		Limit:    10000,
+	})
+	if err != nil {
+		return 0, err
+	}
+	followeeIds := slice.Map(resp.GetFollowRelations(), func(idx int, src *followv1.FollowRelation) int64 {
+		return src.Followee
+	})
+	if len(followeeIds) == 0 {
+		return 0, nil
+	}
+	return a.repo.CountPullEventsWithTyp(ctx, articleEvent, followeeIds, since)
+}
+
+func (a *ArticleEventHandler) FindFeedEvents(ctx context.Context, uid int64, cursor domain.FeedCursor, limit int64) ([]domain.FeedEvent, error) {
 	var eg errgroup.Group
 	var lock sync.Mutex
 	events := make([]domain.FeedEvent, 0, limit*2)
@@ -82,7 +126,7 @@ func (a *ArticleEventHandler) FindFeedEvents(ctx context.Context, uid, timestamp
 		followeeIds := slice.Map(resp.GetFollowRelations(), func(idx int, src *followv1.FollowRelation) int64 {
 			return src.Followee
 		})
-		evts, err := a.repo.FindPullEventsWithTyp(ctx, articleEvent, followeeIds, timestamp, limit)
+		evts, err := a.repo.FindPullEventsWithTyp(ctx, articleEvent, followeeIds, cursor, limit)
 		if err != nil {
 			return err
 		}
@@ -93,7 +137,7 @@ func (a *ArticleEventHandler) FindFeedEvents(ctx context.Context, uid, timestamp
 	})
 
 	eg.Go(func() error {
-		evts, err := a.repo.FindPushEventsWithTyp(ctx, articleEvent, uid, timestamp, limit)
+		evts, err := a.repo.FindPushEventsWithTyp(ctx, articleEvent, uid, cursor, limit)
 		if err != nil {
 			return err
 		}
@@ -110,8 +154,6 @@ func (a *ArticleEventHandler) FindFeedEvents(ctx context.Context, uid, timestamp
 	}
 
 	// 排序
-	sort.Slice(events, func(i, j int) bool {
-		return events[i].Ctime.UnixMilli() > events[j].Ctime.UnixMilli()
-	})
+	sortEvents(events)
 	return events[:min(int(limit), len(events))], nil
 }
@@ -7,16 +7,37 @@ import (
 	followv1 "github.com/daidai53/webook/api/proto/gen/follow/v1"
 	"github.com/daidai53/webook/feed/domain"
 	"github.com/daidai53/webook/feed/repository"
+	"github.com/daidai53/webook/pkg/logger"
 	"github.com/ecodeclub/ekit/slice"
 	"golang.org/x/sync/errgroup"
 	"sort"
 	"sync"
+	"time"
 )
 
 type feedService struct {
 	repo         repository.FeedEventRepo
 	handlerMap   map[string]Handler
 	followClient followv1.FollowServiceClient
+	aggCfg       AggregateConfig
+	l            logger.LoggerV1
+}
+
+func NewFeedService(repo repository.FeedEventRepo, handlerMap map[string]Handler,
+	followClient followv1.FollowServiceClient, aggCfg AggregateConfig, l logger.LoggerV1) FeedService {
+	if aggCfg.SampleSize <= 0 {
+		aggCfg.SampleSize = defaultAggregateConfig.SampleSize
+	}
+	if aggCfg.FetchFactor <= 0 {
+		aggCfg.FetchFactor = defaultAggregateConfig.FetchFactor
+	}
+	return &feedService{
+		repo:         repo,
+		handlerMap:   handlerMap,
+		followClient: followClient,
+		aggCfg:       aggCfg,
+		l:            l,
+	}
 }
 
 func (f *feedService) CreateFeedEvent(ctx context.Context, feed domain.FeedEvent) error {
@@ -29,15 +50,39 @@ func (f *feedService) CreateFeedEvent(ctx context.Context, feed domain.FeedEvent
 	return handler.CreateFeedEvent(ctx, feed.Ext)
 }
 
-// GetFeedEventList 利用Handler查
-func (f *feedService) GetFeedEventList(ctx context.Context, uid, timestamp, limit int64) ([]domain.FeedEvent, error) {
+// GetFeedEventList 利用Handler查，查出来之后再做聚合
+func (f *feedService) GetFeedEventList(ctx context.Context, uid int64, cursor string, limit int64) ([]domain.FeedEvent, string, error) {
+	cur, err := domain.DecodeFeedCursor(cursor)
+	if err != nil {
+		return nil, "", err
+	}
 	var eg errgroup.Group
 	var lock sync.Mutex
-	events := make([]domain.FeedEvent, 0, int(limit)*len(f.handlerMap))
+	// 聚合之后条目会变少，所以要多查一些
+	fetchLimit := limit * f.aggCfg.FetchFactor
+	events := make([]domain.FeedEvent, 0, int(fetchLimit)*len(f.handlerMap))
+	var readCursor domain.ReadCursor
+	eg.Go(func() error {
+		// 已读位置查不到就当全部未读，不能因为它整个 feed 都出不来
+		res, err := f.repo.GetReadCursor(ctx, uid)
+		if err != nil {
+			f.l.Error("查询已读位置失败",
+				logger.Int64("uid", uid),
+				logger.Error(err))
+			res = domain.ReadCursor{Uid: uid}
+		}
+		readCursor = res
+		return nil
+	})
+	var hidden map[int64]struct{}
+	eg.Go(func() error {
+		hidden = f.hiddenUsers(ctx, uid)
+		return nil
+	})
 	for _, handler := range f.handlerMap {
 		h := handler
 		eg.Go(func() error {
-			evts, err := h.FindFeedEvents(ctx, uid, timestamp, limit)
+			evts, err := h.FindFeedEvents(ctx, uid, cur, fetchLimit)
 			if err != nil {
 				return err
 			}
@@ -47,19 +92,125 @@ func (f *feedService) GetFeedEventList(ctx context.Context, uid, timestamp, limi
 			return nil
 		})
 	}
-	err := eg.Wait()
+	err = eg.Wait()
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
-	sort.Slice(events, func(i, j int) bool {
-		return events[i].Ctime.UnixMilli() > events[j].Ctime.UnixMilli()
+	sortEvents(events)
+	window := events[:min(int(fetchLimit), len(events))]
+	visible := f.filterHidden(window, hidden)
+	res, next := f.aggregate(visible, cur, limit)
+	// 能看到的都放进去了，说明这一批查出来的都处理完了，游标要越过被过滤掉的，
+	// 不然整批都被过滤掉的时候游标不动，会一直查同一页
+	if len(window) > 0 && (len(visible) == 0 || next == domain.CursorOf(visible[len(visible)-1])) {
+		next = domain.CursorOf(window[len(window)-1])
+	}
+	for i := range res {
+		res[i].Unread = res[i].Ctime.After(readCursor.ReadTime)
+	}
+	return res, next.Encode(), nil
+}
+
+// hiddenUsers 拉黑、屏蔽了的人，还有拉黑了自己的人，他们的内容都不展示。
+// 关注服务出问题了就不过滤，不影响看 feed
+func (f *feedService) hiddenUsers(ctx context.Context, uid int64) map[int64]struct{} {
+	resp, err := f.followClient.GetHiddenUsers(ctx, &followv1.GetHiddenUsersRequest{
+		Uid: uid,
 	})
-	return events[:min(int(limit), len(events))], nil
+	if err != nil {
+		f.l.Error("查询拉黑、屏蔽的人失败",
+			logger.Int64("uid", uid),
+			logger.Error(err))
+		return nil
+	}
+	res := make(map[int64]struct{}, len(resp.GetUids()))
+	for _, id := range resp.GetUids() {
+		res[id] = struct{}{}
+	}
+	return res
+}
+
+// filterHidden 按照事件是谁产生的来过滤，点赞、回复这种没有 Source 的看行为人
+func (f *feedService) filterHidden(events []domain.FeedEvent, hidden map[int64]struct{}) []domain.FeedEvent {
+	if len(hidden) == 0 {
+		return events
+	}
+	res := make([]domain.FeedEvent, 0, len(events))
+	for _, evt := range events {
+		if _, ok := hidden[f.actorOf(evt)]; ok {
+			continue
+		}
+		res = append(res, evt)
+	}
+	return res
+}
+
+func (f *feedService) actorOf(evt domain.FeedEvent) int64 {
+	if evt.Source != 0 {
+		return evt.Source
+	}
+	handler, ok := f.handlerMap[evt.Type]
+	if !ok {
+		return 0
+	}
+	agg, ok := handler.(Aggregator)
+	if !ok {
+		return 0
+	}
+	_, actor, _ := agg.AggregateTarget(evt)
+	return actor
+}
+
+func (f *feedService) GetUnreadCount(ctx context.Context, uid int64) (int64, error) {
+	cursor, err := f.repo.GetReadCursor(ctx, uid)
+	if err != nil {
+		return 0, err
+	}
+	var eg errgroup.Group
+	var lock sync.Mutex
+	cnt := cursor.PushUnread
+	for _, handler := range f.handlerMap {
+		counter, ok := handler.(UnreadCounter)
+		if !ok {
+			continue
+		}
+		uc := counter
+		eg.Go(func() error {
+			c, er := uc.CountUnread(ctx, uid, cursor.ReadTime)
+			if er != nil {
+				return er
+			}
+			lock.Lock()
+			cnt += c
+			lock.Unlock()
+			return nil
+		})
+	}
+	err = eg.Wait()
+	return cnt, err
+}
+
+func (f *feedService) MarkRead(ctx context.Context, uid, timestamp int64) error {
+	now := time.Now()
+	readTime := now
+	// 客户端的时间不可信，不能标记到未来，不然之后的新事件都成了已读
+	if timestamp > 0 && timestamp < now.UnixMilli() {
+		readTime = time.UnixMilli(timestamp)
+	}
+	cursor, err := f.repo.GetReadCursor(ctx, uid)
+	if err != nil {
+		return err
+	}
+	// 客户端的请求可能乱序，已读的位置只能往后走
+	if !readTime.After(cursor.ReadTime) {
+		return nil
+	}
+	return f.repo.MarkRead(ctx, uid, readTime)
 }
 
 // GetFeedEventListV1 直接查
-func (f *feedService) GetFeedEventListV1(ctx context.Context, uid, timestamp, limit int64) ([]domain.FeedEvent, error) {
+func (f *feedService) GetFeedEventListV1(ctx context.Context, uid int64, cursor domain.FeedCursor, limit int64) ([]domain.FeedEvent, error) {
 	var eg errgroup.Group
 	var lock sync.Mutex
 	events := make([]domain.FeedEvent, 0, limit*2)
@@ -75,7 +226,7 @@ func (f *feedService) GetFeedEventListV1(ctx context.Context, uid, timestamp, li
 		followeeIds := slice.Map(resp.GetFollowRelations(), func(idx int, src *followv1.FollowRelation) int64 {
 			return src.Followee
 		})
-		evts, err := f.repo.FindPullEvents(ctx, followeeIds, timestamp, limit)
+		evts, err := f.repo.FindPullEvents(ctx, followeeIds, cursor, limit)
 		if err != nil {
 			return err
 		}
@@ -86,7 +237,7 @@ func (f *feedService) GetFeedEventListV1(ctx context.Context, uid, timestamp, li
 	})
 
 	eg.Go(func() error {
-		evts, err := f.repo.FindPushEvents(ctx, uid, timestamp, limit)
+		evts, err := f.repo.FindPushEvents(ctx, uid, cursor, limit)
 		if err != nil {
 			return err
 		}
@@ -103,10 +254,56 @@ func (f *feedService) GetFeedEventListV1(ctx context.Context, uid, timestamp, li
 	}
 
 	// 排序
+	sortEvents(events)
+	return events[:min(int(limit), len(events))], nil
+}
+
+func (f *feedService) SyncFollowRelations(ctx context.Context, changes []domain.FollowChange) error {
+	// 同一对关系在一批里面可能变了好几次，只看最后一次
+	type pair struct {
+		follower int64
+		followee int64
+	}
+	latest := make(map[pair]bool, len(changes))
+	for _, c := range changes {
+		latest[pair{follower: c.Follower, followee: c.Followee}] = c.Active
+	}
+
+	// 取消关注的清理收件箱，关注的回填，回填的事件带着 Key，重复消费也不会重复写
+	purge := make(map[int64][]int64, len(latest))
+	var backfill []domain.FeedEvent
+	for p, active := range latest {
+		if !active {
+			purge[p.follower] = append(purge[p.follower], p.followee)
+			continue
+		}
+		for _, handler := range f.handlerMap {
+			syncer, ok := handler.(FollowSyncer)
+			if !ok {
+				continue
+			}
+			evts, err := syncer.Backfill(ctx, p.follower, p.followee)
+			if err != nil {
+				return err
+			}
+			backfill = append(backfill, evts...)
+		}
+	}
+	for follower, followees := range purge {
+		err := f.repo.DeletePushEventsBySource(ctx, follower, followees)
+		if err != nil {
+			return err
+		}
+	}
+	// 批量写
+	return f.repo.CreatePushEvents(ctx, backfill)
+}
+
+// sortEvents 按照 (Ctime, Origin, ID) 降序排，和翻页游标的顺序保持一致
+func sortEvents(events []domain.FeedEvent) {
 	sort.Slice(events, func(i, j int) bool {
-		return events[i].Ctime.UnixMilli() > events[j].Ctime.UnixMilli()
+		return domain.CursorOf(events[j]).Less(domain.CursorOf(events[i]))
 	})
-	return events[:min(int(limit), len(events))], nil
 }
 
 func (f *feedService) registerService(typ string, handler Handler) {
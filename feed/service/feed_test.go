@@ -0,0 +1,270 @@
+// Copyright@daidai53 2024
+package service
+
+import (
+	"context"
+	"errors"
+	followv1 "github.com/daidai53/webook/api/proto/gen/follow/v1"
+	"github.com/daidai53/webook/feed/domain"
+	"github.com/daidai53/webook/feed/repository"
+	"github.com/daidai53/webook/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"testing"
+	"time"
+)
+
+func TestFeedService_filterHidden(t *testing.T) {
+	svc := NewFeedService(nil, map[string]Handler{
+		likeEventName:  &LikeEventHandler{},
+		articleEvent:   &ArticleEventHandler{},
+		ReplyEventName: &ReplyEventHandler{},
+	}, nil, AggregateConfig{}, nil).(*feedService)
+	events := []domain.FeedEvent{
+		{ID: 1, Type: articleEvent, Source: 11},
+		{ID: 2, Type: articleEvent, Source: 12},
+		{ID: 3, Type: likeEventName, Ext: domain.ExtendFields{"biz": "article", "bizId": "1", "liker": "11"}},
+		{ID: 4, Type: likeEventName, Ext: domain.ExtendFields{"biz": "article", "bizId": "1", "liker": "13"}},
+		{ID: 5, Type: ReplyEventName, Ext: domain.ExtendFields{"parentId": "1", "replier": "11"}},
+		// 解析不出来行为人的留着
+		{ID: 6, Type: likeEventName},
+	}
+	res := svc.filterHidden(events, map[int64]struct{}{11: {}})
+	ids := make([]int64, 0, len(res))
+	for _, evt := range res {
+		ids = append(ids, evt.ID)
+	}
+	assert.Equal(t, []int64{2, 4, 6}, ids)
+}
+
+func TestFeedService_GetFeedEventList_Hidden(t *testing.T) {
+	base := time.UnixMilli(10 * time.Hour.Milliseconds())
+	article := func(id, source int64) domain.FeedEvent {
+		return domain.FeedEvent{
+			ID:     id,
+			Type:   articleEvent,
+			Source: source,
+			Ctime:  base.Add(-time.Duration(id) * time.Second),
+		}
+	}
+	testCases := []struct {
+		name     string
+		events   []domain.FeedEvent
+		hidden   []int64
+		hiddenEr error
+		cursorEr error
+		limit    int64
+
+		wantIds  []int64
+		wantNext domain.FeedCursor
+	}{
+		{
+			name:     "整批都被过滤掉，游标也要往后走",
+			events:   []domain.FeedEvent{article(1, 11), article(2, 11)},
+			hidden:   []int64{11},
+			limit:    1,
+			wantIds:  []int64{},
+			wantNext: domain.CursorOf(article(2, 11)),
+		},
+		{
+			name:     "最后被过滤掉的也要越过",
+			events:   []domain.FeedEvent{article(1, 12), article(2, 11)},
+			hidden:   []int64{11},
+			limit:    2,
+			wantIds:  []int64{1},
+			wantNext: domain.CursorOf(article(2, 11)),
+		},
+		{
+			name:     "满了之后游标停在最后一条放进去的",
+			events:   []domain.FeedEvent{article(1, 12), article(2, 11), article(3, 12)},
+			hidden:   []int64{11},
+			limit:    1,
+			wantIds:  []int64{1},
+			wantNext: domain.CursorOf(article(1, 12)),
+		},
+		{
+			name:     "关注服务出问题了就不过滤",
+			events:   []domain.FeedEvent{article(1, 11)},
+			hiddenEr: errors.New("mock error"),
+			limit:    1,
+			wantIds:  []int64{1},
+			wantNext: domain.CursorOf(article(1, 11)),
+		},
+		{
+			name:     "已读位置查不到也照样出 feed",
+			events:   []domain.FeedEvent{article(1, 12)},
+			cursorEr: errors.New("mock error"),
+			limit:    1,
+			wantIds:  []int64{1},
+			wantNext: domain.CursorOf(article(1, 12)),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := NewFeedService(stubFeedRepo{cursorErr: tc.cursorEr}, map[string]Handler{
+				articleEvent: stubHandler{events: tc.events},
+			}, stubFollowClient{hidden: tc.hidden, err: tc.hiddenEr},
+				AggregateConfig{FetchFactor: 3}, logger.NewNopLogger())
+			res, next, err := svc.GetFeedEventList(context.Background(), 1, "", tc.limit)
+			require.NoError(t, err)
+			ids := make([]int64, 0, len(res))
+			for _, evt := range res {
+				ids = append(ids, evt.ID)
+			}
+			assert.Equal(t, tc.wantIds, ids)
+			assert.Equal(t, tc.wantNext.Encode(), next)
+		})
+	}
+}
+
+func TestFeedService_MarkRead(t *testing.T) {
+	past := time.Now().Add(-time.Minute)
+	testCases := []struct {
+		name      string
+		timestamp int64
+
+		// 为零说明按照现在的时间算
+		wantReadTime time.Time
+	}{
+		{
+			name:         "客户端给的时间",
+			timestamp:    past.UnixMilli(),
+			wantReadTime: time.UnixMilli(past.UnixMilli()),
+		},
+		{
+			name:      "未来的时间按照现在算",
+			timestamp: time.Now().Add(time.Hour).UnixMilli(),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := &markFeedRepo{}
+			svc := NewFeedService(repo, map[string]Handler{}, nil, AggregateConfig{}, logger.NewNopLogger())
+			err := svc.MarkRead(context.Background(), 1, tc.timestamp)
+			require.NoError(t, err)
+			if tc.wantReadTime.IsZero() {
+				assert.False(t, repo.readTime.After(time.Now()))
+				return
+			}
+			assert.Equal(t, tc.wantReadTime, repo.readTime)
+		})
+	}
+}
+
+type markFeedRepo struct {
+	repository.FeedEventRepo
+	readTime time.Time
+}
+
+func (m *markFeedRepo) GetReadCursor(ctx context.Context, uid int64) (domain.ReadCursor, error) {
+	return domain.ReadCursor{Uid: uid}, nil
+}
+
+func (m *markFeedRepo) MarkRead(ctx context.Context, uid int64, readTime time.Time) error {
+	m.readTime = readTime
+	return nil
+}
+
+func TestFeedService_SyncFollowRelations(t *testing.T) {
+	testCases := []struct {
+		name    string
+		changes []domain.FollowChange
+
+		wantPurge    map[int64][]int64
+		wantBackfill []domain.FeedEvent
+	}{
+		{
+			name: "关注只回填，不清理",
+			changes: []domain.FollowChange{
+				{Follower: 1, Followee: 2, Active: true},
+			},
+			wantPurge: map[int64][]int64{},
+			wantBackfill: []domain.FeedEvent{
+				{Uid: 1, Source: 2, Type: articleEvent, Key: "123"},
+			},
+		},
+		{
+			name: "取消关注只清理",
+			changes: []domain.FollowChange{
+				{Follower: 1, Followee: 2, Active: true},
+				{Follower: 1, Followee: 2, Active: false},
+			},
+			wantPurge: map[int64][]int64{1: {2}},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := &syncFeedRepo{purge: map[int64][]int64{}}
+			svc := NewFeedService(repo, map[string]Handler{
+				articleEvent: stubSyncer{},
+			}, nil, AggregateConfig{}, logger.NewNopLogger())
+			err := svc.SyncFollowRelations(context.Background(), tc.changes)
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantPurge, repo.purge)
+			assert.Equal(t, tc.wantBackfill, repo.created)
+		})
+	}
+}
+
+type syncFeedRepo struct {
+	repository.FeedEventRepo
+	purge   map[int64][]int64
+	created []domain.FeedEvent
+}
+
+func (s *syncFeedRepo) DeletePushEventsBySource(ctx context.Context, uid int64, sources []int64) error {
+	s.purge[uid] = append(s.purge[uid], sources...)
+	return nil
+}
+
+func (s *syncFeedRepo) CreatePushEvents(ctx context.Context, events []domain.FeedEvent) error {
+	s.created = append(s.created, events...)
+	return nil
+}
+
+type stubSyncer struct {
+	stubHandler
+}
+
+func (stubSyncer) Backfill(ctx context.Context, follower, followee int64) ([]domain.FeedEvent, error) {
+	return []domain.FeedEvent{{Uid: follower, Source: followee, Type: articleEvent, Key: "123"}}, nil
+}
+
+type stubFeedRepo struct {
+	repository.FeedEventRepo
+	cursorErr error
+}
+
+func (s stubFeedRepo) GetReadCursor(ctx context.Context, uid int64) (domain.ReadCursor, error) {
+	return domain.ReadCursor{}, s.cursorErr
+}
+
+type stubHandler struct {
+	events []domain.FeedEvent
+}
+
+func (s stubHandler) CreateFeedEvent(ctx context.Context, ext domain.ExtendFields) error {
+	return nil
+}
+
+func (s stubHandler) FindFeedEvents(ctx context.Context, uid int64, cursor domain.FeedCursor, limit int64) ([]domain.FeedEvent, error) {
+	res := make([]domain.FeedEvent, 0, len(s.events))
+	for _, evt := range s.events {
+		if int64(len(res)) < limit {
+			res = append(res, evt)
+		}
+	}
+	return res, nil
+}
+
+type stubFollowClient struct {
+	followv1.FollowServiceClient
+	hidden []int64
+	err    error
+}
+
+func (s stubFollowClient) GetHiddenUsers(ctx context.Context, in *followv1.GetHiddenUsersRequest,
+	opts ...grpc.CallOption) (*followv1.GetHiddenUsersResponse, error) {
+	return &followv1.GetHiddenUsersResponse{Uids: s.hidden}, s.err
+}
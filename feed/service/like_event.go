@@ -3,6 +3,7 @@ package service
 
 import (
 	"context"
+	"fmt"
 	"github.com/daidai53/webook/feed/domain"
 	"github.com/daidai53/webook/feed/repository"
 	"github.com/daidai53/webook/internal/service"
@@ -46,9 +47,34 @@ func (l *LikeEventHandler) CreateFeedEvent(ctx context.Context, ext domain.Exten
 	)
 }
 
-func (l *LikeEventHandler) FindFeedEvents(ctx context.Context, uid, timestamp, limit int64) ([]domain.FeedEvent, error) {
+func (l *LikeEventHandler) FindFeedEvents(ctx context.Context, uid int64, cursor domain.FeedCursor, limit int64) ([]domain.FeedEvent, error) {
 	if act, err := l.userService.IsActiveUser(ctx, uid); err == nil && act {
-		return l.repo.FindPullEventsWithTyp(ctx, likeEventName, []int64{uid}, timestamp, limit)
+		return l.repo.FindPullEventsWithTyp(ctx, likeEventName, []int64{uid}, cursor, limit)
 	}
-	return l.repo.FindPushEventsWithTyp(ctx, likeEventName, uid, timestamp, limit)
+	return l.repo.FindPushEventsWithTyp(ctx, likeEventName, uid, cursor, limit)
+}
+
+// CountUnread 活跃用户走的是拉模型，要读的时候算；不活跃的用户已经算在收件箱里面了
+func (l *LikeEventHandler) CountUnread(ctx context.Context, uid int64, since time.Time) (int64, error) {
+	if act, err := l.userService.IsActiveUser(ctx, uid); err == nil && act {
+		return l.repo.CountPullEventsWithTyp(ctx, likeEventName, []int64{uid}, since)
+	}
+	return 0, nil
+}
+
+// AggregateTarget 同一个资源上的点赞可以聚合，例如 "A 和其他 12 个人赞了你的文章"
+func (l *LikeEventHandler) AggregateTarget(evt domain.FeedEvent) (string, int64, bool) {
+	biz, err := evt.Ext.Get("biz").AsString()
+	if err != nil {
+		return "", 0, false
+	}
+	bizId, err := evt.Ext.Get("bizId").AsInt64()
+	if err != nil {
+		return "", 0, false
+	}
+	liker, err := evt.Ext.Get("liker").AsInt64()
+	if err != nil {
+		return "", 0, false
+	}
+	return fmt.Sprintf("%s:%d", biz, bizId), liker, true
 }
@@ -0,0 +1,79 @@
+// Copyright@daidai53 2024
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/daidai53/webook/feed/domain"
+	"github.com/daidai53/webook/feed/repository"
+	"github.com/daidai53/webook/internal/service"
+	"time"
+)
+
+// ReplyEventName "X 回复了你"，评论服务那边的评论事件转过来的
+const ReplyEventName = "reply_event"
+
+type ReplyEventHandler struct {
+	repo        repository.FeedEventRepo
+	userService service.UserService
+}
+
+func NewReplyEventHandler(repo repository.FeedEventRepo, userService service.UserService) *ReplyEventHandler {
+	return &ReplyEventHandler{
+		repo:        repo,
+		userService: userService,
+	}
+}
+
+func (r *ReplyEventHandler) CreateFeedEvent(ctx context.Context, ext domain.ExtendFields) error {
+	// 被回复的人
+	uid, err := ext.Get("replied").AsInt64()
+	if err != nil {
+		return err
+	}
+	cid, err := ext.Get("cid").AsInt64()
+	if err != nil {
+		return err
+	}
+	// 不设置 Source，不然取消关注回复的人的时候，收件箱里面的回复也会被清理掉
+	// 同一条回复只提醒一次，评论事件重复消费的时候靠 Key 去重
+	evt := domain.FeedEvent{
+		Uid:   uid,
+		Ext:   ext,
+		Type:  ReplyEventName,
+		Ctime: time.Now(),
+		Key:   fmt.Sprintf("%s:%d", ReplyEventName, cid),
+	}
+	if act, err := r.userService.IsActiveUser(ctx, uid); err == nil && act {
+		return r.repo.CreatePullEvent(ctx, evt)
+	}
+	return r.repo.CreatePushEvents(ctx, []domain.FeedEvent{evt})
+}
+
+func (r *ReplyEventHandler) FindFeedEvents(ctx context.Context, uid int64, cursor domain.FeedCursor, limit int64) ([]domain.FeedEvent, error) {
+	if act, err := r.userService.IsActiveUser(ctx, uid); err == nil && act {
+		return r.repo.FindPullEventsWithTyp(ctx, ReplyEventName, []int64{uid}, cursor, limit)
+	}
+	return r.repo.FindPushEventsWithTyp(ctx, ReplyEventName, uid, cursor, limit)
+}
+
+// CountUnread 和点赞一样，活跃用户走的是拉模型
+func (r *ReplyEventHandler) CountUnread(ctx context.Context, uid int64, since time.Time) (int64, error) {
+	if act, err := r.userService.IsActiveUser(ctx, uid); err == nil && act {
+		return r.repo.CountPullEventsWithTyp(ctx, ReplyEventName, []int64{uid}, since)
+	}
+	return 0, nil
+}
+
+// AggregateTarget 同一条评论下面的回复可以聚合，例如 "A 和其他 3 个人回复了你的评论"
+func (r *ReplyEventHandler) AggregateTarget(evt domain.FeedEvent) (string, int64, bool) {
+	parentId, err := evt.Ext.Get("parentId").AsInt64()
+	if err != nil {
+		return "", 0, false
+	}
+	replier, err := evt.Ext.Get("replier").AsInt64()
+	if err != nil {
+		return "", 0, false
+	}
+	return fmt.Sprintf("comment:%d", parentId), replier, true
+}
@@ -0,0 +1,85 @@
+// Copyright@daidai53 2024
+package service
+
+import (
+	"context"
+	"github.com/daidai53/webook/feed/domain"
+	"github.com/daidai53/webook/feed/repository"
+	"github.com/daidai53/webook/internal/service"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestReplyEventHandler_CreateFeedEvent(t *testing.T) {
+	testCases := []struct {
+		name   string
+		ext    domain.ExtendFields
+		active bool
+
+		wantPull []domain.FeedEvent
+		wantPush []domain.FeedEvent
+		wantErr  bool
+	}{
+		{
+			name:     "活跃用户写发件箱，按照 cid 去重",
+			ext:      domain.ExtendFields{"replied": "1", "cid": "3"},
+			active:   true,
+			wantPull: []domain.FeedEvent{{Uid: 1, Type: ReplyEventName, Key: "reply_event:3"}},
+		},
+		{
+			name:     "不活跃的用户写收件箱，按照 cid 去重",
+			ext:      domain.ExtendFields{"replied": "1", "cid": "3"},
+			wantPush: []domain.FeedEvent{{Uid: 1, Type: ReplyEventName, Key: "reply_event:3"}},
+		},
+		{
+			name:    "没有 cid 没法去重",
+			ext:     domain.ExtendFields{"replied": "1"},
+			wantErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := &replyFeedRepo{}
+			h := NewReplyEventHandler(repo, stubUserService{active: tc.active})
+			err := h.CreateFeedEvent(context.Background(), tc.ext)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantPull, repo.pull)
+			assert.Equal(t, tc.wantPush, repo.push)
+		})
+	}
+}
+
+type replyFeedRepo struct {
+	repository.FeedEventRepo
+	pull []domain.FeedEvent
+	push []domain.FeedEvent
+}
+
+// 时间和扩展字段不关心，清掉方便比较
+func (r *replyFeedRepo) CreatePullEvent(ctx context.Context, event domain.FeedEvent) error {
+	event.Ctime, event.Ext = time.Time{}, nil
+	r.pull = append(r.pull, event)
+	return nil
+}
+
+func (r *replyFeedRepo) CreatePushEvents(ctx context.Context, events []domain.FeedEvent) error {
+	for _, evt := range events {
+		evt.Ctime, evt.Ext = time.Time{}, nil
+		r.push = append(r.push, evt)
+	}
+	return nil
+}
+
+type stubUserService struct {
+	service.UserService
+	active bool
+}
+
+func (s stubUserService) IsActiveUser(ctx context.Context, uid int64) (bool, error) {
+	return s.active, nil
+}
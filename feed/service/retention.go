@@ -0,0 +1,121 @@
+// Copyright@daidai53 2024
+package service
+
+import (
+	"context"
+	"github.com/daidai53/webook/feed/repository"
+	"time"
+)
+
+// RetentionService 清理过期的收件箱和发件箱
+type RetentionService interface {
+	// CleanPushEvents 按照收件人清理收件箱
+	CleanPushEvents(ctx context.Context) error
+	// CleanPullEvents 按照作者清理发件箱
+	CleanPullEvents(ctx context.Context) error
+}
+
+// RetentionConfig 清理策略，两个条件满足任何一个就会被清理
+type RetentionConfig struct {
+	// MaxAge 超过这个时间的事件会被清理，0 表示不按时间清理
+	MaxAge time.Duration `yaml:"maxAge"`
+	// KeepN 每个用户最多保留最新的多少条，0 表示不限制
+	KeepN int `yaml:"keepN"`
+	// BatchSize 每一批处理多少条
+	BatchSize int `yaml:"batchSize"`
+	// Interval 两批之间歇多久，避免影响线上的读
+	Interval time.Duration `yaml:"interval"`
+}
+
+type retentionService struct {
+	push    repository.RetentionRepository
+	pull    repository.RetentionRepository
+	pushCfg RetentionConfig
+	pullCfg RetentionConfig
+}
+
+func NewRetentionService(push, pull repository.RetentionRepository,
+	pushCfg, pullCfg RetentionConfig) RetentionService {
+	return &retentionService{
+		push:    push,
+		pull:    pull,
+		pushCfg: pushCfg,
+		pullCfg: pullCfg,
+	}
+}
+
+func (r *retentionService) CleanPushEvents(ctx context.Context) error {
+	return r.clean(ctx, r.push, r.pushCfg)
+}
+
+func (r *retentionService) CleanPullEvents(ctx context.Context) error {
+	return r.clean(ctx, r.pull, r.pullCfg)
+}
+
+func (r *retentionService) clean(ctx context.Context, repo repository.RetentionRepository, cfg RetentionConfig) error {
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 100
+	}
+	if cfg.MaxAge > 0 {
+		before := time.Now().Add(-cfg.MaxAge).UnixMilli()
+		err := r.removeBefore(ctx, repo, cfg, 0, before)
+		if err != nil {
+			return err
+		}
+	}
+	if cfg.KeepN <= 0 {
+		return nil
+	}
+	// 一批一批地找用户，每个用户只保留最新的 KeepN 条
+	var minUid int64
+	for {
+		uids, err := repo.Uids(ctx, minUid, cfg.BatchSize)
+		if err != nil {
+			return err
+		}
+		for _, uid := range uids {
+			nth, err := repo.NthCTime(ctx, uid, cfg.KeepN)
+			if err != nil {
+				return err
+			}
+			if nth == 0 {
+				// 不足 KeepN 条
+				continue
+			}
+			err = r.removeBefore(ctx, repo, cfg, uid, nth)
+			if err != nil {
+				return err
+			}
+		}
+		if len(uids) < cfg.BatchSize {
+			return nil
+		}
+		minUid = uids[len(uids)-1]
+	}
+}
+
+func (r *retentionService) removeBefore(ctx context.Context, repo repository.RetentionRepository,
+	cfg RetentionConfig, uid, before int64) error {
+	for {
+		ids, err := repo.IdsBefore(ctx, uid, before, cfg.BatchSize)
+		if err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+		err = repo.Remove(ctx, ids)
+		if err != nil {
+			return err
+		}
+		if len(ids) < cfg.BatchSize {
+			return nil
+		}
+		// 限流，歇一会再删下一批
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(cfg.Interval):
+		}
+	}
+}
@@ -4,15 +4,44 @@ package service
 import (
 	"context"
 	"github.com/daidai53/webook/feed/domain"
+	"time"
 )
 
 type FeedService interface {
 	CreateFeedEvent(ctx context.Context, feed domain.FeedEvent) error
-	GetFeedEventList(ctx context.Context, uid, timestamp, limit int64) ([]domain.FeedEvent, error)
+	// GetFeedEventList 同类型、同目标的事件会被聚合成一条
+	// cursor 是上一页返回的 next，第一页传空字符串
+	GetFeedEventList(ctx context.Context, uid int64, cursor string, limit int64) (events []domain.FeedEvent, next string, err error)
+	// SyncFollowRelations 关注之后回填收件箱，取消关注之后清理收件箱
+	SyncFollowRelations(ctx context.Context, changes []domain.FollowChange) error
+	// GetUnreadCount 上一次标记已读之后又来了多少条
+	GetUnreadCount(ctx context.Context, uid int64) (int64, error)
+	// MarkRead 把 timestamp 之前的都标记为已读，timestamp 为 0 就是当前时间
+	MarkRead(ctx context.Context, uid, timestamp int64) error
 }
 
 // Handler 具体业务处理逻辑
 type Handler interface {
 	CreateFeedEvent(ctx context.Context, ext domain.ExtendFields) error
-	FindFeedEvents(ctx context.Context, uid, timestamp, limit int64) ([]domain.FeedEvent, error)
+	// FindFeedEvents 只返回严格排在 cursor 后面的事件，按照 (Ctime, Origin, ID) 降序
+	FindFeedEvents(ctx context.Context, uid int64, cursor domain.FeedCursor, limit int64) ([]domain.FeedEvent, error)
+}
+
+// Aggregator Handler 可以选择实现这个接口，实现了就说明这一类事件可以被聚合
+type Aggregator interface {
+	// AggregateTarget 返回事件作用的目标（例如被点赞的文章）和行为人（例如点赞的人）
+	// ok 为 false 说明这个事件不参与聚合
+	AggregateTarget(evt domain.FeedEvent) (target string, actor int64, ok bool)
+}
+
+// FollowSyncer Handler 可以选择实现这个接口，关注的时候把被关注的人最近的事件回填到收件箱
+type FollowSyncer interface {
+	// Backfill 返回要写进 follower 收件箱的事件
+	Backfill(ctx context.Context, follower, followee int64) ([]domain.FeedEvent, error)
+}
+
+// UnreadCounter 拉模型的 Handler 实现这个接口，读的时候算未读数
+// 推模型的未读数在写收件箱的时候就维护好了，不需要实现
+type UnreadCounter interface {
+	CountUnread(ctx context.Context, uid int64, since time.Time) (int64, error)
 }
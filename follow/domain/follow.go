@@ -18,3 +18,23 @@ type FollowStatics struct {
 	// 自己关注了多少人
 	Followees int64
 }
+
+type BlockType uint8
+
+const (
+	BlockTypeUnknown BlockType = iota
+	// BlockTypeMute 屏蔽，只是自己的 feed 里面不看对方的内容
+	BlockTypeMute
+	// BlockTypeBlock 拉黑，取消双方的关注，不能再关注，
+	// 对方看不到自己的内容，也不能评论、@ 自己
+	BlockTypeBlock
+)
+
+// BlockRelation 拉黑、屏蔽关系
+type BlockRelation struct {
+	// 操作的人
+	Uid int64
+	// 被拉黑或者被屏蔽的人
+	Target int64
+	Type   BlockType
+}
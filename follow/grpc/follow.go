@@ -4,6 +4,7 @@ package grpc
 import (
 	"context"
 	followv1 "github.com/daidai53/webook/api/proto/gen/follow/v1"
+	"github.com/daidai53/webook/follow/domain"
 	"github.com/daidai53/webook/follow/service"
 )
 
@@ -41,3 +42,62 @@ func (f *FollowServiceServer) GetFollowStatic(ctx context.Context, request *foll
 	//TODO implement me
 	panic("implement me")
 }
+
+func (f *FollowServiceServer) Block(ctx context.Context, request *followv1.BlockRequest) (*followv1.BlockResponse, error) {
+	err := f.svc.Block(ctx, request.GetUid(), request.GetTarget())
+	return &followv1.BlockResponse{}, err
+}
+
+func (f *FollowServiceServer) CancelBlock(ctx context.Context, request *followv1.CancelBlockRequest) (*followv1.CancelBlockResponse, error) {
+	err := f.svc.CancelBlock(ctx, request.GetUid(), request.GetTarget())
+	return &followv1.CancelBlockResponse{}, err
+}
+
+func (f *FollowServiceServer) Mute(ctx context.Context, request *followv1.MuteRequest) (*followv1.MuteResponse, error) {
+	err := f.svc.Mute(ctx, request.GetUid(), request.GetTarget())
+	return &followv1.MuteResponse{}, err
+}
+
+func (f *FollowServiceServer) CancelMute(ctx context.Context, request *followv1.CancelMuteRequest) (*followv1.CancelMuteResponse, error) {
+	err := f.svc.CancelMute(ctx, request.GetUid(), request.GetTarget())
+	return &followv1.CancelMuteResponse{}, err
+}
+
+func (f *FollowServiceServer) GetBlockList(ctx context.Context, request *followv1.GetBlockListRequest) (*followv1.GetBlockListResponse, error) {
+	list, err := f.svc.GetBlockList(ctx, request.GetUid(), domain.BlockType(request.GetType()),
+		request.GetOffset(), request.GetLimit())
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*followv1.BlockRelation, 0, len(list))
+	for _, br := range list {
+		res = append(res, &followv1.BlockRelation{
+			Uid:    br.Uid,
+			Target: br.Target,
+			Type:   followv1.BlockType(br.Type),
+		})
+	}
+	return &followv1.GetBlockListResponse{
+		BlockRelations: res,
+	}, nil
+}
+
+func (f *FollowServiceServer) GetBlockers(ctx context.Context, request *followv1.GetBlockersRequest) (*followv1.GetBlockersResponse, error) {
+	blockers, err := f.svc.GetBlockers(ctx, request.GetUid(), request.GetCandidates())
+	if err != nil {
+		return nil, err
+	}
+	return &followv1.GetBlockersResponse{
+		Blockers: blockers,
+	}, nil
+}
+
+func (f *FollowServiceServer) GetHiddenUsers(ctx context.Context, request *followv1.GetHiddenUsersRequest) (*followv1.GetHiddenUsersResponse, error) {
+	uids, err := f.svc.GetHiddenUsers(ctx, request.GetUid())
+	if err != nil {
+		return nil, err
+	}
+	return &followv1.GetHiddenUsersResponse{
+		Uids: uids,
+	}, nil
+}
@@ -3,6 +3,7 @@ package dao
 
 import (
 	"context"
+	"errors"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 	"time"
@@ -12,6 +13,12 @@ type GORMFollowRelationDAO struct {
 	db *gorm.DB
 }
 
+func NewGORMFollowRelationDAO(db *gorm.DB) FollowRelationDao {
+	return &GORMFollowRelationDAO{
+		db: db,
+	}
+}
+
 func (g *GORMFollowRelationDAO) CntFollower(ctx context.Context, uid int64) (int64, error) {
 	var res int64
 	err := g.db.WithContext(ctx).
@@ -69,3 +76,123 @@ func (g *GORMFollowRelationDAO) CreateFollowRelation(ctx context.Context, f Foll
 		}),
 	}).Create(&f).Error
 }
+
+func (g *GORMFollowRelationDAO) Block(ctx context.Context, uid, target int64) error {
+	now := time.Now().UnixMilli()
+	return g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.OnConflict{
+			DoUpdates: clause.Assignments(map[string]any{
+				"type":   BlockTypeBlock,
+				"status": FollowRelationStatusActive,
+				"u_time": now,
+			}),
+		}).Create(&BlockRelation{
+			Uid:    uid,
+			Target: target,
+			Type:   BlockTypeBlock,
+			Status: FollowRelationStatusActive,
+			CTime:  now,
+			UTime:  now,
+		}).Error
+		if err != nil {
+			return err
+		}
+		// 只改还在关注的，binlog 那边靠 UPDATE 去更新统计数和 feed
+		return tx.Model(&FollowRelation{}).
+			Where("((follower = ? AND followee = ?) OR (follower = ? AND followee = ?)) AND status = ?",
+				uid, target, target, uid, FollowRelationStatusActive).
+			Updates(map[string]any{
+				"status": FollowRelationStatusInactive,
+				"u_time": now,
+			}).Error
+	})
+}
+
+func (g *GORMFollowRelationDAO) Mute(ctx context.Context, uid, target int64) error {
+	now := time.Now().UnixMilli()
+	return g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var br BlockRelation
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("uid = ? AND target = ?", uid, target).
+			First(&br).Error
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return tx.Create(&BlockRelation{
+				Uid:    uid,
+				Target: target,
+				Type:   BlockTypeMute,
+				Status: FollowRelationStatusActive,
+				CTime:  now,
+				UTime:  now,
+			}).Error
+		case err != nil:
+			return err
+		}
+		// 拉黑了的已经看不到了，不能降级成屏蔽
+		if br.Status == FollowRelationStatusActive && br.Type == BlockTypeBlock {
+			return nil
+		}
+		return tx.Model(&br).Updates(map[string]any{
+			"type":   BlockTypeMute,
+			"status": FollowRelationStatusActive,
+			"u_time": now,
+		}).Error
+	})
+}
+
+func (g *GORMFollowRelationDAO) CancelBlockRelation(ctx context.Context, uid, target int64, typ uint8) error {
+	return g.db.WithContext(ctx).Model(&BlockRelation{}).
+		Where("uid = ? AND target = ? AND type = ? AND status = ?",
+			uid, target, typ, FollowRelationStatusActive).
+		Updates(map[string]any{
+			"status": FollowRelationStatusInactive,
+			"u_time": time.Now().UnixMilli(),
+		}).Error
+}
+
+func (g *GORMFollowRelationDAO) BlockRelationList(ctx context.Context, uid int64, typ uint8,
+	offset, limit int64) ([]BlockRelation, error) {
+	var res []BlockRelation
+	err := g.db.WithContext(ctx).
+		Where("uid = ? AND type = ? AND status = ?", uid, typ, FollowRelationStatusActive).
+		Order("u_time DESC").
+		Offset(int(offset)).Limit(int(limit)).
+		Find(&res).Error
+	return res, err
+}
+
+func (g *GORMFollowRelationDAO) BlockExists(ctx context.Context, a, b int64) (bool, error) {
+	var cnt int64
+	err := g.db.WithContext(ctx).Model(&BlockRelation{}).
+		Where("((uid = ? AND target = ?) OR (uid = ? AND target = ?)) AND type = ? AND status = ?",
+			a, b, b, a, BlockTypeBlock, FollowRelationStatusActive).
+		Count(&cnt).Error
+	return cnt > 0, err
+}
+
+func (g *GORMFollowRelationDAO) FindBlockers(ctx context.Context, uid int64, candidates []int64) ([]int64, error) {
+	var res []int64
+	if len(candidates) == 0 {
+		return res, nil
+	}
+	err := g.db.WithContext(ctx).Model(&BlockRelation{}).
+		Where("target = ? AND uid IN ? AND type = ? AND status = ?",
+			uid, candidates, BlockTypeBlock, FollowRelationStatusActive).
+		Pluck("uid", &res).Error
+	return res, err
+}
+
+func (g *GORMFollowRelationDAO) FindHidden(ctx context.Context, uid int64) ([]int64, error) {
+	var targets []int64
+	err := g.db.WithContext(ctx).Model(&BlockRelation{}).
+		Where("uid = ? AND status = ?", uid, FollowRelationStatusActive).
+		Pluck("target", &targets).Error
+	if err != nil {
+		return nil, err
+	}
+	var blockers []int64
+	err = g.db.WithContext(ctx).Model(&BlockRelation{}).
+		Where("target = ? AND type = ? AND status = ?", uid, BlockTypeBlock, FollowRelationStatusActive).
+		Pluck("uid", &blockers).Error
+	return append(targets, blockers...), err
+}
@@ -0,0 +1,146 @@
+// Copyright@daidai53 2024
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"testing"
+)
+
+func TestGORMFollowRelationDAO_Block(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(mock sqlmock.Sqlmock)
+
+		wantErr error
+	}{
+		{
+			name: "拉黑同时取消双方的关注",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO `block_relations` .* ON DUPLICATE KEY UPDATE .*").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("UPDATE `follow_relations` SET .* WHERE \\(\\(follower = \\? AND followee = \\?\\) OR \\(follower = \\? AND followee = \\?\\)\\) AND status = \\?").
+					WithArgs(FollowRelationStatusInactive, sqlmock.AnyArg(), int64(1), int64(2), int64(2), int64(1), FollowRelationStatusActive).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "取消关注失败，拉黑也回滚",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO `block_relations` .*").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("UPDATE `follow_relations` .*").
+					WillReturnError(errors.New("mock db error"))
+				mock.ExpectRollback()
+			},
+			wantErr: errors.New("mock db error"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sqlDB, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			tc.mock(mock)
+			dao := NewGORMFollowRelationDAO(newMockDB(t, sqlDB))
+			err = dao.Block(context.Background(), 1, 2)
+			assert.Equal(t, tc.wantErr, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestGORMFollowRelationDAO_Mute(t *testing.T) {
+	cols := []string{"id", "uid", "target", "type", "status"}
+	testCases := []struct {
+		name string
+		mock func(mock sqlmock.Sqlmock)
+
+		wantErr error
+	}{
+		{
+			name: "没有关系，新建一条",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT \\* FROM `block_relations` WHERE uid = \\? AND target = \\? .* FOR UPDATE").
+					WillReturnRows(sqlmock.NewRows(cols))
+				mock.ExpectExec("INSERT INTO `block_relations` .*").
+					WithArgs(int64(1), int64(2), BlockTypeMute, FollowRelationStatusActive, sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "已经拉黑了，不降级成屏蔽",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT \\* FROM `block_relations` .* FOR UPDATE").
+					WillReturnRows(sqlmock.NewRows(cols).
+						AddRow(3, 1, 2, BlockTypeBlock, FollowRelationStatusActive))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "取消过拉黑，重新屏蔽",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT \\* FROM `block_relations` .* FOR UPDATE").
+					WillReturnRows(sqlmock.NewRows(cols).
+						AddRow(3, 1, 2, BlockTypeBlock, FollowRelationStatusInactive))
+				mock.ExpectExec("UPDATE `block_relations` SET .* WHERE `id` = \\?").
+					WithArgs(FollowRelationStatusActive, BlockTypeMute, sqlmock.AnyArg(), int64(3)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sqlDB, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			tc.mock(mock)
+			dao := NewGORMFollowRelationDAO(newMockDB(t, sqlDB))
+			err = dao.Mute(context.Background(), 1, 2)
+			assert.Equal(t, tc.wantErr, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestGORMFollowRelationDAO_FindHidden(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	// 自己拉黑、屏蔽了的
+	mock.ExpectQuery("SELECT `target` FROM `block_relations` WHERE uid = \\? AND status = \\?").
+		WithArgs(int64(1), FollowRelationStatusActive).
+		WillReturnRows(sqlmock.NewRows([]string{"target"}).AddRow(2).AddRow(3))
+	// 拉黑了自己的，屏蔽了自己的不算
+	mock.ExpectQuery("SELECT `uid` FROM `block_relations` WHERE target = \\? AND type = \\? AND status = \\?").
+		WithArgs(int64(1), BlockTypeBlock, FollowRelationStatusActive).
+		WillReturnRows(sqlmock.NewRows([]string{"uid"}).AddRow(4))
+	dao := NewGORMFollowRelationDAO(newMockDB(t, sqlDB))
+	res, err := dao.FindHidden(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{2, 3, 4}, res)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func newMockDB(t *testing.T, sqlDB *sql.DB) *gorm.DB {
+	db, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      sqlDB,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{
+		DisableAutomaticPing:   true,
+		SkipDefaultTransaction: true,
+	})
+	require.NoError(t, err)
+	return db
+}
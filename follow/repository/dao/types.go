@@ -1,7 +1,17 @@
 // Copyright@daidai53 2024
 package dao
 
-import "context"
+import (
+	"context"
+	"gorm.io/gorm"
+)
+
+func InitTables(db *gorm.DB) error {
+	return db.AutoMigrate(
+		&FollowRelation{},
+		&BlockRelation{},
+	)
+}
 
 type FollowRelation struct {
 	Id int64 `gorm:"autoIncrement;primaryKey;"`
@@ -17,12 +27,34 @@ type FollowRelation struct {
 	UTime int64
 }
 
+// BlockRelation 一对人之间只有一条，拉黑会覆盖屏蔽
+type BlockRelation struct {
+	Id int64 `gorm:"autoIncrement;primaryKey;"`
+
+	Uid int64 `gorm:"uniqueIndex:uid_target"`
+	// 查谁拉黑了自己
+	Target int64 `gorm:"uniqueIndex:uid_target;index"`
+	Type   uint8
+
+	// 软删除，和 FollowRelation 一样
+	Status uint8
+
+	CTime int64
+	UTime int64
+}
+
 const (
 	FollowRelationStatusUnknown uint8 = iota
 	FollowRelationStatusActive
 	FollowRelationStatusInactive
 )
 
+const (
+	BlockTypeUnknown uint8 = iota
+	BlockTypeMute
+	BlockTypeBlock
+)
+
 type FollowRelationDao interface {
 	// FollowRelationList 获取某人的关注列表
 	FollowRelationList(ctx context.Context, follower, offset, limit int64) ([]FollowRelation, error)
@@ -35,4 +67,18 @@ type FollowRelationDao interface {
 	CntFollower(ctx context.Context, uid int64) (int64, error)
 	// CntFollowee 统计自己关注了多少人
 	CntFollowee(ctx context.Context, uid int64) (int64, error)
+
+	// Block 拉黑，同时把双方的关注关系都改成取消关注
+	Block(ctx context.Context, uid, target int64) error
+	// Mute 屏蔽，已经拉黑了的不变
+	Mute(ctx context.Context, uid, target int64) error
+	// CancelBlockRelation 只取消 typ 类型的
+	CancelBlockRelation(ctx context.Context, uid, target int64, typ uint8) error
+	BlockRelationList(ctx context.Context, uid int64, typ uint8, offset, limit int64) ([]BlockRelation, error)
+	// BlockExists a 和 b 之间是不是有一方拉黑了另外一方
+	BlockExists(ctx context.Context, a, b int64) (bool, error)
+	// FindBlockers candidates 里面拉黑了 uid 的人
+	FindBlockers(ctx context.Context, uid int64, candidates []int64) ([]int64, error)
+	// FindHidden uid 拉黑、屏蔽了的人，加上拉黑了 uid 的人
+	FindHidden(ctx context.Context, uid int64) ([]int64, error)
 }
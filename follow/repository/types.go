@@ -19,6 +19,16 @@ type FollowRepository interface {
 	// InactiveFollowRelation 取消关注
 	InactiveFollowRelation(ctx context.Context, follower int64, followee int64) error
 	GetFollowStatics(ctx context.Context, uid int64) (domain.FollowStatics, error)
+
+	// Block 拉黑，同时取消双方的关注
+	Block(ctx context.Context, uid, target int64) error
+	Mute(ctx context.Context, uid, target int64) error
+	CancelBlockRelation(ctx context.Context, uid, target int64, typ domain.BlockType) error
+	GetBlockList(ctx context.Context, uid int64, typ domain.BlockType, offset, limit int64) ([]domain.BlockRelation, error)
+	// IsBlocked a 和 b 之间是不是有一方拉黑了另外一方
+	IsBlocked(ctx context.Context, a, b int64) (bool, error)
+	GetBlockers(ctx context.Context, uid int64, candidates []int64) ([]int64, error)
+	GetHiddenUsers(ctx context.Context, uid int64) ([]int64, error)
 }
 
 type CachedRelationRepository struct {
@@ -96,6 +106,47 @@ func (d *CachedRelationRepository) AddFollowRelation(ctx context.Context, c doma
 	return nil
 }
 
+func (d *CachedRelationRepository) Block(ctx context.Context, uid, target int64) error {
+	return d.dao.Block(ctx, uid, target)
+}
+
+func (d *CachedRelationRepository) Mute(ctx context.Context, uid, target int64) error {
+	return d.dao.Mute(ctx, uid, target)
+}
+
+func (d *CachedRelationRepository) CancelBlockRelation(ctx context.Context, uid, target int64, typ domain.BlockType) error {
+	return d.dao.CancelBlockRelation(ctx, uid, target, uint8(typ))
+}
+
+func (d *CachedRelationRepository) GetBlockList(ctx context.Context, uid int64, typ domain.BlockType,
+	offset, limit int64) ([]domain.BlockRelation, error) {
+	list, err := d.dao.BlockRelationList(ctx, uid, uint8(typ), offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]domain.BlockRelation, 0, len(list))
+	for _, br := range list {
+		res = append(res, domain.BlockRelation{
+			Uid:    br.Uid,
+			Target: br.Target,
+			Type:   domain.BlockType(br.Type),
+		})
+	}
+	return res, nil
+}
+
+func (d *CachedRelationRepository) IsBlocked(ctx context.Context, a, b int64) (bool, error) {
+	return d.dao.BlockExists(ctx, a, b)
+}
+
+func (d *CachedRelationRepository) GetBlockers(ctx context.Context, uid int64, candidates []int64) ([]int64, error) {
+	return d.dao.FindBlockers(ctx, uid, candidates)
+}
+
+func (d *CachedRelationRepository) GetHiddenUsers(ctx context.Context, uid int64) ([]int64, error) {
+	return d.dao.FindHidden(ctx, uid)
+}
+
 func (d *CachedRelationRepository) toDomain(fr dao.FollowRelation) domain.FollowRelation {
 	return domain.FollowRelation{
 		Followee: fr.Followee,
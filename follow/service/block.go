@@ -0,0 +1,42 @@
+// Copyright@daidai53 2024
+package service
+
+import (
+	"context"
+	"github.com/daidai53/webook/follow/domain"
+)
+
+func (f *followRelationService) Block(ctx context.Context, uid, target int64) error {
+	if uid == target {
+		return ErrBlockSelf
+	}
+	return f.repo.Block(ctx, uid, target)
+}
+
+func (f *followRelationService) CancelBlock(ctx context.Context, uid, target int64) error {
+	return f.repo.CancelBlockRelation(ctx, uid, target, domain.BlockTypeBlock)
+}
+
+func (f *followRelationService) Mute(ctx context.Context, uid, target int64) error {
+	if uid == target {
+		return ErrBlockSelf
+	}
+	return f.repo.Mute(ctx, uid, target)
+}
+
+func (f *followRelationService) CancelMute(ctx context.Context, uid, target int64) error {
+	return f.repo.CancelBlockRelation(ctx, uid, target, domain.BlockTypeMute)
+}
+
+func (f *followRelationService) GetBlockList(ctx context.Context, uid int64, typ domain.BlockType,
+	offset, limit int64) ([]domain.BlockRelation, error) {
+	return f.repo.GetBlockList(ctx, uid, typ, offset, limit)
+}
+
+func (f *followRelationService) GetBlockers(ctx context.Context, uid int64, candidates []int64) ([]int64, error) {
+	return f.repo.GetBlockers(ctx, uid, candidates)
+}
+
+func (f *followRelationService) GetHiddenUsers(ctx context.Context, uid int64) ([]int64, error) {
+	return f.repo.GetHiddenUsers(ctx, uid)
+}
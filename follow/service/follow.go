@@ -11,6 +11,12 @@ type followRelationService struct {
 	repo repository.FollowRepository
 }
 
+func NewFollowRelationService(repo repository.FollowRepository) FollowService {
+	return &followRelationService{
+		repo: repo,
+	}
+}
+
 func (f *followRelationService) CancelFollow(ctx context.Context, follower, followee int64) error {
 	return f.repo.InactiveFollowRelation(ctx, follower, followee)
 }
@@ -26,6 +32,13 @@ func (f *followRelationService) FollowInfo(ctx context.Context, follower, follow
 }
 
 func (f *followRelationService) Follow(ctx context.Context, follower, followee int64) error {
+	blocked, err := f.repo.IsBlocked(ctx, follower, followee)
+	if err != nil {
+		return err
+	}
+	if blocked {
+		return ErrBlocked
+	}
 	return f.repo.AddFollowRelation(ctx, domain.FollowRelation{
 		Followee: followee,
 		Follower: follower,
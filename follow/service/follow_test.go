@@ -0,0 +1,75 @@
+// Copyright@daidai53 2024
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/daidai53/webook/follow/domain"
+	"github.com/daidai53/webook/follow/repository"
+	repomocks "github.com/daidai53/webook/follow/repository/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+)
+
+func TestFollowRelationService_Follow(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) repository.FollowRepository
+
+		wantErr error
+	}{
+		{
+			name: "关注成功",
+			mock: func(ctrl *gomock.Controller) repository.FollowRepository {
+				repo := repomocks.NewMockFollowRepository(ctrl)
+				repo.EXPECT().IsBlocked(gomock.Any(), int64(1), int64(2)).Return(false, nil)
+				repo.EXPECT().AddFollowRelation(gomock.Any(), domain.FollowRelation{
+					Follower: 1,
+					Followee: 2,
+				}).Return(nil)
+				return repo
+			},
+		},
+		{
+			name: "被拉黑了不能关注",
+			mock: func(ctrl *gomock.Controller) repository.FollowRepository {
+				repo := repomocks.NewMockFollowRepository(ctrl)
+				repo.EXPECT().IsBlocked(gomock.Any(), int64(1), int64(2)).Return(true, nil)
+				return repo
+			},
+			wantErr: ErrBlocked,
+		},
+		{
+			name: "查询拉黑关系失败",
+			mock: func(ctrl *gomock.Controller) repository.FollowRepository {
+				repo := repomocks.NewMockFollowRepository(ctrl)
+				repo.EXPECT().IsBlocked(gomock.Any(), int64(1), int64(2)).
+					Return(false, errors.New("mock db error"))
+				return repo
+			},
+			wantErr: errors.New("mock db error"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			svc := NewFollowRelationService(tc.mock(ctrl))
+			err := svc.Follow(context.Background(), 1, 2)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
+
+func TestFollowRelationService_Block(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := repomocks.NewMockFollowRepository(ctrl)
+	repo.EXPECT().Block(gomock.Any(), int64(1), int64(2)).Return(nil)
+	svc := NewFollowRelationService(repo)
+	assert.NoError(t, svc.Block(context.Background(), 1, 2))
+	// 不能拉黑自己，也不会落到数据库
+	assert.Equal(t, ErrBlockSelf, svc.Block(context.Background(), 1, 1))
+	assert.Equal(t, ErrBlockSelf, svc.Mute(context.Background(), 1, 1))
+}
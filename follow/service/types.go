@@ -3,13 +3,33 @@ package service
 
 import (
 	"context"
+	"errors"
 	"github.com/daidai53/webook/follow/domain"
 )
 
+var (
+	// ErrBlocked 有一方拉黑了另外一方，不能关注
+	ErrBlocked = errors.New("已经被拉黑了")
+	// ErrBlockSelf 不能拉黑、屏蔽自己
+	ErrBlockSelf = errors.New("不能拉黑自己")
+)
+
 type FollowService interface {
 	GetFollowee(ctx context.Context, follower, offset, limit int64) ([]domain.FollowRelation, error)
 	FollowInfo(ctx context.Context,
 		follower, followee int64) (domain.FollowRelation, error)
 	Follow(ctx context.Context, follower, followee int64) error
 	CancelFollow(ctx context.Context, follower, followee int64) error
+
+	// Block 拉黑 target，同时取消双方的关注，之后双方都不能再关注对方
+	Block(ctx context.Context, uid, target int64) error
+	CancelBlock(ctx context.Context, uid, target int64) error
+	// Mute 屏蔽 target，只是自己的 feed 里面不看 target 的内容。已经拉黑了的不变
+	Mute(ctx context.Context, uid, target int64) error
+	CancelMute(ctx context.Context, uid, target int64) error
+	GetBlockList(ctx context.Context, uid int64, typ domain.BlockType, offset, limit int64) ([]domain.BlockRelation, error)
+	// GetBlockers candidates 里面拉黑了 uid 的人
+	GetBlockers(ctx context.Context, uid int64, candidates []int64) ([]int64, error)
+	// GetHiddenUsers uid 不应该看到哪些人的内容：自己拉黑、屏蔽了的，还有拉黑了自己的
+	GetHiddenUsers(ctx context.Context, uid int64) ([]int64, error)
 }
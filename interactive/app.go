@@ -5,10 +5,12 @@ import (
 	"github.com/daidai53/webook/internal/events"
 	"github.com/daidai53/webook/pkg/ginx"
 	"github.com/daidai53/webook/pkg/grpcx"
+	"github.com/robfig/cron/v3"
 )
 
 type App struct {
 	consumers   []events.Consumer
 	server      *grpcx.Server
 	adminServer *ginx.Server
+	cron        *cron.Cron
 }
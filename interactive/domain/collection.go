@@ -0,0 +1,26 @@
+// Copyright@daidai53 2024
+package domain
+
+import "time"
+
+// Collection 收藏夹，Id 为 0 的是默认收藏夹
+type Collection struct {
+	Id          int64
+	Uid         int64
+	Name        string
+	Description string
+	// Public 公开的收藏夹别人也能看
+	Public bool
+	Ctime  time.Time
+	Utime  time.Time
+}
+
+// CollectionItem 收藏夹里面的一条
+type CollectionItem struct {
+	Cid   int64
+	Uid   int64
+	Biz   string
+	BizId int64
+	Ctime time.Time
+	Utime time.Time
+}
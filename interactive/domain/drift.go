@@ -0,0 +1,12 @@
+// Copyright@daidai53 2024
+package domain
+
+// CntDrift 缓存里面的计数和数据库对不上
+type CntDrift struct {
+	Biz   string
+	BizId int64
+	// Field read_cnt, unique_read_cnt, like_cnt 或者 collect_cnt
+	Field string
+	Cache int64
+	DB    int64
+}
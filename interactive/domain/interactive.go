@@ -1,12 +1,32 @@
 // Copyright@daidai53 2023
 package domain
 
+import "time"
+
 type Interactive struct {
-	Biz        string
-	BizId      int64
-	ReadCnt    int64
-	LikeCnt    int64
-	CollectCnt int64
+	Biz     string
+	BizId   int64
+	ReadCnt int64
+	// UniqueReadCnt 去重之后的阅读数，同一个人一天之内只算一次
+	UniqueReadCnt int64
+	LikeCnt       int64
+	CollectCnt    int64
+	// CommentCnt 评论数，回复也算
+	CommentCnt int64
 	Liked      bool
 	Collected  bool
+	// Reactions 每种表情的数量，👍 就是 LikeCnt
+	Reactions map[string]int64
+	// MyReactions 查的人自己发过的表情
+	MyReactions []string
+	Ctime       time.Time
+}
+
+// UserLike 某个人点赞了某个东西
+type UserLike struct {
+	Uid   int64
+	Biz   string
+	BizId int64
+	// Ctime 点赞的时间
+	Ctime time.Time
 }
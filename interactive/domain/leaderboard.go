@@ -0,0 +1,35 @@
+// Copyright@daidai53 2024
+package domain
+
+import "time"
+
+// Window 排行榜统计的时间范围
+type Window uint8
+
+const (
+	// WindowAll 从有记录开始的总点赞数
+	WindowAll Window = iota
+	// WindowDay 最近 24 小时
+	WindowDay
+	// WindowWeek 最近 7 天，包括今天
+	WindowWeek
+)
+
+func (w Window) Valid() bool {
+	return w <= WindowWeek
+}
+
+// LikeRank 排行榜上的一条
+type LikeRank struct {
+	BizId   int64
+	LikeCnt int64
+}
+
+// LikeDelta 一次点赞或者取消点赞带来的变化
+type LikeDelta struct {
+	Biz   string
+	BizId int64
+	// Delta 点赞是 1，取消点赞是 -1
+	Delta int64
+	Ctime time.Time
+}
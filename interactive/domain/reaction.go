@@ -0,0 +1,26 @@
+// Copyright@daidai53 2024
+package domain
+
+// ReactionLike 👍，也就是原来的点赞
+const ReactionLike = "like"
+
+// ReactionSet 某个 biz 可以用哪些表情
+type ReactionSet struct {
+	Reactions []string
+	// Multi 一个人能不能同时发好几种表情，不能的话发新的会把旧的换掉
+	Multi bool
+}
+
+// DefaultReactionSet 没有配置过的 biz 只能点赞
+var DefaultReactionSet = ReactionSet{
+	Reactions: []string{ReactionLike},
+}
+
+func (r ReactionSet) Contains(reaction string) bool {
+	for _, re := range r.Reactions {
+		if re == reaction {
+			return true
+		}
+	}
+	return false
+}
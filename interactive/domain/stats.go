@@ -0,0 +1,18 @@
+// Copyright@daidai53 2024
+package domain
+
+import "time"
+
+// DailyStat 某个东西某一天的增量
+type DailyStat struct {
+	Biz   string
+	BizId int64
+	// Day 那一天的零点
+	Day           time.Time
+	ReadCnt       int64
+	UniqueReadCnt int64
+	LikeCnt       int64
+	CollectCnt    int64
+	// Total 那一天结束的时候的总数，算第二天的增量用
+	Total Interactive
+}
@@ -0,0 +1,82 @@
+// Copyright@daidai53 2024
+package events
+
+import (
+	"context"
+	"github.com/IBM/sarama"
+	"github.com/daidai53/webook/interactive/repository"
+	"github.com/daidai53/webook/pkg/logger"
+	"github.com/daidai53/webook/pkg/saramax"
+	"github.com/prometheus/client_golang/prometheus"
+	"time"
+)
+
+// TopicCommentCntEvent 评论服务发出来的，同一个资源的事件在同一个分区上
+const TopicCommentCntEvent = "comment_cnt"
+
+// CommentCntEvent 带的是评论数的总数，不是增量
+type CommentCntEvent struct {
+	Biz   string `json:"biz"`
+	BizId int64  `json:"biz_id"`
+	Cnt   int64  `json:"cnt"`
+}
+
+// CommentCntConsumer 把评论服务那边的评论数同步到互动数据里面
+type CommentCntConsumer struct {
+	repo   repository.InteractiveRepository
+	client sarama.Client
+	l      logger.LoggerV1
+}
+
+func NewCommentCntConsumer(repo repository.InteractiveRepository, client sarama.Client, l logger.LoggerV1) *CommentCntConsumer {
+	return &CommentCntConsumer{
+		repo:   repo,
+		client: client,
+		l:      l,
+	}
+}
+
+func (c *CommentCntConsumer) Start() error {
+	cg, err := sarama.NewConsumerGroupFromClient("interactive_comment_cnt", c.client)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		er := cg.Consume(context.Background(),
+			[]string{TopicCommentCntEvent},
+			saramax.NewBatchHandler[CommentCntEvent](c.BatchConsume, c.l,
+				prometheus.CounterOpts{
+					Namespace: "daidai53",
+					Subsystem: "webook",
+					Name:      "interactive_comment_cnt_kafka",
+				}),
+		)
+		if er != nil {
+			c.l.Error("退出消费",
+				logger.Error(er))
+		}
+	}()
+	return nil
+}
+
+// BatchConsume 同一个资源只要这一批里面最后的那个总数
+func (c *CommentCntConsumer) BatchConsume(msgs []*sarama.ConsumerMessage, events []CommentCntEvent) error {
+	type bizKey struct {
+		biz   string
+		bizId int64
+	}
+	latest := make(map[bizKey]int64, len(events))
+	for _, evt := range events {
+		latest[bizKey{biz: evt.Biz, bizId: evt.BizId}] = evt.Cnt
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	for key, cnt := range latest {
+		err := c.repo.SetCommentCnt(ctx, key.biz, key.bizId, cnt)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -73,19 +73,22 @@ func (i *InteractiveReadEventConsumer) StartSingle() error {
 func (i *InteractiveReadEventConsumer) BatchConsume(msgs []*sarama.ConsumerMessage, events []ReadEvent) error {
 	bizs := make([]string, 0, len(events))
 	bizIds := make([]int64, 0, len(events))
+	uids := make([]int64, 0, len(events))
 	for _, evt := range events {
 		bizs = append(bizs, "article")
 		bizIds = append(bizIds, evt.Aid)
+		uids = append(uids, evt.Uid)
 	}
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 	defer cancel()
-	return i.repo.BatchIncrReadCnt(ctx, bizs, bizIds)
+	return i.repo.BatchIncrReadCnt(ctx, bizs, bizIds, uids)
 }
 
 func (i *InteractiveReadEventConsumer) Consume(msg *sarama.ConsumerMessage, event ReadEvent) error {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 	defer cancel()
-	return i.repo.IncrReadCnt(ctx, "article", event.Aid)
+	// 走批量的逻辑，这样单条消费的时候阅读的人也会去重
+	return i.repo.BatchIncrReadCnt(ctx, []string{"article"}, []int64{event.Aid}, []int64{event.Uid})
 }
 
 type ReadEvent struct {
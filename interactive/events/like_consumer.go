@@ -0,0 +1,68 @@
+// Copyright@daidai53 2024
+package events
+
+import (
+	"context"
+	"github.com/IBM/sarama"
+	"github.com/daidai53/webook/interactive/domain"
+	"github.com/daidai53/webook/interactive/repository"
+	"github.com/daidai53/webook/pkg/logger"
+	"github.com/daidai53/webook/pkg/saramax"
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/prometheus/client_golang/prometheus"
+	"time"
+)
+
+const TopicLikeEvent = "interactive_like"
+
+// LikeLeaderboardConsumer 消费点赞事件，增量维护点赞排行榜
+type LikeLeaderboardConsumer struct {
+	repo   repository.InteractiveRepository
+	client sarama.Client
+	l      logger.LoggerV1
+}
+
+func NewLikeLeaderboardConsumer(repo repository.InteractiveRepository, client sarama.Client, l logger.LoggerV1) *LikeLeaderboardConsumer {
+	return &LikeLeaderboardConsumer{
+		repo:   repo,
+		client: client,
+		l:      l,
+	}
+}
+
+func (c *LikeLeaderboardConsumer) Start() error {
+	cg, err := sarama.NewConsumerGroupFromClient("interactive_leaderboard", c.client)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		er := cg.Consume(context.Background(),
+			[]string{TopicLikeEvent},
+			saramax.NewBatchHandler[LikeEvent](c.BatchConsume, c.l,
+				prometheus.CounterOpts{
+					Namespace: "daidai53",
+					Subsystem: "webook",
+					Name:      "interactive_leaderboard_kafka",
+				}),
+		)
+		if er != nil {
+			c.l.Error("退出消费",
+				logger.Error(er))
+		}
+	}()
+	return nil
+}
+
+func (c *LikeLeaderboardConsumer) BatchConsume(msgs []*sarama.ConsumerMessage, events []LikeEvent) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	return c.repo.IncrLikeRank(ctx, slice.Map(events, func(idx int, src LikeEvent) domain.LikeDelta {
+		return domain.LikeDelta{
+			Biz:   src.Biz,
+			BizId: src.BizId,
+			Delta: src.Delta,
+			Ctime: time.UnixMilli(src.Ctime),
+		}
+	}))
+}
@@ -10,12 +10,33 @@ import (
 
 type Producer interface {
 	ProduceInteractiveEvent(ctx context.Context, evt InteractiveEvent) error
+	ProduceLikeEvent(ctx context.Context, evt LikeEvent) error
 }
 
 type SaramaSyncProducer struct {
 	client sarama.SyncProducer
 }
 
+func NewSaramaSyncProducer(client sarama.SyncProducer) Producer {
+	return &SaramaSyncProducer{
+		client: client,
+	}
+}
+
+func (s *SaramaSyncProducer) ProduceLikeEvent(ctx context.Context, evt LikeEvent) error {
+	data, err := json.Marshal(evt)
+	if err != nil {
+		return err
+	}
+	_, _, err = s.client.SendMessage(&sarama.ProducerMessage{
+		Topic: TopicLikeEvent,
+		// 同一个东西的点赞事件进同一个分区
+		Key:   sarama.StringEncoder(fmt.Sprintf("%s:%d", evt.Biz, evt.BizId)),
+		Value: sarama.ByteEncoder(data),
+	})
+	return err
+}
+
 func (s *SaramaSyncProducer) ProduceInteractiveEvent(ctx context.Context, evt InteractiveEvent) error {
 	data, _ := json.Marshal(evt)
 	event := SyncDataEvent{
@@ -38,6 +59,17 @@ type InteractiveEvent struct {
 	Type  uint8  `json:"type"`
 }
 
+// LikeEvent 点赞和取消点赞，用来维护排行榜
+type LikeEvent struct {
+	Uid   int64  `json:"uid"`
+	Biz   string `json:"biz"`
+	BizId int64  `json:"biz_id"`
+	// Delta 点赞是 1，取消点赞是 -1
+	Delta int64 `json:"delta"`
+	// Ctime 毫秒数
+	Ctime int64 `json:"ctime"`
+}
+
 type SyncDataEvent struct {
 	IndexName string
 	DocId     string
@@ -45,6 +77,7 @@ type SyncDataEvent struct {
 }
 
 const (
-	TypeLike    = 1
-	TypeCollect = 2
+	TypeLike          = 1
+	TypeCollect       = 2
+	TypeCancelCollect = 3
 )
@@ -6,16 +6,22 @@ import (
 	interv1 "github.com/daidai53/webook/api/proto/gen/inter/v1"
 	"github.com/daidai53/webook/interactive/domain"
 	"github.com/daidai53/webook/interactive/service"
+	"github.com/ecodeclub/ekit/slice"
 	"google.golang.org/grpc"
+	"time"
 )
 
 type InteractiveServiceServer struct {
 	interv1.UnimplementedInteractiveServiceServer
-	svc service.InteractiveService
+	svc      service.InteractiveService
+	colSvc   service.CollectionService
+	reactSvc service.ReactionService
+	statsSvc service.StatsService
 }
 
-func NewInteractiveServiceServer(svc service.InteractiveService) *InteractiveServiceServer {
-	return &InteractiveServiceServer{svc: svc}
+func NewInteractiveServiceServer(svc service.InteractiveService, colSvc service.CollectionService,
+	reactSvc service.ReactionService, statsSvc service.StatsService) *InteractiveServiceServer {
+	return &InteractiveServiceServer{svc: svc, colSvc: colSvc, reactSvc: reactSvc, statsSvc: statsSvc}
 }
 
 func (i *InteractiveServiceServer) Register(s *grpc.Server) {
@@ -28,12 +34,12 @@ func (i *InteractiveServiceServer) IncrReadCnt(ctx context.Context, request *int
 }
 
 func (i *InteractiveServiceServer) Like(ctx context.Context, request *interv1.LikeRequest) (*interv1.LikeResponse, error) {
-	err := i.svc.Like(ctx, request.GetBiz(), request.GetId(), request.GetUid())
+	err := i.reactSvc.ChangeReaction(ctx, request.GetBiz(), request.GetId(), request.GetUid(), domain.ReactionLike)
 	return &interv1.LikeResponse{}, err
 }
 
 func (i *InteractiveServiceServer) CancelLike(ctx context.Context, request *interv1.CancelLikeRequest) (*interv1.CancelLikeResponse, error) {
-	err := i.svc.CancelLike(ctx, request.GetBiz(), request.GetId(), request.GetUid())
+	err := i.reactSvc.RemoveReaction(ctx, request.GetBiz(), request.GetId(), request.GetUid(), domain.ReactionLike)
 	return &interv1.CancelLikeResponse{}, err
 }
 
@@ -42,6 +48,11 @@ func (i *InteractiveServiceServer) Collect(ctx context.Context, request *interv1
 	return &interv1.CollectResponse{}, err
 }
 
+func (i *InteractiveServiceServer) CancelCollect(ctx context.Context, request *interv1.CancelCollectRequest) (*interv1.CancelCollectResponse, error) {
+	err := i.svc.CancelCollect(ctx, request.GetBiz(), request.GetId(), request.GetUid())
+	return &interv1.CancelCollectResponse{}, err
+}
+
 func (i *InteractiveServiceServer) Get(ctx context.Context, request *interv1.GetRequest) (*interv1.GetResponse, error) {
 	inter, err := i.svc.Get(ctx, request.Biz, request.GetId(), request.GetUid())
 	if err != nil {
@@ -64,14 +75,190 @@ func (i *InteractiveServiceServer) GetByIds(ctx context.Context, request *interv
 	}, nil
 }
 
+func (i *InteractiveServiceServer) BatchGet(ctx context.Context, request *interv1.BatchGetRequest) (*interv1.BatchGetResponse, error) {
+	res, err := i.svc.BatchGet(ctx, request.GetBiz(), request.GetIds(), request.GetUid())
+	if err != nil {
+		return nil, err
+	}
+	inters := make(map[int64]*interv1.Interactive, len(res))
+	for k, v := range res {
+		inters[k] = i.toDTO(v)
+	}
+	return &interv1.BatchGetResponse{
+		Inters: inters,
+	}, nil
+}
+
+func (i *InteractiveServiceServer) GetLikers(ctx context.Context, request *interv1.GetLikersRequest) (*interv1.GetLikersResponse, error) {
+	likes, err := i.svc.GetLikers(ctx, request.GetBiz(), request.GetBizId(),
+		int(request.GetOffset()), int(request.GetLimit()))
+	if err != nil {
+		return nil, err
+	}
+	return &interv1.GetLikersResponse{
+		Likes: slice.Map(likes, func(idx int, src domain.UserLike) *interv1.UserLike {
+			return i.toUserLikeDTO(src)
+		}),
+	}, nil
+}
+
+func (i *InteractiveServiceServer) GetLikedItems(ctx context.Context, request *interv1.GetLikedItemsRequest) (*interv1.GetLikedItemsResponse, error) {
+	likes, err := i.svc.GetLikedItems(ctx, request.GetUid(), request.GetBiz(),
+		int(request.GetOffset()), int(request.GetLimit()))
+	if err != nil {
+		return nil, err
+	}
+	return &interv1.GetLikedItemsResponse{
+		Likes: slice.Map(likes, func(idx int, src domain.UserLike) *interv1.UserLike {
+			return i.toUserLikeDTO(src)
+		}),
+	}, nil
+}
+
+func (i *InteractiveServiceServer) TopN(ctx context.Context, request *interv1.TopNRequest) (*interv1.TopNResponse, error) {
+	ranks, err := i.svc.TopN(ctx, request.GetBiz(), domain.Window(request.GetWindow()), int(request.GetN()))
+	if err != nil {
+		return nil, err
+	}
+	return &interv1.TopNResponse{
+		Items: slice.Map(ranks, func(idx int, src domain.LikeRank) *interv1.TopItem {
+			return &interv1.TopItem{
+				BizId:   src.BizId,
+				LikeCnt: src.LikeCnt,
+			}
+		}),
+	}, nil
+}
+
+func (i *InteractiveServiceServer) ChangeReaction(ctx context.Context, request *interv1.ChangeReactionRequest) (*interv1.ChangeReactionResponse, error) {
+	err := i.reactSvc.ChangeReaction(ctx, request.GetBiz(), request.GetBizId(), request.GetUid(), request.GetReaction())
+	return &interv1.ChangeReactionResponse{}, err
+}
+
+func (i *InteractiveServiceServer) RemoveReaction(ctx context.Context, request *interv1.RemoveReactionRequest) (*interv1.RemoveReactionResponse, error) {
+	err := i.reactSvc.RemoveReaction(ctx, request.GetBiz(), request.GetBizId(), request.GetUid(), request.GetReaction())
+	return &interv1.RemoveReactionResponse{}, err
+}
+
+func (i *InteractiveServiceServer) GetDailyStats(ctx context.Context, request *interv1.GetDailyStatsRequest) (*interv1.GetDailyStatsResponse, error) {
+	stats, err := i.statsSvc.GetDailyStats(ctx, request.GetBiz(), request.GetBizIds(),
+		time.UnixMilli(request.GetStart()), time.UnixMilli(request.GetEnd()))
+	if err != nil {
+		return nil, err
+	}
+	return &interv1.GetDailyStatsResponse{
+		Stats: slice.Map(stats, func(idx int, src domain.DailyStat) *interv1.DailyStat {
+			return &interv1.DailyStat{
+				BizId:         src.BizId,
+				Day:           src.Day.UnixMilli(),
+				ReadCnt:       src.ReadCnt,
+				UniqueReadCnt: src.UniqueReadCnt,
+				LikeCnt:       src.LikeCnt,
+				CollectCnt:    src.CollectCnt,
+			}
+		}),
+	}, nil
+}
+
+func (i *InteractiveServiceServer) CreateCollection(ctx context.Context, request *interv1.CreateCollectionRequest) (*interv1.CreateCollectionResponse, error) {
+	id, err := i.colSvc.Create(ctx, i.toCollectionDomain(request.GetCollection()))
+	if err != nil {
+		return nil, err
+	}
+	return &interv1.CreateCollectionResponse{Id: id}, nil
+}
+
+func (i *InteractiveServiceServer) UpdateCollection(ctx context.Context, request *interv1.UpdateCollectionRequest) (*interv1.UpdateCollectionResponse, error) {
+	err := i.colSvc.Update(ctx, i.toCollectionDomain(request.GetCollection()))
+	return &interv1.UpdateCollectionResponse{}, err
+}
+
+func (i *InteractiveServiceServer) DeleteCollection(ctx context.Context, request *interv1.DeleteCollectionRequest) (*interv1.DeleteCollectionResponse, error) {
+	err := i.colSvc.Delete(ctx, request.GetUid(), request.GetId())
+	return &interv1.DeleteCollectionResponse{}, err
+}
+
+func (i *InteractiveServiceServer) ListCollections(ctx context.Context, request *interv1.ListCollectionsRequest) (*interv1.ListCollectionsResponse, error) {
+	cols, err := i.colSvc.List(ctx, request.GetUid(), request.GetViewer(),
+		int(request.GetOffset()), int(request.GetLimit()))
+	if err != nil {
+		return nil, err
+	}
+	return &interv1.ListCollectionsResponse{
+		Collections: slice.Map(cols, func(idx int, src domain.Collection) *interv1.Collection {
+			return i.toCollectionDTO(src)
+		}),
+	}, nil
+}
+
+func (i *InteractiveServiceServer) ListCollectionItems(ctx context.Context, request *interv1.ListCollectionItemsRequest) (*interv1.ListCollectionItemsResponse, error) {
+	items, err := i.colSvc.ListItems(ctx, request.GetUid(), request.GetCid(), request.GetViewer(),
+		int(request.GetOffset()), int(request.GetLimit()))
+	if err != nil {
+		return nil, err
+	}
+	return &interv1.ListCollectionItemsResponse{
+		Items: slice.Map(items, func(idx int, src domain.CollectionItem) *interv1.CollectionItem {
+			return &interv1.CollectionItem{
+				Cid:   src.Cid,
+				Uid:   src.Uid,
+				Biz:   src.Biz,
+				BizId: src.BizId,
+				Ctime: src.Ctime.UnixMilli(),
+				Utime: src.Utime.UnixMilli(),
+			}
+		}),
+	}, nil
+}
+
+func (i *InteractiveServiceServer) MoveCollectionItems(ctx context.Context, request *interv1.MoveCollectionItemsRequest) (*interv1.MoveCollectionItemsResponse, error) {
+	err := i.colSvc.MoveItems(ctx, request.GetUid(), request.GetBiz(), request.GetBizIds(), request.GetCid())
+	return &interv1.MoveCollectionItemsResponse{}, err
+}
+
+func (i *InteractiveServiceServer) toCollectionDomain(col *interv1.Collection) domain.Collection {
+	return domain.Collection{
+		Id:          col.GetId(),
+		Uid:         col.GetUid(),
+		Name:        col.GetName(),
+		Description: col.GetDescription(),
+		Public:      col.GetPublic(),
+	}
+}
+
+func (i *InteractiveServiceServer) toCollectionDTO(col domain.Collection) *interv1.Collection {
+	return &interv1.Collection{
+		Id:          col.Id,
+		Uid:         col.Uid,
+		Name:        col.Name,
+		Description: col.Description,
+		Public:      col.Public,
+		Ctime:       col.Ctime.UnixMilli(),
+		Utime:       col.Utime.UnixMilli(),
+	}
+}
+
+func (i *InteractiveServiceServer) toUserLikeDTO(like domain.UserLike) *interv1.UserLike {
+	return &interv1.UserLike{
+		Uid:   like.Uid,
+		Biz:   like.Biz,
+		BizId: like.BizId,
+		Ctime: like.Ctime.UnixMilli(),
+	}
+}
+
 func (i *InteractiveServiceServer) toDTO(inter domain.Interactive) *interv1.Interactive {
 	return &interv1.Interactive{
-		Biz:        inter.Biz,
-		BizId:      inter.BizId,
-		ReadCnt:    inter.ReadCnt,
-		CollectCnt: inter.CollectCnt,
-		LikeCnt:    inter.LikeCnt,
-		Liked:      inter.Liked,
-		Collected:  inter.Collected,
+		Biz:           inter.Biz,
+		BizId:         inter.BizId,
+		ReadCnt:       inter.ReadCnt,
+		UniqueReadCnt: inter.UniqueReadCnt,
+		CollectCnt:    inter.CollectCnt,
+		CommentCnt:    inter.CommentCnt,
+		LikeCnt:       inter.LikeCnt,
+		Liked:         inter.Liked,
+		Collected:     inter.Collected,
+		Reactions:     inter.Reactions,
+		MyReactions:   inter.MyReactions,
 	}
 }
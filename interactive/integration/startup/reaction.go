@@ -0,0 +1,15 @@
+// Copyright@daidai53 2024
+package startup
+
+import (
+	"github.com/daidai53/webook/interactive/domain"
+	"github.com/daidai53/webook/interactive/service"
+)
+
+func InitReactionSets() service.ReactionSets {
+	return service.ReactionSets{
+		"article": domain.ReactionSet{
+			Reactions: []string{domain.ReactionLike, "heart", "laugh", "party"},
+		},
+	}
+}
@@ -4,6 +4,7 @@
 package startup
 
 import (
+	"github.com/daidai53/webook/interactive/events"
 	"github.com/daidai53/webook/interactive/grpc"
 	"github.com/daidai53/webook/interactive/repository"
 	"github.com/daidai53/webook/interactive/repository/cache"
@@ -16,8 +17,8 @@ import (
 var thirdPartySet = wire.NewSet(
 	InitDB,
 	InitRedis,
-	//InitSaramaClient,
-	//InitSyncProducer,
+	InitSaramaClient,
+	InitSyncProducer,
 	ioc.InitLogger,
 )
 
@@ -25,14 +26,23 @@ var interactiveSvcSet = wire.NewSet(
 	dao.NewGORMInteractiveDAO,
 	cache.NewInteractiveRedisCache,
 	repository.NewCachedInteractiveRepository,
+	events.NewSaramaSyncProducer,
 	service.NewInteractiveService,
+	dao.NewGORMCollectionDAO,
+	repository.NewCollectionRepository,
+	service.NewCollectionService,
+	InitReactionSets,
+	service.NewReactionService,
+	dao.NewGORMStatsDAO,
+	repository.NewStatsRepository,
+	service.NewStatsService,
 )
 
 func InitInteractiveService() *grpc.InteractiveServiceServer {
 	wire.Build(
 		thirdPartySet,
 		interactiveSvcSet,
-		cache.NewTopLikesCache,
+		cache.NewLeaderboardRedisCache,
 		grpc.NewInteractiveServiceServer,
 	)
 	return new(grpc.InteractiveServiceServer)
@@ -7,6 +7,7 @@
 package startup
 
 import (
+	"github.com/daidai53/webook/interactive/events"
 	"github.com/daidai53/webook/interactive/grpc"
 	"github.com/daidai53/webook/interactive/repository"
 	"github.com/daidai53/webook/interactive/repository/cache"
@@ -19,15 +20,26 @@ import (
 // Injectors from wire.go:
 
 func InitInteractiveService() *grpc.InteractiveServiceServer {
-	db := InitDB()
-	interactiveDAO := dao.NewGORMInteractiveDAO(db)
+	gormDB := InitDB()
+	interactiveDAO := dao.NewGORMInteractiveDAO(gormDB)
 	cmdable := InitRedis()
 	interactiveCache := cache.NewInteractiveRedisCache(cmdable)
+	leaderboardCache := cache.NewLeaderboardRedisCache(cmdable)
 	loggerV1 := ioc.InitLogger()
-	topLikesArticleCache := cache.NewTopLikesCache(cmdable, loggerV1)
-	interactiveRepository := repository.NewCachedInteractiveRepository(interactiveDAO, interactiveCache, topLikesArticleCache, loggerV1)
-	interactiveService := service.NewInteractiveService(interactiveRepository)
-	interactiveServiceServer := grpc.NewInteractiveServiceServer(interactiveService)
+	interactiveRepository := repository.NewCachedInteractiveRepository(interactiveDAO, interactiveCache, leaderboardCache, loggerV1)
+	collectionDAO := dao.NewGORMCollectionDAO(gormDB)
+	collectionRepository := repository.NewCollectionRepository(collectionDAO)
+	client := InitSaramaClient()
+	syncProducer := InitSyncProducer(client)
+	producer := events.NewSaramaSyncProducer(syncProducer)
+	interactiveService := service.NewInteractiveService(interactiveRepository, collectionRepository, producer, loggerV1)
+	collectionService := service.NewCollectionService(collectionRepository)
+	reactionSets := InitReactionSets()
+	reactionService := service.NewReactionService(interactiveRepository, producer, reactionSets, loggerV1)
+	statsDAO := dao.NewGORMStatsDAO(gormDB)
+	statsRepository := repository.NewStatsRepository(statsDAO)
+	statsService := service.NewStatsService(statsRepository)
+	interactiveServiceServer := grpc.NewInteractiveServiceServer(interactiveService, collectionService, reactionService, statsService)
 	return interactiveServiceServer
 }
 
@@ -35,7 +47,9 @@ func InitInteractiveService() *grpc.InteractiveServiceServer {
 
 var thirdPartySet = wire.NewSet(
 	InitDB,
-	InitRedis, ioc.InitLogger,
+	InitRedis,
+	InitSaramaClient,
+	InitSyncProducer, ioc.InitLogger,
 )
 
-var interactiveSvcSet = wire.NewSet(dao.NewGORMInteractiveDAO, cache.NewInteractiveRedisCache, repository.NewCachedInteractiveRepository, service.NewInteractiveService)
+var interactiveSvcSet = wire.NewSet(dao.NewGORMInteractiveDAO, cache.NewInteractiveRedisCache, repository.NewCachedInteractiveRepository, events.NewSaramaSyncProducer, service.NewInteractiveService, dao.NewGORMCollectionDAO, repository.NewCollectionRepository, service.NewCollectionService, InitReactionSets, service.NewReactionService, dao.NewGORMStatsDAO, repository.NewStatsRepository, service.NewStatsService)
@@ -0,0 +1,93 @@
+// Copyright@daidai53 2024
+package ioc
+
+import (
+	"github.com/daidai53/webook/interactive/job"
+	"github.com/daidai53/webook/interactive/repository"
+	"github.com/daidai53/webook/interactive/service"
+	job2 "github.com/daidai53/webook/internal/job"
+	"github.com/daidai53/webook/pkg/logger"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/robfig/cron/v3"
+	"github.com/spf13/viper"
+	"time"
+)
+
+func InitSnapshotJob(svc service.StatsService) *job.SnapshotJob {
+	return job.NewSnapshotJob(svc, time.Hour)
+}
+
+func InitReconcileJob(repo repository.InteractiveRepository, l logger.LoggerV1) *job.ReconcileJob {
+	return job.NewReconcileJob(repo, l, 1000, time.Minute)
+}
+
+// InitFlushJob 刷增量的间隔是 interactive.writeBehind.interval
+func InitFlushJob(repo *repository.WriteBehindInteractiveRepository) *job.FlushJob {
+	cfg := loadWriteBehindConfig()
+	return job.NewFlushJob(repo, cfg.Interval*10)
+}
+
+// InitLeaderboardRebuildJob interactive.leaderboard.bizs 里面的 biz 如果 Redis 里面没有总榜，就从数据库重建
+func InitLeaderboardRebuildJob(repo repository.InteractiveRepository, l logger.LoggerV1) *job.LeaderboardRebuildJob {
+	type Config struct {
+		Bizs []string `yaml:"bizs"`
+	}
+	cfg := Config{
+		Bizs: []string{"article"},
+	}
+	err := viper.UnmarshalKey("interactive.leaderboard", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	return job.NewLeaderboardRebuildJob(repo, cfg.Bizs, l, 15*time.Minute)
+}
+
+func InitJobs(l logger.LoggerV1, sJob *job.SnapshotJob, rJob *job.ReconcileJob,
+	fJob *job.FlushJob, lJob *job.LeaderboardRebuildJob) *cron.Cron {
+	builder := job2.NewCronJobBuilder(l, prometheus.SummaryOpts{
+		Namespace: "daidai53",
+		Subsystem: "webook",
+		Name:      "interactive_cron_job",
+		Objectives: map[float64]float64{
+			0.5:   0.01,
+			0.75:  0.01,
+			0.9:   0.01,
+			0.99:  0.001,
+			0.999: 0.0001,
+		},
+	})
+	expr := cron.New(cron.WithSeconds())
+	// 每天 00:05 记录前一天的
+	_, err := expr.AddJob("0 5 0 * * *", builder.Build(sJob))
+	if err != nil {
+		panic(err)
+	}
+	// 每分钟对一批缓存
+	_, err = expr.AddJob("@every 1m", builder.Build(rJob))
+	if err != nil {
+		panic(err)
+	}
+	// 上一轮还没跑完的就跳过这一轮，不然慢的时候会越积越多
+	skip := cron.NewChain(cron.SkipIfStillRunning(cron.DiscardLogger))
+	_, err = expr.AddJob("@every "+loadWriteBehindConfig().Interval.String(), skip.Then(builder.Build(fJob)))
+	if err != nil {
+		panic(err)
+	}
+	// 启动之后马上重建，之后 Redis 里面的总榜丢了也能恢复
+	expr.Schedule(&startNowSchedule{interval: 5 * time.Minute}, skip.Then(builder.Build(lJob)))
+	return expr
+}
+
+// startNowSchedule 启动的时候马上跑一次，之后每隔 interval 跑一次
+type startNowSchedule struct {
+	interval time.Duration
+	started  bool
+}
+
+func (s *startNowSchedule) Next(t time.Time) time.Time {
+	if !s.started {
+		s.started = true
+		return t
+	}
+	return t.Add(s.interval)
+}
@@ -36,6 +36,7 @@ func InitSaramaSyncProducer(client sarama.Client) sarama.SyncProducer {
 	return p
 }
 
-func InitConsumers(c1 *events2.InteractiveReadEventConsumer, fixConsumer *fixer.Consumer[dao.Interactive]) []events.Consumer {
-	return []events.Consumer{c1, fixConsumer}
+func InitConsumers(c1 *events2.InteractiveReadEventConsumer, c2 *events2.LikeLeaderboardConsumer,
+	c3 *events2.CommentCntConsumer, fixConsumer *fixer.Consumer[dao.Interactive]) []events.Consumer {
+	return []events.Consumer{c1, c2, c3, fixConsumer}
 }
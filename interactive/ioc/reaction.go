@@ -0,0 +1,29 @@
+// Copyright@daidai53 2024
+package ioc
+
+import (
+	"github.com/daidai53/webook/interactive/domain"
+	"github.com/daidai53/webook/interactive/service"
+	"github.com/spf13/viper"
+)
+
+// InitReactionSets 每个 biz 能用的表情在 interactive.reactions 下面配置
+func InitReactionSets() service.ReactionSets {
+	type Config struct {
+		Reactions []string `yaml:"reactions"`
+		Multi     bool     `yaml:"multi"`
+	}
+	var cfg map[string]Config
+	err := viper.UnmarshalKey("interactive.reactions", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	res := make(service.ReactionSets, len(cfg))
+	for biz, c := range cfg {
+		res[biz] = domain.ReactionSet{
+			Reactions: c.Reactions,
+			Multi:     c.Multi,
+		}
+	}
+	return res
+}
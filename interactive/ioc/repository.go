@@ -0,0 +1,50 @@
+// Copyright@daidai53 2024
+package ioc
+
+import (
+	"github.com/daidai53/webook/interactive/repository"
+	"github.com/daidai53/webook/interactive/repository/cache"
+	"github.com/daidai53/webook/interactive/repository/dao"
+	"github.com/daidai53/webook/pkg/logger"
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/viper"
+	"time"
+)
+
+type writeBehindConfig struct {
+	Bizs      []string      `yaml:"bizs"`
+	Interval  time.Duration `yaml:"interval"`
+	BatchSize int           `yaml:"batchSize"`
+}
+
+func loadWriteBehindConfig() writeBehindConfig {
+	cfg := writeBehindConfig{
+		Interval:  time.Second,
+		BatchSize: 200,
+	}
+	err := viper.UnmarshalKey("interactive.writeBehind", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	return cfg
+}
+
+// InitInteractiveRepository interactive.writeBehind.bizs 里面的 biz 开启 write-behind，
+// main 里面监听了配置文件，改配置可以随时开关，已经攒下来的增量不管开没开都会继续刷到数据库。
+// 刷增量和重建排行榜都是定时任务，见 InitJobs
+func InitInteractiveRepository(d dao.InteractiveDAO, c cache.InteractiveCache, board cache.LeaderboardCache,
+	deltas cache.DeltaCache, l logger.LoggerV1) *repository.WriteBehindInteractiveRepository {
+	cfg := loadWriteBehindConfig()
+	cached := repository.NewCachedInteractiveRepository(d, c, board, l)
+	res := repository.NewWriteBehindInteractiveRepository(cached, d, c, deltas, cfg.Bizs, cfg.BatchSize, l)
+	viper.OnConfigChange(func(in fsnotify.Event) {
+		var newCfg writeBehindConfig
+		err := viper.UnmarshalKey("interactive.writeBehind", &newCfg)
+		if err != nil {
+			l.Error("读取 write-behind 配置失败", logger.Error(err))
+			return
+		}
+		res.UpdateBizs(newCfg.Bizs)
+	})
+	return res
+}
@@ -0,0 +1,31 @@
+// Copyright@daidai53 2024
+package job
+
+import (
+	"context"
+	"github.com/daidai53/webook/interactive/repository"
+	"time"
+)
+
+// FlushJob 把 write-behind 攒在 Redis 里面的计数增量刷到数据库
+type FlushJob struct {
+	repo    *repository.WriteBehindInteractiveRepository
+	timeout time.Duration
+}
+
+func NewFlushJob(repo *repository.WriteBehindInteractiveRepository, timeout time.Duration) *FlushJob {
+	return &FlushJob{
+		repo:    repo,
+		timeout: timeout,
+	}
+}
+
+func (f *FlushJob) Name() string {
+	return "interactive_flush_deltas"
+}
+
+func (f *FlushJob) Run() error {
+	ctx, cancel := context.WithTimeout(context.Background(), f.timeout)
+	defer cancel()
+	return f.repo.Flush(ctx)
+}
@@ -0,0 +1,45 @@
+// Copyright@daidai53 2024
+package job
+
+import (
+	"context"
+	"github.com/daidai53/webook/interactive/repository"
+	"github.com/daidai53/webook/pkg/logger"
+	"time"
+)
+
+// LeaderboardRebuildJob Redis 里面没有总榜的 biz 从数据库重建，有的话什么都不做。
+// Redis 数据丢了之后下一轮就能恢复
+type LeaderboardRebuildJob struct {
+	repo    repository.InteractiveRepository
+	bizs    []string
+	l       logger.LoggerV1
+	timeout time.Duration
+}
+
+func NewLeaderboardRebuildJob(repo repository.InteractiveRepository, bizs []string,
+	l logger.LoggerV1, timeout time.Duration) *LeaderboardRebuildJob {
+	return &LeaderboardRebuildJob{
+		repo:    repo,
+		bizs:    bizs,
+		l:       l,
+		timeout: timeout,
+	}
+}
+
+func (r *LeaderboardRebuildJob) Name() string {
+	return "interactive_leaderboard_rebuild"
+}
+
+// Run 一个 biz 失败了不影响其它的
+func (r *LeaderboardRebuildJob) Run() error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+	for _, biz := range r.bizs {
+		err := r.repo.RebuildLeaderboard(ctx, biz)
+		if err != nil {
+			r.l.Error("重建点赞排行榜失败", logger.Error(err), logger.String("biz", biz))
+		}
+	}
+	return nil
+}
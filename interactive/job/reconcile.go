@@ -0,0 +1,106 @@
+// Copyright@daidai53 2024
+package job
+
+import (
+	"context"
+	"github.com/daidai53/webook/interactive/repository"
+	"github.com/daidai53/webook/interactive/repository/cache"
+	"github.com/daidai53/webook/pkg/logger"
+	"github.com/prometheus/client_golang/prometheus"
+	"sync"
+	"time"
+)
+
+// ReconcileJob 对比 Redis 和 MySQL 里的计数，对不上的把缓存删掉，让下次查询从数据库加载。
+// 每次只扫一小批，游标记在内存里，扫完一轮从头再来。
+type ReconcileJob struct {
+	repo      repository.InteractiveRepository
+	l         logger.LoggerV1
+	batchSize int
+	timeout   time.Duration
+
+	mu     sync.Mutex
+	cursor uint64
+
+	checked     prometheus.Counter
+	drift       *prometheus.CounterVec
+	driftAmount *prometheus.CounterVec
+}
+
+func NewReconcileJob(repo repository.InteractiveRepository, l logger.LoggerV1,
+	batchSize int, timeout time.Duration) *ReconcileJob {
+	checked := prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "daidai53",
+		Subsystem: "webook",
+		Name:      "interactive_reconcile_checked_total",
+		Help:      "对账检查过的缓存数量",
+	})
+	drift := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "daidai53",
+		Subsystem: "webook",
+		Name:      "interactive_reconcile_drift_total",
+		Help:      "缓存和数据库对不上的次数",
+	}, []string{"biz", "field"})
+	driftAmount := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "daidai53",
+		Subsystem: "webook",
+		Name:      "interactive_reconcile_drift_amount_total",
+		Help:      "缓存和数据库差了多少，取绝对值",
+	}, []string{"biz", "field"})
+	prometheus.MustRegister(checked, drift, driftAmount)
+	return &ReconcileJob{
+		repo:        repo,
+		l:           l,
+		batchSize:   batchSize,
+		timeout:     timeout,
+		checked:     checked,
+		drift:       drift,
+		driftAmount: driftAmount,
+	}
+}
+
+func (r *ReconcileJob) Name() string {
+	return "interactive_reconcile"
+}
+
+func (r *ReconcileJob) Run() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+	drifts, checked, next, err := r.repo.ScanDrift(ctx, r.cursor, r.batchSize)
+	if err != nil {
+		return err
+	}
+	r.cursor = next
+	r.checked.Add(float64(checked))
+	// 同一个缓存可能好几个字段都对不上，删一次就够了
+	repaired := make(map[cache.BizKey]struct{}, len(drifts))
+	for _, d := range drifts {
+		amount := d.Cache - d.DB
+		if amount < 0 {
+			amount = -amount
+		}
+		r.drift.WithLabelValues(d.Biz, d.Field).Inc()
+		r.driftAmount.WithLabelValues(d.Biz, d.Field).Add(float64(amount))
+		r.l.Warn("互动计数缓存和数据库不一致",
+			logger.String("biz", d.Biz),
+			logger.Int64("biz_id", d.BizId),
+			logger.String("field", d.Field),
+			logger.Int64("cache", d.Cache),
+			logger.Int64("db", d.DB))
+		key := cache.BizKey{Biz: d.Biz, BizId: d.BizId}
+		if _, ok := repaired[key]; ok {
+			continue
+		}
+		repaired[key] = struct{}{}
+		err = r.repo.RepairCache(ctx, d.Biz, d.BizId)
+		if err != nil {
+			r.l.Error("修复互动计数缓存失败",
+				logger.String("biz", d.Biz),
+				logger.Int64("biz_id", d.BizId),
+				logger.Error(err))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+// Copyright@daidai53 2024
+package job
+
+import (
+	"context"
+	"github.com/daidai53/webook/interactive/service"
+	"time"
+)
+
+// SnapshotJob 每天凌晨记录前一天的互动数据。
+// 好几个实例同时跑也没关系，快照是按天覆盖写的。
+type SnapshotJob struct {
+	svc     service.StatsService
+	timeout time.Duration
+}
+
+func NewSnapshotJob(svc service.StatsService, timeout time.Duration) *SnapshotJob {
+	return &SnapshotJob{
+		svc:     svc,
+		timeout: timeout,
+	}
+}
+
+func (s *SnapshotJob) Name() string {
+	return "interactive_snapshot"
+}
+
+func (s *SnapshotJob) Run() error {
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+	return s.svc.Snapshot(ctx, time.Now().AddDate(0, 0, -1))
+}
@@ -25,6 +25,10 @@ func main() {
 			panic(err)
 		}
 	}
+	app.cron.Start()
+	defer func() {
+		<-app.cron.Stop().Done()
+	}()
 	go func() {
 		err1 := app.adminServer.Start()
 		panic(err1)
@@ -42,6 +46,8 @@ func initViper() {
 	if err != nil {
 		panic(err)
 	}
+	// write-behind 的开关要能随时改
+	viper.WatchConfig()
 	val := viper.Get("test.key")
 	log.Println(val)
 }
@@ -0,0 +1,150 @@
+// Copyright@daidai53 2024
+package cache
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"github.com/redis/go-redis/v9"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+//go:embed lua/seal_delta.lua
+var luaSealDelta string
+
+// 所有 key 都带同一个 hash tag，在 Redis Cluster 下面 lua 脚本也能跑
+const (
+	deltaKey        = "{interactive:delta}:current"
+	deltaPendingKey = "{interactive:delta}:pending"
+)
+
+// CntDelta 一个 biz 攒下来的计数增量
+type CntDelta struct {
+	Biz           string
+	BizId         int64
+	ReadCnt       int64
+	UniqueReadCnt int64
+	LikeCnt       int64
+}
+
+// DeltaCache 计数增量的日志，先攒在 Redis 里面，定时整批刷到数据库。
+// 进程崩溃的时候增量还在 Redis 里面，不会丢。
+type DeltaCache interface {
+	IncrReadCnt(ctx context.Context, biz string, bizId int64, unique bool) error
+	IncrLikeCnt(ctx context.Context, biz string, bizId int64, delta int64) error
+	// Seal 把目前攒下来的增量封成一个批次，没有增量的时候返回 false
+	Seal(ctx context.Context, batch string) (bool, error)
+	// PendingBatches 封好了但是还没有刷到库里的批次
+	PendingBatches(ctx context.Context) ([]string, error)
+	// GetBatch 按照 biz, bizId 排好序，同一个批次每次拿到的顺序都一样
+	GetBatch(ctx context.Context, batch string) ([]CntDelta, error)
+	// DelBatch 刷完库之后删掉
+	DelBatch(ctx context.Context, batch string) error
+}
+
+type DeltaRedisCache struct {
+	client redis.Cmdable
+}
+
+func NewDeltaRedisCache(client redis.Cmdable) DeltaCache {
+	return &DeltaRedisCache{
+		client: client,
+	}
+}
+
+func (d *DeltaRedisCache) IncrReadCnt(ctx context.Context, biz string, bizId int64, unique bool) error {
+	if !unique {
+		return d.client.HIncrBy(ctx, deltaKey, d.field(fieldReadCnt, biz, bizId), 1).Err()
+	}
+	_, err := d.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HIncrBy(ctx, deltaKey, d.field(fieldReadCnt, biz, bizId), 1)
+		pipe.HIncrBy(ctx, deltaKey, d.field(fieldUniqueReadCnt, biz, bizId), 1)
+		return nil
+	})
+	return err
+}
+
+func (d *DeltaRedisCache) IncrLikeCnt(ctx context.Context, biz string, bizId int64, delta int64) error {
+	return d.client.HIncrBy(ctx, deltaKey, d.field(fieldLikeCnt, biz, bizId), delta).Err()
+}
+
+func (d *DeltaRedisCache) Seal(ctx context.Context, batch string) (bool, error) {
+	res, err := d.client.Eval(ctx, luaSealDelta,
+		[]string{deltaKey, d.batchKey(batch), deltaPendingKey}, batch).Int()
+	return res == 1, err
+}
+
+func (d *DeltaRedisCache) PendingBatches(ctx context.Context) ([]string, error) {
+	return d.client.SMembers(ctx, deltaPendingKey).Result()
+}
+
+func (d *DeltaRedisCache) GetBatch(ctx context.Context, batch string) ([]CntDelta, error) {
+	res, err := d.client.HGetAll(ctx, d.batchKey(batch)).Result()
+	if err != nil {
+		return nil, err
+	}
+	type bizKey struct {
+		biz   string
+		bizId int64
+	}
+	deltas := make(map[bizKey]*CntDelta, len(res))
+	for field, val := range res {
+		// field 的格式是 cnt:bizId:biz，biz 放在最后，里面有冒号也不要紧
+		segs := strings.SplitN(field, ":", 3)
+		if len(segs) != 3 {
+			continue
+		}
+		bizId, err := strconv.ParseInt(segs[1], 10, 64)
+		if err != nil {
+			continue
+		}
+		cnt, err := strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			continue
+		}
+		key := bizKey{biz: segs[2], bizId: bizId}
+		delta, ok := deltas[key]
+		if !ok {
+			delta = &CntDelta{Biz: key.biz, BizId: key.bizId}
+			deltas[key] = delta
+		}
+		switch segs[0] {
+		case fieldReadCnt:
+			delta.ReadCnt += cnt
+		case fieldUniqueReadCnt:
+			delta.UniqueReadCnt += cnt
+		case fieldLikeCnt:
+			delta.LikeCnt += cnt
+		}
+	}
+	ret := make([]CntDelta, 0, len(deltas))
+	for _, delta := range deltas {
+		ret = append(ret, *delta)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].Biz != ret[j].Biz {
+			return ret[i].Biz < ret[j].Biz
+		}
+		return ret[i].BizId < ret[j].BizId
+	})
+	return ret, nil
+}
+
+func (d *DeltaRedisCache) DelBatch(ctx context.Context, batch string) error {
+	_, err := d.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, d.batchKey(batch))
+		pipe.SRem(ctx, deltaPendingKey, batch)
+		return nil
+	})
+	return err
+}
+
+func (d *DeltaRedisCache) field(cnt string, biz string, bizId int64) string {
+	return fmt.Sprintf("%s:%d:%s", cnt, bizId, biz)
+}
+
+func (d *DeltaRedisCache) batchKey(batch string) string {
+	return fmt.Sprintf("{interactive:delta}:batch:%s", batch)
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/daidai53/webook/interactive/domain"
 	"github.com/redis/go-redis/v9"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -17,19 +18,58 @@ var ErrKeyNotFound = errors.New("没有记录")
 var (
 	//go:embed lua/incr_cnt.lua
 	luaIncrCnt string
+	//go:embed lua/add_liker.lua
+	luaAddLiker string
 )
 
+// LikersCacheSize 只缓存最近点赞的这么多个人，也就是第一页
+const LikersCacheSize = 50
+
 const fieldReadCnt = "read_cnt"
+const fieldUniqueReadCnt = "unique_read_cnt"
 const fieldLikeCnt = "like_cnt"
 const fieldCollectCnt = "collect_cnt"
+const fieldCommentCnt = "comment_cnt"
+
+// counterKeyPrefix 计数缓存的 key 的前缀，后面是 biz:bizId
+const counterKeyPrefix = "interactive:article:"
+
+// counterExpiration 计数缓存的过期时间
+const counterExpiration = time.Minute * 15
+
+// fieldReactionPrefix 每种表情的数量单独一个 field
+const fieldReactionPrefix = "reaction:"
 
 type InteractiveCache interface {
 	IncrReadCntIfPresent(ctx context.Context, biz string, id int64) error
+	IncrUniqueReadCntIfPresent(ctx context.Context, biz string, id int64) error
+	// AddReaders 记录当天读过的人，返回的是每一条是不是这个人当天第一次读
+	AddReaders(ctx context.Context, biz []string, bizIds []int64, uids []int64) ([]bool, error)
 	IncrLikeCntIfPresent(ctx context.Context, biz string, id int64) error
 	DecrLikeCntIfPresent(ctx context.Context, biz string, id int64) error
 	IncrCollectCntIfPresent(ctx context.Context, biz string, bizId int64) error
+	DecrCollectCntIfPresent(ctx context.Context, biz string, bizId int64) error
+	IncrReactionCntIfPresent(ctx context.Context, biz string, bizId int64, reaction string, delta int64) error
 	Get(ctx context.Context, biz string, bizId int64) (domain.Interactive, error)
 	Set(ctx context.Context, res domain.Interactive, biz string, bizId int64) error
+	// BatchGet 一次 pipeline 查多个，没有缓存的不在返回的 map 里面
+	BatchGet(ctx context.Context, biz string, bizIds []int64) (map[int64]domain.Interactive, error)
+	// BatchSet 一次 pipeline 写多个，用的是 domain.Interactive 里面的 BizId
+	BatchSet(ctx context.Context, biz string, intrs []domain.Interactive) error
+	Del(ctx context.Context, biz string, bizId int64) error
+	// ScanKeys 一批一批地扫缓存了计数的东西，cursor 从 0 开始，返回 0 表示扫完了一轮
+	ScanKeys(ctx context.Context, cursor uint64, count int64) ([]BizKey, uint64, error)
+	// AddLikerIfPresent 缓存了点赞人列表才加进去
+	AddLikerIfPresent(ctx context.Context, biz string, bizId int64, uid int64, likeTime time.Time) error
+	// GetLikers 最近点赞的人，没有缓存返回 ErrKeyNotFound
+	GetLikers(ctx context.Context, biz string, bizId int64, offset, limit int) ([]domain.UserLike, error)
+	SetLikers(ctx context.Context, biz string, bizId int64, likers []domain.UserLike) error
+	DelLikers(ctx context.Context, biz string, bizId int64) error
+}
+
+type BizKey struct {
+	Biz   string
+	BizId int64
 }
 
 type InteractiveRedisCache struct {
@@ -45,14 +85,11 @@ func NewInteractiveRedisCache(cmd redis.Cmdable) InteractiveCache {
 func (i *InteractiveRedisCache) Set(ctx context.Context, res domain.Interactive,
 	biz string, bizId int64) error {
 	key := i.key(biz, bizId)
-	err := i.client.HSet(ctx, key,
-		fieldLikeCnt, res.LikeCnt,
-		fieldReadCnt, res.ReadCnt,
-		fieldCollectCnt, res.CollectCnt).Err()
+	err := i.client.HSet(ctx, key, i.fields(res)...).Err()
 	if err != nil {
 		return err
 	}
-	return i.client.Expire(ctx, key, time.Minute*15).Err()
+	return i.client.Expire(ctx, key, counterExpiration).Err()
 }
 
 func (i *InteractiveRedisCache) Get(ctx context.Context, biz string, bizId int64) (domain.Interactive, error) {
@@ -64,12 +101,81 @@ func (i *InteractiveRedisCache) Get(ctx context.Context, biz string, bizId int64
 	if len(res) == 0 {
 		return domain.Interactive{}, ErrKeyNotFound
 	}
+	return i.parse(bizId, res), nil
+}
+
+func (i *InteractiveRedisCache) BatchGet(ctx context.Context, biz string, bizIds []int64) (map[int64]domain.Interactive, error) {
+	cmds := make([]*redis.MapStringStringCmd, len(bizIds))
+	_, err := i.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for idx, bizId := range bizIds {
+			cmds[idx] = pipe.HGetAll(ctx, i.key(biz, bizId))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[int64]domain.Interactive, len(bizIds))
+	for idx, cmd := range cmds {
+		if len(cmd.Val()) == 0 {
+			continue
+		}
+		res[bizIds[idx]] = i.parse(bizIds[idx], cmd.Val())
+	}
+	return res, nil
+}
+
+func (i *InteractiveRedisCache) BatchSet(ctx context.Context, biz string, intrs []domain.Interactive) error {
+	_, err := i.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, intr := range intrs {
+			key := i.key(biz, intr.BizId)
+			pipe.HSet(ctx, key, i.fields(intr)...)
+			pipe.Expire(ctx, key, counterExpiration)
+		}
+		return nil
+	})
+	return err
+}
+
+// fields 转成 HSET 的参数
+func (i *InteractiveRedisCache) fields(res domain.Interactive) []any {
+	vals := []any{
+		fieldLikeCnt, res.LikeCnt,
+		fieldReadCnt, res.ReadCnt,
+		fieldUniqueReadCnt, res.UniqueReadCnt,
+		fieldCollectCnt, res.CollectCnt,
+		fieldCommentCnt, res.CommentCnt,
+	}
+	for reaction, cnt := range res.Reactions {
+		// 👍 就是点赞数，不用再存一份
+		if reaction == domain.ReactionLike {
+			continue
+		}
+		vals = append(vals, fieldReactionPrefix+reaction, cnt)
+	}
+	return vals
+}
+
+func (i *InteractiveRedisCache) parse(bizId int64, res map[string]string) domain.Interactive {
 	var intr domain.Interactive
 	intr.BizId = bizId
 	intr.ReadCnt, _ = strconv.ParseInt(res[fieldReadCnt], 10, 64)
+	intr.UniqueReadCnt, _ = strconv.ParseInt(res[fieldUniqueReadCnt], 10, 64)
 	intr.LikeCnt, _ = strconv.ParseInt(res[fieldLikeCnt], 10, 64)
 	intr.CollectCnt, _ = strconv.ParseInt(res[fieldCollectCnt], 10, 64)
-	return intr, nil
+	intr.CommentCnt, _ = strconv.ParseInt(res[fieldCommentCnt], 10, 64)
+	intr.Reactions = make(map[string]int64)
+	for field, val := range res {
+		reaction, ok := strings.CutPrefix(field, fieldReactionPrefix)
+		if !ok {
+			continue
+		}
+		cnt, _ := strconv.ParseInt(val, 10, 64)
+		if cnt > 0 {
+			intr.Reactions[reaction] = cnt
+		}
+	}
+	return intr
 }
 
 func (i *InteractiveRedisCache) IncrCollectCntIfPresent(ctx context.Context, biz string, bizId int64) error {
@@ -78,6 +184,18 @@ func (i *InteractiveRedisCache) IncrCollectCntIfPresent(ctx context.Context, biz
 	return err
 }
 
+func (i *InteractiveRedisCache) DecrCollectCntIfPresent(ctx context.Context, biz string, bizId int64) error {
+	key := i.key(biz, bizId)
+	_, err := i.client.Eval(ctx, luaIncrCnt, []string{key}, fieldCollectCnt, -1).Int()
+	return err
+}
+
+func (i *InteractiveRedisCache) IncrReactionCntIfPresent(ctx context.Context, biz string, bizId int64, reaction string, delta int64) error {
+	key := i.key(biz, bizId)
+	_, err := i.client.Eval(ctx, luaIncrCnt, []string{key}, fieldReactionPrefix+reaction, delta).Int()
+	return err
+}
+
 func (i *InteractiveRedisCache) IncrLikeCntIfPresent(ctx context.Context, biz string, id int64) error {
 	key := i.key(biz, id)
 	_, err := i.client.Eval(ctx, luaIncrCnt, []string{key}, fieldLikeCnt, 1).Int()
@@ -95,6 +213,125 @@ func (i *InteractiveRedisCache) IncrReadCntIfPresent(ctx context.Context, biz st
 	_, err := i.client.Eval(ctx, luaIncrCnt, []string{key}, fieldReadCnt, 1).Int()
 	return err
 }
+func (i *InteractiveRedisCache) AddLikerIfPresent(ctx context.Context, biz string, bizId int64, uid int64, likeTime time.Time) error {
+	return i.client.Eval(ctx, luaAddLiker, []string{i.likersKey(biz, bizId)},
+		uid, likeTime.UnixMilli(), LikersCacheSize).Err()
+}
+
+func (i *InteractiveRedisCache) GetLikers(ctx context.Context, biz string, bizId int64, offset, limit int) ([]domain.UserLike, error) {
+	key := i.likersKey(biz, bizId)
+	cnt, err := i.client.Exists(ctx, key).Result()
+	if err != nil {
+		return nil, err
+	}
+	if cnt == 0 {
+		return nil, ErrKeyNotFound
+	}
+	res, err := i.client.ZRevRangeWithScores(ctx, key, int64(offset), int64(offset+limit-1)).Result()
+	if err != nil {
+		return nil, err
+	}
+	likers := make([]domain.UserLike, 0, len(res))
+	for _, z := range res {
+		uid, _ := strconv.ParseInt(z.Member.(string), 10, 64)
+		likers = append(likers, domain.UserLike{
+			Uid:   uid,
+			Biz:   biz,
+			BizId: bizId,
+			Ctime: time.UnixMilli(int64(z.Score)),
+		})
+	}
+	return likers, nil
+}
+
+func (i *InteractiveRedisCache) SetLikers(ctx context.Context, biz string, bizId int64, likers []domain.UserLike) error {
+	if len(likers) == 0 {
+		return nil
+	}
+	key := i.likersKey(biz, bizId)
+	members := make([]redis.Z, 0, len(likers))
+	for _, l := range likers {
+		members = append(members, redis.Z{
+			Score:  float64(l.Ctime.UnixMilli()),
+			Member: l.Uid,
+		})
+	}
+	_, err := i.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, key)
+		pipe.ZAdd(ctx, key, members...)
+		pipe.Expire(ctx, key, time.Minute*15)
+		return nil
+	})
+	return err
+}
+
+func (i *InteractiveRedisCache) DelLikers(ctx context.Context, biz string, bizId int64) error {
+	return i.client.Del(ctx, i.likersKey(biz, bizId)).Err()
+}
+
+func (i *InteractiveRedisCache) likersKey(biz string, bizId int64) string {
+	return fmt.Sprintf("interactive:likers:%s:%d", biz, bizId)
+}
+
+func (i *InteractiveRedisCache) IncrUniqueReadCntIfPresent(ctx context.Context, biz string, id int64) error {
+	key := i.key(biz, id)
+	_, err := i.client.Eval(ctx, luaIncrCnt, []string{key}, fieldUniqueReadCnt, 1).Int()
+	return err
+}
+
+func (i *InteractiveRedisCache) AddReaders(ctx context.Context, biz []string, bizIds []int64, uids []int64) ([]bool, error) {
+	day := time.Now().Format("20060102")
+	cmds := make([]*redis.IntCmd, len(biz))
+	_, err := i.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for idx := range biz {
+			key := i.readersKey(biz[idx], bizIds[idx], day)
+			// HyperLogLog 有一点误差，但是不管阅读的人有多少，一个 key 最多只占 12KB
+			cmds[idx] = pipe.PFAdd(ctx, key, uids[idx])
+			// 第二天就不用了，多留一天防止跨天的时候出问题
+			pipe.Expire(ctx, key, time.Hour*48)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	res := make([]bool, len(cmds))
+	for idx, cmd := range cmds {
+		res[idx] = cmd.Val() == 1
+	}
+	return res, nil
+}
+
+func (i *InteractiveRedisCache) readersKey(biz string, bizId int64, day string) string {
+	return fmt.Sprintf("interactive:readers:%s:%d:%s", biz, bizId, day)
+}
+
+func (i *InteractiveRedisCache) Del(ctx context.Context, biz string, bizId int64) error {
+	return i.client.Del(ctx, i.key(biz, bizId)).Err()
+}
+
+func (i *InteractiveRedisCache) ScanKeys(ctx context.Context, cursor uint64, count int64) ([]BizKey, uint64, error) {
+	keys, next, err := i.client.Scan(ctx, cursor, counterKeyPrefix+"*", count).Result()
+	if err != nil {
+		return nil, 0, err
+	}
+	res := make([]BizKey, 0, len(keys))
+	for _, key := range keys {
+		// biz 里面可能有冒号，所以从后面切
+		rest := strings.TrimPrefix(key, counterKeyPrefix)
+		idx := strings.LastIndex(rest, ":")
+		if idx < 0 {
+			continue
+		}
+		bizId, err := strconv.ParseInt(rest[idx+1:], 10, 64)
+		if err != nil {
+			continue
+		}
+		res = append(res, BizKey{Biz: rest[:idx], BizId: bizId})
+	}
+	return res, next, nil
+}
+
 func (i *InteractiveRedisCache) key(biz string, bizId int64) string {
-	return fmt.Sprintf("interactive:article:%s:%d", biz, bizId)
+	return fmt.Sprintf("%s%s:%d", counterKeyPrefix, biz, bizId)
 }
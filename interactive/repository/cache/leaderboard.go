@@ -0,0 +1,178 @@
+// Copyright@daidai53 2024
+package cache
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"github.com/daidai53/webook/interactive/domain"
+	"github.com/redis/go-redis/v9"
+	"strconv"
+	"time"
+)
+
+var (
+	//go:embed lua/zincr_if_present.lua
+	luaZIncrIfPresent string
+	//go:embed lua/rename_if_present.lua
+	luaRenameIfPresent string
+)
+
+var ErrUnknownWindow = errors.New("未知的排行榜窗口")
+
+const (
+	// 最近 24 小时的榜是 24 个小时桶合起来的，小时桶多留一个小时防止跨点的时候出问题
+	hourBucketTTL = 25 * time.Hour
+	// 最近 7 天的榜是 7 个天桶合起来的
+	dayBucketTTL = 8 * 24 * time.Hour
+	// 合出来的榜缓存一会，不然每次查询都要合一次
+	unionTTL = 30 * time.Second
+)
+
+// LeaderboardCache 按 biz 分开的点赞排行榜，每个 biz 有总榜、日榜和周榜
+type LeaderboardCache interface {
+	// IncrLike 同时更新所有窗口的榜
+	IncrLike(ctx context.Context, deltas []domain.LikeDelta) error
+	TopN(ctx context.Context, biz string, window domain.Window, n int) ([]domain.LikeRank, error)
+	// AllTimeExists 总榜在不在，不在就要从数据库重建
+	AllTimeExists(ctx context.Context, biz string) (bool, error)
+	// AddToRebuild 重建总榜的时候分批加进去，重建完之前查不到
+	AddToRebuild(ctx context.Context, biz string, ranks []domain.LikeRank) error
+	// FinishRebuild 用重建好的总榜换掉现在的
+	FinishRebuild(ctx context.Context, biz string) error
+}
+
+type LeaderboardRedisCache struct {
+	client redis.Cmdable
+}
+
+func NewLeaderboardRedisCache(client redis.Cmdable) LeaderboardCache {
+	return &LeaderboardRedisCache{
+		client: client,
+	}
+}
+
+func (l *LeaderboardRedisCache) IncrLike(ctx context.Context, deltas []domain.LikeDelta) error {
+	if len(deltas) == 0 {
+		return nil
+	}
+	_, err := l.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, d := range deltas {
+			member := strconv.FormatInt(d.BizId, 10)
+			pipe.Eval(ctx, luaZIncrIfPresent, []string{l.allKey(d.Biz)}, d.Delta, member)
+			hourKey := l.hourKey(d.Biz, d.Ctime)
+			pipe.ZIncrBy(ctx, hourKey, float64(d.Delta), member)
+			pipe.Expire(ctx, hourKey, hourBucketTTL)
+			dayKey := l.dayKey(d.Biz, d.Ctime)
+			pipe.ZIncrBy(ctx, dayKey, float64(d.Delta), member)
+			pipe.Expire(ctx, dayKey, dayBucketTTL)
+		}
+		return nil
+	})
+	return err
+}
+
+func (l *LeaderboardRedisCache) TopN(ctx context.Context, biz string, window domain.Window, n int) ([]domain.LikeRank, error) {
+	var key string
+	switch window {
+	case domain.WindowAll:
+		key = l.allKey(biz)
+	case domain.WindowDay, domain.WindowWeek:
+		var err error
+		key, err = l.union(ctx, biz, window)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, ErrUnknownWindow
+	}
+	res, err := l.client.ZRevRangeWithScores(ctx, key, 0, int64(n-1)).Result()
+	if err != nil {
+		return nil, err
+	}
+	ranks := make([]domain.LikeRank, 0, len(res))
+	for _, z := range res {
+		// 取消点赞之后窗口里面可能是 0 甚至是负数，这些不算上榜
+		if z.Score <= 0 {
+			break
+		}
+		bizId, _ := strconv.ParseInt(z.Member.(string), 10, 64)
+		ranks = append(ranks, domain.LikeRank{
+			BizId:   bizId,
+			LikeCnt: int64(z.Score),
+		})
+	}
+	return ranks, nil
+}
+
+// union 把窗口里面的桶合起来，返回合好的 key
+func (l *LeaderboardRedisCache) union(ctx context.Context, biz string, window domain.Window) (string, error) {
+	key := fmt.Sprintf("{interactive:top_likes:%s}:window:%d", biz, window)
+	cnt, err := l.client.Exists(ctx, key).Result()
+	if err != nil {
+		return "", err
+	}
+	if cnt > 0 {
+		return key, nil
+	}
+	now := time.Now()
+	var buckets []string
+	if window == domain.WindowDay {
+		for i := 0; i < 24; i++ {
+			buckets = append(buckets, l.hourKey(biz, now.Add(-time.Duration(i)*time.Hour)))
+		}
+	} else {
+		for i := 0; i < 7; i++ {
+			buckets = append(buckets, l.dayKey(biz, now.AddDate(0, 0, -i)))
+		}
+	}
+	// 并发的时候可能会合好几次，结果都一样，无所谓
+	_, err = l.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZUnionStore(ctx, key, &redis.ZStore{Keys: buckets})
+		pipe.Expire(ctx, key, unionTTL)
+		return nil
+	})
+	return key, err
+}
+
+func (l *LeaderboardRedisCache) AllTimeExists(ctx context.Context, biz string) (bool, error) {
+	cnt, err := l.client.Exists(ctx, l.allKey(biz)).Result()
+	return cnt > 0, err
+}
+
+func (l *LeaderboardRedisCache) AddToRebuild(ctx context.Context, biz string, ranks []domain.LikeRank) error {
+	if len(ranks) == 0 {
+		return nil
+	}
+	members := make([]redis.Z, 0, len(ranks))
+	for _, r := range ranks {
+		members = append(members, redis.Z{
+			Score:  float64(r.LikeCnt),
+			Member: r.BizId,
+		})
+	}
+	// 用 ZADD 而不是 ZINCRBY，好几个实例同时重建也不会加重复
+	return l.client.ZAdd(ctx, l.rebuildKey(biz), members...).Err()
+}
+
+func (l *LeaderboardRedisCache) FinishRebuild(ctx context.Context, biz string) error {
+	return l.client.Eval(ctx, luaRenameIfPresent, []string{l.rebuildKey(biz), l.allKey(biz)}).Err()
+}
+
+// 同一个 biz 的 key 都带同一个 hash tag，在 Redis Cluster 下面才能 ZUNIONSTORE 和 RENAME
+func (l *LeaderboardRedisCache) allKey(biz string) string {
+	return fmt.Sprintf("{interactive:top_likes:%s}:all", biz)
+}
+
+func (l *LeaderboardRedisCache) rebuildKey(biz string) string {
+	return fmt.Sprintf("{interactive:top_likes:%s}:rebuild", biz)
+}
+
+func (l *LeaderboardRedisCache) hourKey(biz string, t time.Time) string {
+	return fmt.Sprintf("{interactive:top_likes:%s}:hour:%s", biz, t.Format("2006010215"))
+}
+
+func (l *LeaderboardRedisCache) dayKey(biz string, t time.Time) string {
+	return fmt.Sprintf("{interactive:top_likes:%s}:day:%s", biz, t.Format("20060102"))
+}
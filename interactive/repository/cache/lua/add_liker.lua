@@ -0,0 +1,16 @@
+-- 某个东西最近的点赞人
+local key = KEYS[1]
+local uid = ARGV[1]
+-- 点赞时间
+local score = tonumber(ARGV[2])
+-- 最多缓存多少个人
+local size = tonumber(ARGV[3])
+
+if redis.call("EXISTS", key) == 1 then
+    redis.call("ZADD", key, score, uid)
+    -- 只保留最近的 size 个
+    redis.call("ZREMRANGEBYRANK", key, 0, -(size + 1))
+    return 1
+else
+    return 0
+end
\ No newline at end of file
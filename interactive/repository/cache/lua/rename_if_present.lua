@@ -0,0 +1,6 @@
+-- 别的实例已经换过了，或者压根就没有数据，都不用换
+if redis.call("EXISTS", KEYS[1]) == 1 then
+    redis.call("RENAME", KEYS[1], KEYS[2])
+    return 1
+end
+return 0
\ No newline at end of file
@@ -0,0 +1,8 @@
+-- 把正在攒的增量整个挪到批次 key 上，挪走之后新的增量会写到新的 hash 里面
+if redis.call("EXISTS", KEYS[1]) == 0 then
+    return 0
+end
+redis.call("RENAME", KEYS[1], KEYS[2])
+-- 记下来还没刷库的批次，进程在刷库之前挂了也能找回来
+redis.call("SADD", KEYS[3], ARGV[1])
+return 1
\ No newline at end of file
@@ -0,0 +1,6 @@
+-- 总榜还没有建好的时候不加，不然重建的时候会以为已经有了
+if redis.call("EXISTS", KEYS[1]) == 1 then
+    redis.call("ZINCRBY", KEYS[1], ARGV[1], ARGV[2])
+    return 1
+end
+return 0
\ No newline at end of file
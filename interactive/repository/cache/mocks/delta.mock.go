@@ -0,0 +1,127 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./interactive/repository/cache/delta.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./interactive/repository/cache/delta.go -package=cachemocks -destination=./interactive/repository/cache/mocks/delta.mock.go
+//
+// Package cachemocks is a generated GoMock package.
+package cachemocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	cache "github.com/daidai53/webook/interactive/repository/cache"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockDeltaCache is a mock of DeltaCache interface.
+type MockDeltaCache struct {
+	ctrl     *gomock.Controller
+	recorder *MockDeltaCacheMockRecorder
+}
+
+// MockDeltaCacheMockRecorder is the mock recorder for MockDeltaCache.
+type MockDeltaCacheMockRecorder struct {
+	mock *MockDeltaCache
+}
+
+// NewMockDeltaCache creates a new mock instance.
+func NewMockDeltaCache(ctrl *gomock.Controller) *MockDeltaCache {
+	mock := &MockDeltaCache{ctrl: ctrl}
+	mock.recorder = &MockDeltaCacheMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDeltaCache) EXPECT() *MockDeltaCacheMockRecorder {
+	return m.recorder
+}
+
+// DelBatch mocks base method.
+func (m *MockDeltaCache) DelBatch(ctx context.Context, batch string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DelBatch", ctx, batch)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DelBatch indicates an expected call of DelBatch.
+func (mr *MockDeltaCacheMockRecorder) DelBatch(ctx, batch any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DelBatch", reflect.TypeOf((*MockDeltaCache)(nil).DelBatch), ctx, batch)
+}
+
+// GetBatch mocks base method.
+func (m *MockDeltaCache) GetBatch(ctx context.Context, batch string) ([]cache.CntDelta, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBatch", ctx, batch)
+	ret0, _ := ret[0].([]cache.CntDelta)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBatch indicates an expected call of GetBatch.
+func (mr *MockDeltaCacheMockRecorder) GetBatch(ctx, batch any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBatch", reflect.TypeOf((*MockDeltaCache)(nil).GetBatch), ctx, batch)
+}
+
+// IncrLikeCnt mocks base method.
+func (m *MockDeltaCache) IncrLikeCnt(ctx context.Context, biz string, bizId, delta int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrLikeCnt", ctx, biz, bizId, delta)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncrLikeCnt indicates an expected call of IncrLikeCnt.
+func (mr *MockDeltaCacheMockRecorder) IncrLikeCnt(ctx, biz, bizId, delta any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrLikeCnt", reflect.TypeOf((*MockDeltaCache)(nil).IncrLikeCnt), ctx, biz, bizId, delta)
+}
+
+// IncrReadCnt mocks base method.
+func (m *MockDeltaCache) IncrReadCnt(ctx context.Context, biz string, bizId int64, unique bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrReadCnt", ctx, biz, bizId, unique)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncrReadCnt indicates an expected call of IncrReadCnt.
+func (mr *MockDeltaCacheMockRecorder) IncrReadCnt(ctx, biz, bizId, unique any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrReadCnt", reflect.TypeOf((*MockDeltaCache)(nil).IncrReadCnt), ctx, biz, bizId, unique)
+}
+
+// PendingBatches mocks base method.
+func (m *MockDeltaCache) PendingBatches(ctx context.Context) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PendingBatches", ctx)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PendingBatches indicates an expected call of PendingBatches.
+func (mr *MockDeltaCacheMockRecorder) PendingBatches(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PendingBatches", reflect.TypeOf((*MockDeltaCache)(nil).PendingBatches), ctx)
+}
+
+// Seal mocks base method.
+func (m *MockDeltaCache) Seal(ctx context.Context, batch string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Seal", ctx, batch)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Seal indicates an expected call of Seal.
+func (mr *MockDeltaCacheMockRecorder) Seal(ctx, batch any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Seal", reflect.TypeOf((*MockDeltaCache)(nil).Seal), ctx, batch)
+}
@@ -0,0 +1,103 @@
+// Copyright@daidai53 2024
+package repository
+
+import (
+	"context"
+	"github.com/daidai53/webook/interactive/domain"
+	"github.com/daidai53/webook/interactive/repository/dao"
+	"github.com/ecodeclub/ekit/slice"
+	"time"
+)
+
+type CollectionRepository interface {
+	Create(ctx context.Context, c domain.Collection) (int64, error)
+	Update(ctx context.Context, c domain.Collection) error
+	Delete(ctx context.Context, uid, cid int64) error
+	FindById(ctx context.Context, cid int64) (domain.Collection, error)
+	FindByUid(ctx context.Context, uid int64, onlyPublic bool, offset, limit int) ([]domain.Collection, error)
+	FindItems(ctx context.Context, uid, cid int64, offset, limit int) ([]domain.CollectionItem, error)
+	MoveItems(ctx context.Context, uid int64, biz string, bizIds []int64, cid int64) error
+}
+
+type collectionRepository struct {
+	dao dao.CollectionDAO
+}
+
+func NewCollectionRepository(dao dao.CollectionDAO) CollectionRepository {
+	return &collectionRepository{
+		dao: dao,
+	}
+}
+
+func (c *collectionRepository) Create(ctx context.Context, col domain.Collection) (int64, error) {
+	return c.dao.Insert(ctx, c.toEntity(col))
+}
+
+func (c *collectionRepository) Update(ctx context.Context, col domain.Collection) error {
+	return c.dao.Update(ctx, c.toEntity(col))
+}
+
+func (c *collectionRepository) Delete(ctx context.Context, uid, cid int64) error {
+	return c.dao.Delete(ctx, uid, cid)
+}
+
+func (c *collectionRepository) FindById(ctx context.Context, cid int64) (domain.Collection, error) {
+	col, err := c.dao.FindById(ctx, cid)
+	if err != nil {
+		return domain.Collection{}, err
+	}
+	return c.toDomain(col), nil
+}
+
+func (c *collectionRepository) FindByUid(ctx context.Context, uid int64, onlyPublic bool, offset, limit int) ([]domain.Collection, error) {
+	cols, err := c.dao.FindByUid(ctx, uid, onlyPublic, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(cols, func(idx int, src dao.Collection) domain.Collection {
+		return c.toDomain(src)
+	}), nil
+}
+
+func (c *collectionRepository) FindItems(ctx context.Context, uid, cid int64, offset, limit int) ([]domain.CollectionItem, error) {
+	items, err := c.dao.FindItems(ctx, uid, cid, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(items, func(idx int, src dao.UserCollectionBiz) domain.CollectionItem {
+		return domain.CollectionItem{
+			Cid:   src.Cid,
+			Uid:   src.Uid,
+			Biz:   src.Biz,
+			BizId: src.BizId,
+			Ctime: time.UnixMilli(src.CTime),
+			Utime: time.UnixMilli(src.UTime),
+		}
+	}), nil
+}
+
+func (c *collectionRepository) MoveItems(ctx context.Context, uid int64, biz string, bizIds []int64, cid int64) error {
+	return c.dao.MoveItems(ctx, uid, biz, bizIds, cid)
+}
+
+func (c *collectionRepository) toEntity(col domain.Collection) dao.Collection {
+	return dao.Collection{
+		Id:          col.Id,
+		Uid:         col.Uid,
+		Name:        col.Name,
+		Description: col.Description,
+		Public:      col.Public,
+	}
+}
+
+func (c *collectionRepository) toDomain(col dao.Collection) domain.Collection {
+	return domain.Collection{
+		Id:          col.Id,
+		Uid:         col.Uid,
+		Name:        col.Name,
+		Description: col.Description,
+		Public:      col.Public,
+		Ctime:       time.UnixMilli(col.CTime),
+		Utime:       time.UnixMilli(col.UTime),
+	}
+}
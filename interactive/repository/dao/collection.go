@@ -0,0 +1,127 @@
+// Copyright@daidai53 2024
+package dao
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"time"
+)
+
+type CollectionDAO interface {
+	Insert(ctx context.Context, c Collection) (int64, error)
+	// Update 只能改自己的收藏夹，找不到就返回 gorm.ErrRecordNotFound
+	Update(ctx context.Context, c Collection) error
+	// Delete 删除收藏夹，里面的东西回到默认收藏夹
+	Delete(ctx context.Context, uid, cid int64) error
+	FindById(ctx context.Context, cid int64) (Collection, error)
+	// FindByUid onlyPublic 为 true 的时候只找公开的收藏夹
+	FindByUid(ctx context.Context, uid int64, onlyPublic bool, offset, limit int) ([]Collection, error)
+	FindItems(ctx context.Context, uid, cid int64, offset, limit int) ([]UserCollectionBiz, error)
+	// MoveItems 把 uid 收藏的 bizIds 挪到 cid 这个收藏夹
+	MoveItems(ctx context.Context, uid int64, biz string, bizIds []int64, cid int64) error
+}
+
+type GORMCollectionDAO struct {
+	db *gorm.DB
+}
+
+func NewGORMCollectionDAO(db *gorm.DB) CollectionDAO {
+	return &GORMCollectionDAO{
+		db: db,
+	}
+}
+
+func (g *GORMCollectionDAO) Insert(ctx context.Context, c Collection) (int64, error) {
+	now := time.Now().UnixMilli()
+	c.CTime = now
+	c.UTime = now
+	err := g.db.WithContext(ctx).Create(&c).Error
+	return c.Id, err
+}
+
+func (g *GORMCollectionDAO) Update(ctx context.Context, c Collection) error {
+	res := g.db.WithContext(ctx).Model(&Collection{}).
+		Where("id = ? AND uid = ?", c.Id, c.Uid).
+		Updates(map[string]any{
+			"name":        c.Name,
+			"description": c.Description,
+			"public":      c.Public,
+			"u_time":      time.Now().UnixMilli(),
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (g *GORMCollectionDAO) Delete(ctx context.Context, uid, cid int64) error {
+	return g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Where("id = ? AND uid = ?", cid, uid).Delete(&Collection{})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return tx.Model(&UserCollectionBiz{}).
+			Where("uid = ? AND cid = ?", uid, cid).
+			Updates(map[string]any{
+				"cid":    0,
+				"u_time": time.Now().UnixMilli(),
+			}).Error
+	})
+}
+
+func (g *GORMCollectionDAO) FindById(ctx context.Context, cid int64) (Collection, error) {
+	var res Collection
+	err := g.db.WithContext(ctx).Where("id = ?", cid).First(&res).Error
+	return res, err
+}
+
+func (g *GORMCollectionDAO) FindByUid(ctx context.Context, uid int64, onlyPublic bool, offset, limit int) ([]Collection, error) {
+	var res []Collection
+	db := g.db.WithContext(ctx).Where("uid = ?", uid)
+	if onlyPublic {
+		db = db.Where("public = ?", true)
+	}
+	err := db.Order("id DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&res).Error
+	return res, err
+}
+
+func (g *GORMCollectionDAO) FindItems(ctx context.Context, uid, cid int64, offset, limit int) ([]UserCollectionBiz, error) {
+	var res []UserCollectionBiz
+	err := g.db.WithContext(ctx).
+		Where("uid = ? AND cid = ?", uid, cid).
+		Order("u_time DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&res).Error
+	return res, err
+}
+
+func (g *GORMCollectionDAO) MoveItems(ctx context.Context, uid int64, biz string, bizIds []int64, cid int64) error {
+	return g.db.WithContext(ctx).Model(&UserCollectionBiz{}).
+		Where("uid = ? AND biz = ? AND biz_id IN ?", uid, biz, bizIds).
+		Updates(map[string]any{
+			"cid":    cid,
+			"u_time": time.Now().UnixMilli(),
+		}).Error
+}
+
+// Collection 收藏夹，Id 就是 UserCollectionBiz 里面的 Cid
+// Cid 为 0 的是默认收藏夹，不在这张表里面
+type Collection struct {
+	Id          int64  `gorm:"primaryKey,autoIncrement"`
+	Uid         int64  `gorm:"index"`
+	Name        string `gorm:"type:varchar(256)"`
+	Description string `gorm:"type:varchar(1024)"`
+	Public      bool
+	UTime       int64
+	CTime       int64
+}
@@ -17,6 +17,18 @@ type DoubleWriteDAO struct {
 
 var ErrUnknownPattern = errors.New("未知的双写模式")
 
+var _ InteractiveDAO = &DoubleWriteDAO{}
+
+// NewDoubleWriteDAO 一开始只读写 src，迁移的时候通过 UpdatePattern 切换
+func NewDoubleWriteDAO(src, dst InteractiveDAO, l logger.LoggerV1) *DoubleWriteDAO {
+	return &DoubleWriteDAO{
+		src:     src,
+		dst:     dst,
+		pattern: atomicx.NewValueOf(PatternSrcOnly),
+		l:       l,
+	}
+}
+
 func (d *DoubleWriteDAO) UpdatePattern(pattern string) {
 	d.pattern.Store(pattern)
 }
@@ -57,34 +69,58 @@ func (d *DoubleWriteDAO) IncrReadCnt(ctx context.Context, biz string, id int64)
 	}
 }
 
-func (d *DoubleWriteDAO) BatchIncrReadCnt(ctx context.Context, biz []string, id []int64) error {
-	//TODO implement me
-	panic("implement me")
+func (d *DoubleWriteDAO) BatchIncrReadCnt(ctx context.Context, biz []string, id []int64, unique []bool) error {
+	return d.write(func(dao InteractiveDAO) error {
+		return dao.BatchIncrReadCnt(ctx, biz, id, unique)
+	})
 }
 
 func (d *DoubleWriteDAO) InsertLikeInfo(ctx context.Context, biz string, id int64, uid int64) error {
-	//TODO implement me
-	panic("implement me")
+	return d.write(func(dao InteractiveDAO) error {
+		return dao.InsertLikeInfo(ctx, biz, id, uid)
+	})
 }
 
 func (d *DoubleWriteDAO) DeleteLikeInfo(ctx context.Context, biz string, id int64, uid int64) error {
-	//TODO implement me
-	panic("implement me")
+	return d.write(func(dao InteractiveDAO) error {
+		return dao.DeleteLikeInfo(ctx, biz, id, uid)
+	})
 }
 
 func (d *DoubleWriteDAO) InsertCollectionBiz(ctx context.Context, cb UserCollectionBiz) error {
-	//TODO implement me
-	panic("implement me")
+	return d.write(func(dao InteractiveDAO) error {
+		return dao.InsertCollectionBiz(ctx, cb)
+	})
+}
+
+func (d *DoubleWriteDAO) DeleteCollectionBiz(ctx context.Context, biz string, bizId int64, uid int64) error {
+	return d.write(func(dao InteractiveDAO) error {
+		return dao.DeleteCollectionBiz(ctx, biz, bizId, uid)
+	})
 }
 
 func (d *DoubleWriteDAO) GetLikeInfo(ctx context.Context, biz string, bizId int64, uid int64) (UserLikeBiz, error) {
-	//TODO implement me
-	panic("implement me")
+	return doubleRead(d, func(dao InteractiveDAO) (UserLikeBiz, error) {
+		return dao.GetLikeInfo(ctx, biz, bizId, uid)
+	})
+}
+
+func (d *DoubleWriteDAO) FindLikers(ctx context.Context, biz string, bizId int64, offset, limit int) ([]UserLikeBiz, error) {
+	return doubleRead(d, func(dao InteractiveDAO) ([]UserLikeBiz, error) {
+		return dao.FindLikers(ctx, biz, bizId, offset, limit)
+	})
+}
+
+func (d *DoubleWriteDAO) FindLikedItems(ctx context.Context, uid int64, biz string, offset, limit int) ([]UserLikeBiz, error) {
+	return doubleRead(d, func(dao InteractiveDAO) ([]UserLikeBiz, error) {
+		return dao.FindLikedItems(ctx, uid, biz, offset, limit)
+	})
 }
 
 func (d *DoubleWriteDAO) GetCollectInfo(ctx context.Context, biz string, bizId int64, uid int64) (UserCollectionBiz, error) {
-	//TODO implement me
-	panic("implement me")
+	return doubleRead(d, func(dao InteractiveDAO) (UserCollectionBiz, error) {
+		return dao.GetCollectInfo(ctx, biz, bizId, uid)
+	})
 }
 
 func (d *DoubleWriteDAO) Get(ctx context.Context, biz string, bizId int64) (Interactive, error) {
@@ -99,14 +135,146 @@ func (d *DoubleWriteDAO) Get(ctx context.Context, biz string, bizId int64) (Inte
 	}
 }
 
-func (d *DoubleWriteDAO) GetAllArticleLikes() ([]Likes, error) {
-	//TODO implement me
-	panic("implement me")
+func (d *DoubleWriteDAO) FindLikes(ctx context.Context, biz string, minId int64, limit int) ([]Likes, error) {
+	return doubleRead(d, func(dao InteractiveDAO) ([]Likes, error) {
+		return dao.FindLikes(ctx, biz, minId, limit)
+	})
 }
 
 func (d *DoubleWriteDAO) GetByIds(ctx context.Context, biz string, ids []int64) ([]Interactive, error) {
-	//TODO implement me
-	panic("implement me")
+	return doubleRead(d, func(dao InteractiveDAO) ([]Interactive, error) {
+		return dao.GetByIds(ctx, biz, ids)
+	})
+}
+
+func (d *DoubleWriteDAO) InsertLikeRecord(ctx context.Context, biz string, id int64, uid int64) error {
+	return d.write(func(dao InteractiveDAO) error {
+		return dao.InsertLikeRecord(ctx, biz, id, uid)
+	})
+}
+
+func (d *DoubleWriteDAO) DeleteLikeRecord(ctx context.Context, biz string, id int64, uid int64) error {
+	return d.write(func(dao InteractiveDAO) error {
+		return dao.DeleteLikeRecord(ctx, biz, id, uid)
+	})
+}
+
+// ApplyDeltas src 和 dst 各自记录刷过了哪些 batch，重试的时候两边都不会重复加
+func (d *DoubleWriteDAO) ApplyDeltas(ctx context.Context, batch string, deltas []CntDelta) error {
+	return d.write(func(dao InteractiveDAO) error {
+		return dao.ApplyDeltas(ctx, batch, deltas)
+	})
+}
+
+// InsertReaction 返回的是先写的那边撤掉了哪些表情
+func (d *DoubleWriteDAO) InsertReaction(ctx context.Context, biz string, bizId int64, uid int64, reaction string, exclusive bool) ([]string, error) {
+	return doubleWrite(d, func(dao InteractiveDAO) ([]string, error) {
+		return dao.InsertReaction(ctx, biz, bizId, uid, reaction, exclusive)
+	})
+}
+
+func (d *DoubleWriteDAO) DeleteReaction(ctx context.Context, biz string, bizId int64, uid int64, reaction string) error {
+	return d.write(func(dao InteractiveDAO) error {
+		return dao.DeleteReaction(ctx, biz, bizId, uid, reaction)
+	})
+}
+
+func (d *DoubleWriteDAO) InsertReactionRecord(ctx context.Context, biz string, bizId int64, uid int64, reaction string, exclusive bool) ([]string, error) {
+	return doubleWrite(d, func(dao InteractiveDAO) ([]string, error) {
+		return dao.InsertReactionRecord(ctx, biz, bizId, uid, reaction, exclusive)
+	})
+}
+
+func (d *DoubleWriteDAO) DeleteReactionRecord(ctx context.Context, biz string, bizId int64, uid int64, reaction string) error {
+	return d.write(func(dao InteractiveDAO) error {
+		return dao.DeleteReactionRecord(ctx, biz, bizId, uid, reaction)
+	})
+}
+
+func (d *DoubleWriteDAO) FindUserReactions(ctx context.Context, biz string, bizId int64, uid int64) ([]UserReactionBiz, error) {
+	return doubleRead(d, func(dao InteractiveDAO) ([]UserReactionBiz, error) {
+		return dao.FindUserReactions(ctx, biz, bizId, uid)
+	})
+}
+
+func (d *DoubleWriteDAO) FindReactionCnts(ctx context.Context, biz string, bizIds []int64) ([]ReactionCnt, error) {
+	return doubleRead(d, func(dao InteractiveDAO) ([]ReactionCnt, error) {
+		return dao.FindReactionCnts(ctx, biz, bizIds)
+	})
+}
+
+func (d *DoubleWriteDAO) FindLikeInfos(ctx context.Context, biz string, bizIds []int64, uid int64) ([]UserLikeBiz, error) {
+	return doubleRead(d, func(dao InteractiveDAO) ([]UserLikeBiz, error) {
+		return dao.FindLikeInfos(ctx, biz, bizIds, uid)
+	})
+}
+
+func (d *DoubleWriteDAO) FindCollectInfos(ctx context.Context, biz string, bizIds []int64, uid int64) ([]UserCollectionBiz, error) {
+	return doubleRead(d, func(dao InteractiveDAO) ([]UserCollectionBiz, error) {
+		return dao.FindCollectInfos(ctx, biz, bizIds, uid)
+	})
+}
+
+func (d *DoubleWriteDAO) SetCommentCnt(ctx context.Context, biz string, bizId int64, cnt int64) error {
+	return d.write(func(dao InteractiveDAO) error {
+		return dao.SetCommentCnt(ctx, biz, bizId, cnt)
+	})
+}
+
+// write 和 IncrReadCnt 一样按照双写模式写两边
+func (d *DoubleWriteDAO) write(fn func(dao InteractiveDAO) error) error {
+	_, err := doubleWrite(d, func(dao InteractiveDAO) (struct{}, error) {
+		return struct{}{}, fn(dao)
+	})
+	return err
+}
+
+// doubleWrite 先写的那边成功了就算业务成功，后写的那边失败只记日志，返回先写的那边的结果
+func doubleWrite[T any](d *DoubleWriteDAO, fn func(dao InteractiveDAO) (T, error)) (T, error) {
+	pattern := d.pattern.Load()
+	switch pattern {
+	case PatternSrcOnly:
+		return fn(d.src)
+	case PatternSrcFirst:
+		res, err := fn(d.src)
+		if err != nil {
+			return res, err
+		}
+		_, err = fn(d.dst)
+		if err != nil {
+			d.l.Error("双写写入Dst失败", logger.Error(err))
+		}
+		return res, nil
+	case PatternDstFirst:
+		res, err := fn(d.dst)
+		if err != nil {
+			return res, err
+		}
+		_, err = fn(d.src)
+		if err != nil {
+			d.l.Error("双写写入src失败", logger.Error(err))
+		}
+		return res, nil
+	case PatternDstOnly:
+		return fn(d.dst)
+	default:
+		var t T
+		return t, ErrUnknownPattern
+	}
+}
+
+// doubleRead 和 Get 一样，src 优先的时候读 src，dst 优先的时候读 dst
+func doubleRead[T any](d *DoubleWriteDAO, fn func(dao InteractiveDAO) (T, error)) (T, error) {
+	pattern := d.pattern.Load()
+	switch pattern {
+	case PatternSrcOnly, PatternSrcFirst:
+		return fn(d.src)
+	case PatternDstFirst, PatternDstOnly:
+		return fn(d.dst)
+	default:
+		var t T
+		return t, ErrUnknownPattern
+	}
 }
 
 const (
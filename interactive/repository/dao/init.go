@@ -8,5 +8,11 @@ func InitTable(db *gorm.DB) error {
 		&Interactive{},
 		&UserLikeBiz{},
 		&UserCollectionBiz{},
+		&Collection{},
+		&InteractiveFlushLog{},
+		&UserReactionLock{},
+		&UserReactionBiz{},
+		&ReactionCnt{},
+		&InteractiveDailyStat{},
 	)
 }
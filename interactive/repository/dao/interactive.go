@@ -3,7 +3,9 @@ package dao
 
 import (
 	"context"
+	"errors"
 	"github.com/daidai53/webook/pkg/migrator"
+	"github.com/go-sql-driver/mysql"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 	"time"
@@ -11,15 +13,43 @@ import (
 
 type InteractiveDAO interface {
 	IncrReadCnt(ctx context.Context, biz string, id int64) error
-	BatchIncrReadCnt(ctx context.Context, biz []string, id []int64) error
+	// BatchIncrReadCnt unique 标记了哪些是当天第一次阅读，这些同时也会加 unique_read_cnt
+	BatchIncrReadCnt(ctx context.Context, biz []string, id []int64, unique []bool) error
 	InsertLikeInfo(ctx context.Context, biz string, id int64, uid int64) error
 	DeleteLikeInfo(ctx context.Context, biz string, id int64, uid int64) error
+	// InsertLikeRecord 和 DeleteLikeRecord 只记录谁点了赞，不动 interactive 表上的计数，给 write-behind 用
+	InsertLikeRecord(ctx context.Context, biz string, id int64, uid int64) error
+	DeleteLikeRecord(ctx context.Context, biz string, id int64, uid int64) error
+	// InsertReaction 发一个表情，👍 也算。exclusive 的时候先撤掉 uid 之前发的别的表情，返回撤掉了哪些。
+	// 都在锁住 UserReactionLock 的事务里面做，已经发过这个表情了返回 gorm.ErrDuplicatedKey
+	InsertReaction(ctx context.Context, biz string, bizId int64, uid int64, reaction string, exclusive bool) ([]string, error)
+	// DeleteReaction 撤回一个表情，👍 也算，没有发过这个表情返回 gorm.ErrRecordNotFound
+	DeleteReaction(ctx context.Context, biz string, bizId int64, uid int64, reaction string) error
+	// InsertReactionRecord 和 DeleteReactionRecord 和上面一样，只是 👍 不动 interactive 表上的点赞数，给 write-behind 用
+	InsertReactionRecord(ctx context.Context, biz string, bizId int64, uid int64, reaction string, exclusive bool) ([]string, error)
+	DeleteReactionRecord(ctx context.Context, biz string, bizId int64, uid int64, reaction string) error
+	FindUserReactions(ctx context.Context, biz string, bizId int64, uid int64) ([]UserReactionBiz, error)
+	FindReactionCnts(ctx context.Context, biz string, bizIds []int64) ([]ReactionCnt, error)
+	// ApplyDeltas 把一批计数增量刷到 interactive 表，同一个 batch 只会生效一次
+	ApplyDeltas(ctx context.Context, batch string, deltas []CntDelta) error
 	InsertCollectionBiz(ctx context.Context, cb UserCollectionBiz) error
+	// DeleteCollectionBiz 取消收藏，没有收藏过就返回 gorm.ErrRecordNotFound
+	DeleteCollectionBiz(ctx context.Context, biz string, bizId int64, uid int64) error
 	GetLikeInfo(ctx context.Context, biz string, bizId int64, uid int64) (UserLikeBiz, error)
+	// FindLikers 谁点赞了这个东西，最近点赞的在前面
+	FindLikers(ctx context.Context, biz string, bizId int64, offset, limit int) ([]UserLikeBiz, error)
+	// FindLikedItems uid 点赞过的 biz 类的东西，最近点赞的在前面
+	FindLikedItems(ctx context.Context, uid int64, biz string, offset, limit int) ([]UserLikeBiz, error)
 	GetCollectInfo(ctx context.Context, biz string, bizId int64, uid int64) (UserCollectionBiz, error)
+	// FindLikeInfos 和 FindCollectInfos 查 uid 在这一批东西里面点赞了或者收藏了哪些
+	FindLikeInfos(ctx context.Context, biz string, bizIds []int64, uid int64) ([]UserLikeBiz, error)
+	FindCollectInfos(ctx context.Context, biz string, bizIds []int64, uid int64) ([]UserCollectionBiz, error)
 	Get(ctx context.Context, biz string, bizId int64) (Interactive, error)
-	GetAllArticleLikes() ([]Likes, error)
+	// FindLikes 按照 id 从小到大分批查点赞数，重建排行榜的时候用
+	FindLikes(ctx context.Context, biz string, minId int64, limit int) ([]Likes, error)
 	GetByIds(ctx context.Context, biz string, ids []int64) ([]Interactive, error)
+	// SetCommentCnt 评论数是评论服务那边算好的，这里直接覆盖
+	SetCommentCnt(ctx context.Context, biz string, bizId int64, cnt int64) error
 }
 
 type GORMInteractiveDAO struct {
@@ -40,13 +70,15 @@ func (g *GORMInteractiveDAO) GetByIds(ctx context.Context, biz string, ids []int
 	return res, err
 }
 
-func (g *GORMInteractiveDAO) GetAllArticleLikes() ([]Likes, error) {
+func (g *GORMInteractiveDAO) FindLikes(ctx context.Context, biz string, minId int64, limit int) ([]Likes, error) {
 	var res []Likes
-	err := g.db.Model(&Interactive{}).Select([]string{"biz_id", "like_cnt"}).Where("biz=?", "article").Find(&res).Error
-	if err != nil {
-		return nil, err
-	}
-	return res, nil
+	err := g.db.WithContext(ctx).Model(&Interactive{}).
+		Select([]string{"id", "biz_id", "like_cnt"}).
+		Where("biz=? AND id>?", biz, minId).
+		Order("id ASC").
+		Limit(limit).
+		Find(&res).Error
+	return res, err
 }
 
 func (g *GORMInteractiveDAO) Get(ctx context.Context, biz string, bizId int64) (Interactive, error) {
@@ -61,12 +93,50 @@ func (g *GORMInteractiveDAO) GetCollectInfo(ctx context.Context, biz string, biz
 	return res, err
 }
 
+func (g *GORMInteractiveDAO) FindLikeInfos(ctx context.Context, biz string, bizIds []int64, uid int64) ([]UserLikeBiz, error) {
+	var res []UserLikeBiz
+	err := g.db.WithContext(ctx).
+		Where("uid=? AND biz=? AND biz_id IN ? AND status=?", uid, biz, bizIds, 1).
+		Find(&res).Error
+	return res, err
+}
+
+func (g *GORMInteractiveDAO) FindCollectInfos(ctx context.Context, biz string, bizIds []int64, uid int64) ([]UserCollectionBiz, error) {
+	var res []UserCollectionBiz
+	err := g.db.WithContext(ctx).
+		Where("uid=? AND biz=? AND biz_id IN ?", uid, biz, bizIds).
+		Find(&res).Error
+	return res, err
+}
+
 func (g *GORMInteractiveDAO) GetLikeInfo(ctx context.Context, biz string, bizId int64, uid int64) (UserLikeBiz, error) {
 	var res UserLikeBiz
 	err := g.db.WithContext(ctx).Where("biz=? AND biz_id=? AND uid=? AND status=?", biz, bizId, uid, 1).First(&res).Error
 	return res, err
 }
 
+func (g *GORMInteractiveDAO) FindLikers(ctx context.Context, biz string, bizId int64, offset, limit int) ([]UserLikeBiz, error) {
+	var res []UserLikeBiz
+	err := g.db.WithContext(ctx).
+		Where("biz_id=? AND biz=? AND status=?", bizId, biz, 1).
+		Order("u_time DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&res).Error
+	return res, err
+}
+
+func (g *GORMInteractiveDAO) FindLikedItems(ctx context.Context, uid int64, biz string, offset, limit int) ([]UserLikeBiz, error) {
+	var res []UserLikeBiz
+	err := g.db.WithContext(ctx).
+		Where("uid=? AND biz=? AND status=?", uid, biz, 1).
+		Order("u_time DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&res).Error
+	return res, err
+}
+
 func (g *GORMInteractiveDAO) InsertCollectionBiz(ctx context.Context, cb UserCollectionBiz) error {
 	now := time.Now().UnixMilli()
 	cb.CTime = now
@@ -91,64 +161,165 @@ func (g *GORMInteractiveDAO) InsertCollectionBiz(ctx context.Context, cb UserCol
 	})
 }
 
-func (g *GORMInteractiveDAO) InsertLikeInfo(ctx context.Context, biz string, id int64, uid int64) error {
+func (g *GORMInteractiveDAO) DeleteCollectionBiz(ctx context.Context, biz string, bizId int64, uid int64) error {
 	now := time.Now().UnixMilli()
 	return g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		err := tx.Clauses(clause.OnConflict{
-			DoUpdates: clause.Assignments(map[string]interface{}{
-				"u_time": now,
-				"status": 1,
-			}),
-		}).Create(&UserLikeBiz{
-			Uid:    uid,
-			Biz:    biz,
-			BizId:  id,
-			Status: 1,
-			UTime:  now,
-			CTime:  now,
+		res := tx.Where("uid=? AND biz_id=? AND biz=?", uid, bizId, biz).
+			Delete(&UserCollectionBiz{})
+		if res.Error != nil {
+			return res.Error
+		}
+		// 没有删到就不能减，不然重复取消收藏会把计数减成负数
+		if res.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return tx.Model(&Interactive{}).
+			Where("biz=? AND biz_id=? AND collect_cnt>0", biz, bizId).
+			Updates(map[string]interface{}{
+				"collect_cnt": gorm.Expr("`collect_cnt`-1"),
+				"u_time":      now,
+			}).Error
+	})
+}
+
+func (g *GORMInteractiveDAO) InsertLikeRecord(ctx context.Context, biz string, id int64, uid int64) error {
+	return insertLikeRecord(g.db.WithContext(ctx), biz, id, uid, time.Now().UnixMilli())
+}
+
+func insertLikeRecord(tx *gorm.DB, biz string, id int64, uid int64, now int64) error {
+	return tx.Clauses(clause.OnConflict{
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"u_time": now,
+			"status": 1,
+		}),
+	}).Create(&UserLikeBiz{
+		Uid:    uid,
+		Biz:    biz,
+		BizId:  id,
+		Status: 1,
+		UTime:  now,
+		CTime:  now,
+	}).Error
+}
+
+func (g *GORMInteractiveDAO) DeleteLikeRecord(ctx context.Context, biz string, id int64, uid int64) error {
+	return g.db.WithContext(ctx).Model(&UserLikeBiz{}).
+		Where("uid=? AND biz_id=? AND biz=?", uid, id, biz).
+		Updates(map[string]interface{}{
+			"u_time": time.Now().UnixMilli(),
+			"status": 0,
+		}).Error
+}
+
+var errBatchApplied = errors.New("这一批增量已经刷过了")
+
+func (g *GORMInteractiveDAO) ApplyDeltas(ctx context.Context, batch string, deltas []CntDelta) error {
+	now := time.Now().UnixMilli()
+	err := g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 刷库和记 batch 在同一个事务里面，中途崩溃重刷的时候不会重复加
+		err := tx.Create(&InteractiveFlushLog{
+			Batch: batch,
+			CTime: now,
 		}).Error
 		if err != nil {
+			var mysqlErr *mysql.MySQLError
+			const duplicateErr uint16 = 1062
+			if errors.As(err, &mysqlErr) && mysqlErr.Number == duplicateErr {
+				return errBatchApplied
+			}
 			return err
 		}
-		return tx.WithContext(ctx).Clauses(clause.OnConflict{
-			DoUpdates: clause.Assignments(map[string]interface{}{
-				"like_cnt": gorm.Expr("`like_cnt`+1"),
-				"u_time":   now,
-			}),
-		}).Create(&Interactive{
-			Biz:     biz,
-			BizId:   id,
-			LikeCnt: 1,
-			CTime:   now,
-			UTime:   now,
-		}).Error
+		for _, d := range deltas {
+			err = tx.Clauses(clause.OnConflict{
+				DoUpdates: clause.Assignments(map[string]interface{}{
+					"read_cnt":        gorm.Expr("`read_cnt`+?", d.ReadCnt),
+					"unique_read_cnt": gorm.Expr("`unique_read_cnt`+?", d.UniqueReadCnt),
+					"like_cnt":        gorm.Expr("`like_cnt`+?", d.LikeCnt),
+					"u_time":          now,
+				}),
+			}).Create(&Interactive{
+				Biz:           d.Biz,
+				BizId:         d.BizId,
+				ReadCnt:       d.ReadCnt,
+				UniqueReadCnt: d.UniqueReadCnt,
+				LikeCnt:       d.LikeCnt,
+				CTime:         now,
+				UTime:         now,
+			}).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if errors.Is(err, errBatchApplied) {
+		return nil
+	}
+	return err
+}
+
+func (g *GORMInteractiveDAO) InsertLikeInfo(ctx context.Context, biz string, id int64, uid int64) error {
+	now := time.Now().UnixMilli()
+	return g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return insertLike(tx, biz, id, uid, now)
 	})
 }
 
 func (g *GORMInteractiveDAO) DeleteLikeInfo(ctx context.Context, biz string, id int64, uid int64) error {
 	now := time.Now().UnixMilli()
 	return g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		err := tx.Model(&UserLikeBiz{}).
-			Where("uid=? AND biz_id=? AND biz=?", uid, id, biz).
-			Updates(map[string]interface{}{
-				"u_time": now,
-				"status": 0,
-			}).Error
-		if err != nil {
-			return err
-		}
-		return tx.Model(&Interactive{}).Where("biz=? AND biz_id=?", biz, id).Updates(map[string]interface{}{
-			"like_cnt": gorm.Expr("`like_cnt`-1"),
-			"u_time":   now,
-		}).Error
+		_, err := deleteLike(tx, biz, id, uid, now)
+		return err
 	})
 }
 
-func (g *GORMInteractiveDAO) BatchIncrReadCnt(ctx context.Context, biz []string, id []int64) error {
+func insertLike(tx *gorm.DB, biz string, id int64, uid int64, now int64) error {
+	err := insertLikeRecord(tx, biz, id, uid, now)
+	if err != nil {
+		return err
+	}
+	return tx.Clauses(clause.OnConflict{
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"like_cnt": gorm.Expr("`like_cnt`+1"),
+			"u_time":   now,
+		}),
+	}).Create(&Interactive{
+		Biz:     biz,
+		BizId:   id,
+		LikeCnt: 1,
+		CTime:   now,
+		UTime:   now,
+	}).Error
+}
+
+// deleteLike 没有点过赞返回 false，点赞数也不会减
+func deleteLike(tx *gorm.DB, biz string, id int64, uid int64, now int64) (bool, error) {
+	ok, err := deleteLikeRecord(tx, biz, id, uid, now)
+	if err != nil || !ok {
+		return false, err
+	}
+	return true, tx.Model(&Interactive{}).Where("biz=? AND biz_id=?", biz, id).Updates(map[string]interface{}{
+		"like_cnt": gorm.Expr("`like_cnt`-1"),
+		"u_time":   now,
+	}).Error
+}
+
+// deleteLikeRecord 没有点过赞返回 false
+func deleteLikeRecord(tx *gorm.DB, biz string, id int64, uid int64, now int64) (bool, error) {
+	res := tx.Model(&UserLikeBiz{}).
+		Where("uid=? AND biz_id=? AND biz=? AND status=?", uid, id, biz, 1).
+		Updates(map[string]interface{}{
+			"u_time": now,
+			"status": 0,
+		})
+	return res.RowsAffected > 0, res.Error
+}
+
+func (g *GORMInteractiveDAO) BatchIncrReadCnt(ctx context.Context, biz []string, id []int64, unique []bool) error {
 	return g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		txDAO := NewGORMInteractiveDAO(tx)
+		txDAO := &GORMInteractiveDAO{db: tx}
 		for i := 0; i < len(biz); i++ {
-			err := txDAO.IncrReadCnt(ctx, biz[i], id[i])
+			err := txDAO.incrReadCnt(ctx, biz[i], id[i], unique[i])
 			if err != nil {
 				return err
 			}
@@ -157,30 +328,57 @@ func (g *GORMInteractiveDAO) BatchIncrReadCnt(ctx context.Context, biz []string,
 	})
 }
 
+func (g *GORMInteractiveDAO) SetCommentCnt(ctx context.Context, biz string, bizId int64, cnt int64) error {
+	now := time.Now().UnixMilli()
+	return g.db.WithContext(ctx).Clauses(clause.OnConflict{
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"comment_cnt": cnt,
+			"u_time":      now,
+		}),
+	}).Create(&Interactive{
+		Biz:        biz,
+		BizId:      bizId,
+		CommentCnt: cnt,
+		CTime:      now,
+		UTime:      now,
+	}).Error
+}
+
 func (g *GORMInteractiveDAO) IncrReadCnt(ctx context.Context, biz string, id int64) error {
+	return g.incrReadCnt(ctx, biz, id, false)
+}
+
+func (g *GORMInteractiveDAO) incrReadCnt(ctx context.Context, biz string, id int64, unique bool) error {
 	now := time.Now().UnixMilli()
+	var uniqueDelta int64
+	if unique {
+		uniqueDelta = 1
+	}
 	return g.db.WithContext(ctx).Clauses(clause.OnConflict{
 		DoUpdates: clause.Assignments(map[string]interface{}{
-			"read_cnt": gorm.Expr("`read_cnt`+1"),
-			"u_time":   now,
+			"read_cnt":        gorm.Expr("`read_cnt`+1"),
+			"unique_read_cnt": gorm.Expr("`unique_read_cnt`+?", uniqueDelta),
+			"u_time":          now,
 		}),
 	}).Create(&Interactive{
-		Biz:     biz,
-		BizId:   id,
-		ReadCnt: 1,
-		CTime:   now,
-		UTime:   now,
+		Biz:           biz,
+		BizId:         id,
+		ReadCnt:       1,
+		UniqueReadCnt: uniqueDelta,
+		CTime:         now,
+		UTime:         now,
 	}).Error
 }
 
 type UserLikeBiz struct {
 	Id int64 `gorm:"primaryKey,autoIncrement"`
 	// <bizId, biz>
-	Uid    int64  `gorm:"uniqueIndex:uid_biz_type_id"`
-	BizId  int64  `gorm:"uniqueIndex:uid_biz_type_id"`
-	Biz    string `gorm:"type:varchar(128);uniqueIndex:uid_biz_type_id"`
-	Status int
-	UTime  int64
+	// biz_status_utime 用来查某人点赞过的东西，biz_id_status_utime 用来查谁点赞了某个东西
+	Uid    int64  `gorm:"uniqueIndex:uid_biz_type_id;index:uid_biz_status_utime,priority:1"`
+	BizId  int64  `gorm:"uniqueIndex:uid_biz_type_id;index:biz_id_status_utime,priority:1"`
+	Biz    string `gorm:"type:varchar(128);uniqueIndex:uid_biz_type_id;index:uid_biz_status_utime,priority:2;index:biz_id_status_utime,priority:2"`
+	Status int    `gorm:"index:uid_biz_status_utime,priority:3;index:biz_id_status_utime,priority:3"`
+	UTime  int64  `gorm:"index:uid_biz_status_utime,priority:4;index:biz_id_status_utime,priority:4"`
 	CTime  int64
 }
 
@@ -198,11 +396,15 @@ type UserCollectionBiz struct {
 type Interactive struct {
 	Id int64 `gorm:"primaryKey,autoIncrement"`
 	// <bizId, biz>
-	BizId      int64  `gorm:"uniqueIndex:biz_type_id"`
-	Biz        string `gorm:"type:varchar(128);uniqueIndex:biz_type_id"`
-	ReadCnt    int64
-	LikeCnt    int64
-	CollectCnt int64
+	BizId   int64  `gorm:"uniqueIndex:biz_type_id"`
+	Biz     string `gorm:"type:varchar(128);uniqueIndex:biz_type_id"`
+	ReadCnt int64
+	// UniqueReadCnt 每个人每天只算一次
+	UniqueReadCnt int64
+	LikeCnt       int64
+	CollectCnt    int64
+	// CommentCnt 评论服务那边同步过来的
+	CommentCnt int64
 	UTime      int64
 	CTime      int64
 }
@@ -219,7 +421,24 @@ func (i Interactive) CompareTo(dst migrator.Entity) bool {
 	return i == val
 }
 
+// CntDelta 一段时间内攒下来的计数增量
+type CntDelta struct {
+	Biz           string
+	BizId         int64
+	ReadCnt       int64
+	UniqueReadCnt int64
+	LikeCnt       int64
+}
+
+// InteractiveFlushLog 记录已经刷到库里的增量批次
+type InteractiveFlushLog struct {
+	Id    int64  `gorm:"primaryKey,autoIncrement"`
+	Batch string `gorm:"type:varchar(128);uniqueIndex"`
+	CTime int64
+}
+
 type Likes struct {
+	Id      int64
 	BizId   int64
 	LikeCnt int64
 }
@@ -0,0 +1,403 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./interactive/repository/dao/interactive.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./interactive/repository/dao/interactive.go -package=daomocks -destination=./interactive/repository/dao/mocks/interactive.mock.go
+//
+// Package daomocks is a generated GoMock package.
+package daomocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	dao "github.com/daidai53/webook/interactive/repository/dao"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockInteractiveDAO is a mock of InteractiveDAO interface.
+type MockInteractiveDAO struct {
+	ctrl     *gomock.Controller
+	recorder *MockInteractiveDAOMockRecorder
+}
+
+// MockInteractiveDAOMockRecorder is the mock recorder for MockInteractiveDAO.
+type MockInteractiveDAOMockRecorder struct {
+	mock *MockInteractiveDAO
+}
+
+// NewMockInteractiveDAO creates a new mock instance.
+func NewMockInteractiveDAO(ctrl *gomock.Controller) *MockInteractiveDAO {
+	mock := &MockInteractiveDAO{ctrl: ctrl}
+	mock.recorder = &MockInteractiveDAOMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInteractiveDAO) EXPECT() *MockInteractiveDAOMockRecorder {
+	return m.recorder
+}
+
+// ApplyDeltas mocks base method.
+func (m *MockInteractiveDAO) ApplyDeltas(ctx context.Context, batch string, deltas []dao.CntDelta) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyDeltas", ctx, batch, deltas)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApplyDeltas indicates an expected call of ApplyDeltas.
+func (mr *MockInteractiveDAOMockRecorder) ApplyDeltas(ctx, batch, deltas any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyDeltas", reflect.TypeOf((*MockInteractiveDAO)(nil).ApplyDeltas), ctx, batch, deltas)
+}
+
+// BatchIncrReadCnt mocks base method.
+func (m *MockInteractiveDAO) BatchIncrReadCnt(ctx context.Context, biz []string, id []int64, unique []bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchIncrReadCnt", ctx, biz, id, unique)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BatchIncrReadCnt indicates an expected call of BatchIncrReadCnt.
+func (mr *MockInteractiveDAOMockRecorder) BatchIncrReadCnt(ctx, biz, id, unique any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchIncrReadCnt", reflect.TypeOf((*MockInteractiveDAO)(nil).BatchIncrReadCnt), ctx, biz, id, unique)
+}
+
+// DeleteCollectionBiz mocks base method.
+func (m *MockInteractiveDAO) DeleteCollectionBiz(ctx context.Context, biz string, bizId, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCollectionBiz", ctx, biz, bizId, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCollectionBiz indicates an expected call of DeleteCollectionBiz.
+func (mr *MockInteractiveDAOMockRecorder) DeleteCollectionBiz(ctx, biz, bizId, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCollectionBiz", reflect.TypeOf((*MockInteractiveDAO)(nil).DeleteCollectionBiz), ctx, biz, bizId, uid)
+}
+
+// DeleteLikeInfo mocks base method.
+func (m *MockInteractiveDAO) DeleteLikeInfo(ctx context.Context, biz string, id, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLikeInfo", ctx, biz, id, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteLikeInfo indicates an expected call of DeleteLikeInfo.
+func (mr *MockInteractiveDAOMockRecorder) DeleteLikeInfo(ctx, biz, id, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLikeInfo", reflect.TypeOf((*MockInteractiveDAO)(nil).DeleteLikeInfo), ctx, biz, id, uid)
+}
+
+// DeleteLikeRecord mocks base method.
+func (m *MockInteractiveDAO) DeleteLikeRecord(ctx context.Context, biz string, id, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLikeRecord", ctx, biz, id, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteLikeRecord indicates an expected call of DeleteLikeRecord.
+func (mr *MockInteractiveDAOMockRecorder) DeleteLikeRecord(ctx, biz, id, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLikeRecord", reflect.TypeOf((*MockInteractiveDAO)(nil).DeleteLikeRecord), ctx, biz, id, uid)
+}
+
+// DeleteReaction mocks base method.
+func (m *MockInteractiveDAO) DeleteReaction(ctx context.Context, biz string, bizId, uid int64, reaction string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteReaction", ctx, biz, bizId, uid, reaction)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteReaction indicates an expected call of DeleteReaction.
+func (mr *MockInteractiveDAOMockRecorder) DeleteReaction(ctx, biz, bizId, uid, reaction any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteReaction", reflect.TypeOf((*MockInteractiveDAO)(nil).DeleteReaction), ctx, biz, bizId, uid, reaction)
+}
+
+// DeleteReactionRecord mocks base method.
+func (m *MockInteractiveDAO) DeleteReactionRecord(ctx context.Context, biz string, bizId, uid int64, reaction string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteReactionRecord", ctx, biz, bizId, uid, reaction)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteReactionRecord indicates an expected call of DeleteReactionRecord.
+func (mr *MockInteractiveDAOMockRecorder) DeleteReactionRecord(ctx, biz, bizId, uid, reaction any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteReactionRecord", reflect.TypeOf((*MockInteractiveDAO)(nil).DeleteReactionRecord), ctx, biz, bizId, uid, reaction)
+}
+
+// FindCollectInfos mocks base method.
+func (m *MockInteractiveDAO) FindCollectInfos(ctx context.Context, biz string, bizIds []int64, uid int64) ([]dao.UserCollectionBiz, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindCollectInfos", ctx, biz, bizIds, uid)
+	ret0, _ := ret[0].([]dao.UserCollectionBiz)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindCollectInfos indicates an expected call of FindCollectInfos.
+func (mr *MockInteractiveDAOMockRecorder) FindCollectInfos(ctx, biz, bizIds, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindCollectInfos", reflect.TypeOf((*MockInteractiveDAO)(nil).FindCollectInfos), ctx, biz, bizIds, uid)
+}
+
+// FindLikeInfos mocks base method.
+func (m *MockInteractiveDAO) FindLikeInfos(ctx context.Context, biz string, bizIds []int64, uid int64) ([]dao.UserLikeBiz, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindLikeInfos", ctx, biz, bizIds, uid)
+	ret0, _ := ret[0].([]dao.UserLikeBiz)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindLikeInfos indicates an expected call of FindLikeInfos.
+func (mr *MockInteractiveDAOMockRecorder) FindLikeInfos(ctx, biz, bizIds, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindLikeInfos", reflect.TypeOf((*MockInteractiveDAO)(nil).FindLikeInfos), ctx, biz, bizIds, uid)
+}
+
+// FindLikedItems mocks base method.
+func (m *MockInteractiveDAO) FindLikedItems(ctx context.Context, uid int64, biz string, offset, limit int) ([]dao.UserLikeBiz, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindLikedItems", ctx, uid, biz, offset, limit)
+	ret0, _ := ret[0].([]dao.UserLikeBiz)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindLikedItems indicates an expected call of FindLikedItems.
+func (mr *MockInteractiveDAOMockRecorder) FindLikedItems(ctx, uid, biz, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindLikedItems", reflect.TypeOf((*MockInteractiveDAO)(nil).FindLikedItems), ctx, uid, biz, offset, limit)
+}
+
+// FindLikers mocks base method.
+func (m *MockInteractiveDAO) FindLikers(ctx context.Context, biz string, bizId int64, offset, limit int) ([]dao.UserLikeBiz, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindLikers", ctx, biz, bizId, offset, limit)
+	ret0, _ := ret[0].([]dao.UserLikeBiz)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindLikers indicates an expected call of FindLikers.
+func (mr *MockInteractiveDAOMockRecorder) FindLikers(ctx, biz, bizId, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindLikers", reflect.TypeOf((*MockInteractiveDAO)(nil).FindLikers), ctx, biz, bizId, offset, limit)
+}
+
+// FindLikes mocks base method.
+func (m *MockInteractiveDAO) FindLikes(ctx context.Context, biz string, minId int64, limit int) ([]dao.Likes, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindLikes", ctx, biz, minId, limit)
+	ret0, _ := ret[0].([]dao.Likes)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindLikes indicates an expected call of FindLikes.
+func (mr *MockInteractiveDAOMockRecorder) FindLikes(ctx, biz, minId, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindLikes", reflect.TypeOf((*MockInteractiveDAO)(nil).FindLikes), ctx, biz, minId, limit)
+}
+
+// FindReactionCnts mocks base method.
+func (m *MockInteractiveDAO) FindReactionCnts(ctx context.Context, biz string, bizIds []int64) ([]dao.ReactionCnt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindReactionCnts", ctx, biz, bizIds)
+	ret0, _ := ret[0].([]dao.ReactionCnt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindReactionCnts indicates an expected call of FindReactionCnts.
+func (mr *MockInteractiveDAOMockRecorder) FindReactionCnts(ctx, biz, bizIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindReactionCnts", reflect.TypeOf((*MockInteractiveDAO)(nil).FindReactionCnts), ctx, biz, bizIds)
+}
+
+// FindUserReactions mocks base method.
+func (m *MockInteractiveDAO) FindUserReactions(ctx context.Context, biz string, bizId, uid int64) ([]dao.UserReactionBiz, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindUserReactions", ctx, biz, bizId, uid)
+	ret0, _ := ret[0].([]dao.UserReactionBiz)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindUserReactions indicates an expected call of FindUserReactions.
+func (mr *MockInteractiveDAOMockRecorder) FindUserReactions(ctx, biz, bizId, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindUserReactions", reflect.TypeOf((*MockInteractiveDAO)(nil).FindUserReactions), ctx, biz, bizId, uid)
+}
+
+// Get mocks base method.
+func (m *MockInteractiveDAO) Get(ctx context.Context, biz string, bizId int64) (dao.Interactive, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, biz, bizId)
+	ret0, _ := ret[0].(dao.Interactive)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockInteractiveDAOMockRecorder) Get(ctx, biz, bizId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockInteractiveDAO)(nil).Get), ctx, biz, bizId)
+}
+
+// GetByIds mocks base method.
+func (m *MockInteractiveDAO) GetByIds(ctx context.Context, biz string, ids []int64) ([]dao.Interactive, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIds", ctx, biz, ids)
+	ret0, _ := ret[0].([]dao.Interactive)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIds indicates an expected call of GetByIds.
+func (mr *MockInteractiveDAOMockRecorder) GetByIds(ctx, biz, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIds", reflect.TypeOf((*MockInteractiveDAO)(nil).GetByIds), ctx, biz, ids)
+}
+
+// GetCollectInfo mocks base method.
+func (m *MockInteractiveDAO) GetCollectInfo(ctx context.Context, biz string, bizId, uid int64) (dao.UserCollectionBiz, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCollectInfo", ctx, biz, bizId, uid)
+	ret0, _ := ret[0].(dao.UserCollectionBiz)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCollectInfo indicates an expected call of GetCollectInfo.
+func (mr *MockInteractiveDAOMockRecorder) GetCollectInfo(ctx, biz, bizId, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCollectInfo", reflect.TypeOf((*MockInteractiveDAO)(nil).GetCollectInfo), ctx, biz, bizId, uid)
+}
+
+// GetLikeInfo mocks base method.
+func (m *MockInteractiveDAO) GetLikeInfo(ctx context.Context, biz string, bizId, uid int64) (dao.UserLikeBiz, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLikeInfo", ctx, biz, bizId, uid)
+	ret0, _ := ret[0].(dao.UserLikeBiz)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLikeInfo indicates an expected call of GetLikeInfo.
+func (mr *MockInteractiveDAOMockRecorder) GetLikeInfo(ctx, biz, bizId, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLikeInfo", reflect.TypeOf((*MockInteractiveDAO)(nil).GetLikeInfo), ctx, biz, bizId, uid)
+}
+
+// IncrReadCnt mocks base method.
+func (m *MockInteractiveDAO) IncrReadCnt(ctx context.Context, biz string, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrReadCnt", ctx, biz, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncrReadCnt indicates an expected call of IncrReadCnt.
+func (mr *MockInteractiveDAOMockRecorder) IncrReadCnt(ctx, biz, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrReadCnt", reflect.TypeOf((*MockInteractiveDAO)(nil).IncrReadCnt), ctx, biz, id)
+}
+
+// InsertCollectionBiz mocks base method.
+func (m *MockInteractiveDAO) InsertCollectionBiz(ctx context.Context, cb dao.UserCollectionBiz) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertCollectionBiz", ctx, cb)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertCollectionBiz indicates an expected call of InsertCollectionBiz.
+func (mr *MockInteractiveDAOMockRecorder) InsertCollectionBiz(ctx, cb any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertCollectionBiz", reflect.TypeOf((*MockInteractiveDAO)(nil).InsertCollectionBiz), ctx, cb)
+}
+
+// InsertLikeInfo mocks base method.
+func (m *MockInteractiveDAO) InsertLikeInfo(ctx context.Context, biz string, id, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertLikeInfo", ctx, biz, id, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertLikeInfo indicates an expected call of InsertLikeInfo.
+func (mr *MockInteractiveDAOMockRecorder) InsertLikeInfo(ctx, biz, id, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertLikeInfo", reflect.TypeOf((*MockInteractiveDAO)(nil).InsertLikeInfo), ctx, biz, id, uid)
+}
+
+// InsertLikeRecord mocks base method.
+func (m *MockInteractiveDAO) InsertLikeRecord(ctx context.Context, biz string, id, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertLikeRecord", ctx, biz, id, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertLikeRecord indicates an expected call of InsertLikeRecord.
+func (mr *MockInteractiveDAOMockRecorder) InsertLikeRecord(ctx, biz, id, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertLikeRecord", reflect.TypeOf((*MockInteractiveDAO)(nil).InsertLikeRecord), ctx, biz, id, uid)
+}
+
+// InsertReaction mocks base method.
+func (m *MockInteractiveDAO) InsertReaction(ctx context.Context, biz string, bizId, uid int64, reaction string, exclusive bool) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertReaction", ctx, biz, bizId, uid, reaction, exclusive)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertReaction indicates an expected call of InsertReaction.
+func (mr *MockInteractiveDAOMockRecorder) InsertReaction(ctx, biz, bizId, uid, reaction, exclusive any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertReaction", reflect.TypeOf((*MockInteractiveDAO)(nil).InsertReaction), ctx, biz, bizId, uid, reaction, exclusive)
+}
+
+// InsertReactionRecord mocks base method.
+func (m *MockInteractiveDAO) InsertReactionRecord(ctx context.Context, biz string, bizId, uid int64, reaction string, exclusive bool) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertReactionRecord", ctx, biz, bizId, uid, reaction, exclusive)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertReactionRecord indicates an expected call of InsertReactionRecord.
+func (mr *MockInteractiveDAOMockRecorder) InsertReactionRecord(ctx, biz, bizId, uid, reaction, exclusive any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertReactionRecord", reflect.TypeOf((*MockInteractiveDAO)(nil).InsertReactionRecord), ctx, biz, bizId, uid, reaction, exclusive)
+}
+
+// SetCommentCnt mocks base method.
+func (m *MockInteractiveDAO) SetCommentCnt(ctx context.Context, biz string, bizId, cnt int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCommentCnt", ctx, biz, bizId, cnt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetCommentCnt indicates an expected call of SetCommentCnt.
+func (mr *MockInteractiveDAOMockRecorder) SetCommentCnt(ctx, biz, bizId, cnt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCommentCnt", reflect.TypeOf((*MockInteractiveDAO)(nil).SetCommentCnt), ctx, biz, bizId, cnt)
+}
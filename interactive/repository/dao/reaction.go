@@ -0,0 +1,232 @@
+// Copyright@daidai53 2024
+package dao
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+// ReactionLike 和 domain.ReactionLike 是同一个，👍 记在 UserLikeBiz 里面，数量是 Interactive.LikeCnt
+const ReactionLike = "like"
+
+func (g *GORMInteractiveDAO) InsertReaction(ctx context.Context, biz string, bizId int64, uid int64,
+	reaction string, exclusive bool) ([]string, error) {
+	return g.insertReaction(ctx, biz, bizId, uid, reaction, exclusive, true)
+}
+
+func (g *GORMInteractiveDAO) InsertReactionRecord(ctx context.Context, biz string, bizId int64, uid int64,
+	reaction string, exclusive bool) ([]string, error) {
+	return g.insertReaction(ctx, biz, bizId, uid, reaction, exclusive, false)
+}
+
+// insertReaction likeCnt 为 false 的时候 👍 只记录谁点了赞，点赞数由调用方自己攒着
+func (g *GORMInteractiveDAO) insertReaction(ctx context.Context, biz string, bizId int64, uid int64,
+	reaction string, exclusive bool, likeCnt bool) ([]string, error) {
+	var removed []string
+	err := g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		removed = nil
+		now := time.Now().UnixMilli()
+		current, err := lockUserReactions(tx, biz, bizId, uid, now)
+		if err != nil {
+			return err
+		}
+		for _, re := range current {
+			if re == reaction {
+				return gorm.ErrDuplicatedKey
+			}
+		}
+		if exclusive {
+			for _, re := range current {
+				ok, err := deleteUserReaction(tx, biz, bizId, uid, re, now, likeCnt)
+				if err != nil {
+					return err
+				}
+				if ok {
+					removed = append(removed, re)
+				}
+			}
+		}
+		return insertUserReaction(tx, biz, bizId, uid, reaction, now, likeCnt)
+	})
+	return removed, err
+}
+
+func (g *GORMInteractiveDAO) DeleteReaction(ctx context.Context, biz string, bizId int64, uid int64, reaction string) error {
+	return g.deleteReaction(ctx, biz, bizId, uid, reaction, true)
+}
+
+func (g *GORMInteractiveDAO) DeleteReactionRecord(ctx context.Context, biz string, bizId int64, uid int64, reaction string) error {
+	return g.deleteReaction(ctx, biz, bizId, uid, reaction, false)
+}
+
+func (g *GORMInteractiveDAO) deleteReaction(ctx context.Context, biz string, bizId int64, uid int64,
+	reaction string, likeCnt bool) error {
+	return g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now().UnixMilli()
+		_, err := lockUserReactions(tx, biz, bizId, uid, now)
+		if err != nil {
+			return err
+		}
+		ok, err := deleteUserReaction(tx, biz, bizId, uid, reaction, now, likeCnt)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+}
+
+// lockUserReactions 锁住 uid 在这个东西上的 UserReactionLock，同一个人并发改表情会在这里排队，
+// 返回锁住之后 uid 发过的表情，包括 👍
+func lockUserReactions(tx *gorm.DB, biz string, bizId int64, uid int64, now int64) ([]string, error) {
+	err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&UserReactionLock{
+		Uid:   uid,
+		Biz:   biz,
+		BizId: bizId,
+		CTime: now,
+		UTime: now,
+	}).Error
+	if err != nil {
+		return nil, err
+	}
+	var lock UserReactionLock
+	err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("uid=? AND biz_id=? AND biz=?", uid, bizId, biz).
+		First(&lock).Error
+	if err != nil {
+		return nil, err
+	}
+	var res []string
+	err = tx.Model(&UserReactionBiz{}).
+		Where("uid=? AND biz_id=? AND biz=?", uid, bizId, biz).
+		Pluck("reaction", &res).Error
+	if err != nil {
+		return nil, err
+	}
+	var likes int64
+	err = tx.Model(&UserLikeBiz{}).
+		Where("uid=? AND biz_id=? AND biz=? AND status=?", uid, bizId, biz, 1).
+		Count(&likes).Error
+	if err != nil {
+		return nil, err
+	}
+	if likes > 0 {
+		res = append(res, ReactionLike)
+	}
+	return res, tx.Model(&lock).Update("u_time", now).Error
+}
+
+func insertUserReaction(tx *gorm.DB, biz string, bizId int64, uid int64, reaction string, now int64, likeCnt bool) error {
+	if reaction == ReactionLike && likeCnt {
+		return insertLike(tx, biz, bizId, uid, now)
+	}
+	if reaction == ReactionLike {
+		return insertLikeRecord(tx, biz, bizId, uid, now)
+	}
+	err := tx.Create(&UserReactionBiz{
+		Uid:      uid,
+		Biz:      biz,
+		BizId:    bizId,
+		Reaction: reaction,
+		CTime:    now,
+	}).Error
+	if err != nil {
+		return err
+	}
+	err = tx.Clauses(clause.OnConflict{
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"cnt":    gorm.Expr("`cnt`+1"),
+			"u_time": now,
+		}),
+	}).Create(&ReactionCnt{
+		Biz:      biz,
+		BizId:    bizId,
+		Reaction: reaction,
+		Cnt:      1,
+		CTime:    now,
+		UTime:    now,
+	}).Error
+	if err != nil {
+		return err
+	}
+	// 只有表情没有点赞和阅读的时候也要有 interactive 这一行，不然查不到
+	return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&Interactive{
+		Biz:   biz,
+		BizId: bizId,
+		CTime: now,
+		UTime: now,
+	}).Error
+}
+
+// deleteUserReaction 没有发过这个表情返回 false
+func deleteUserReaction(tx *gorm.DB, biz string, bizId int64, uid int64, reaction string, now int64, likeCnt bool) (bool, error) {
+	if reaction == ReactionLike && likeCnt {
+		return deleteLike(tx, biz, bizId, uid, now)
+	}
+	if reaction == ReactionLike {
+		return deleteLikeRecord(tx, biz, bizId, uid, now)
+	}
+	res := tx.Where("uid=? AND biz_id=? AND biz=? AND reaction=?", uid, bizId, biz, reaction).
+		Delete(&UserReactionBiz{})
+	if res.Error != nil || res.RowsAffected == 0 {
+		return false, res.Error
+	}
+	return true, tx.Model(&ReactionCnt{}).
+		Where("biz=? AND biz_id=? AND reaction=? AND cnt>0", biz, bizId, reaction).
+		Updates(map[string]interface{}{
+			"cnt":    gorm.Expr("`cnt`-1"),
+			"u_time": now,
+		}).Error
+}
+
+func (g *GORMInteractiveDAO) FindUserReactions(ctx context.Context, biz string, bizId int64, uid int64) ([]UserReactionBiz, error) {
+	var res []UserReactionBiz
+	err := g.db.WithContext(ctx).
+		Where("uid=? AND biz_id=? AND biz=?", uid, bizId, biz).
+		Find(&res).Error
+	return res, err
+}
+
+func (g *GORMInteractiveDAO) FindReactionCnts(ctx context.Context, biz string, bizIds []int64) ([]ReactionCnt, error) {
+	var res []ReactionCnt
+	err := g.db.WithContext(ctx).
+		Where("biz=? AND biz_id IN ? AND cnt>0", biz, bizIds).
+		Find(&res).Error
+	return res, err
+}
+
+// UserReactionLock 每个人对每个东西一行，改表情之前先锁住这一行，
+// 只能发一种表情的 biz 靠这个保证并发换表情的时候不会留下两个
+type UserReactionLock struct {
+	Id    int64  `gorm:"primaryKey,autoIncrement"`
+	Uid   int64  `gorm:"uniqueIndex:uid_biz_type_id"`
+	BizId int64  `gorm:"uniqueIndex:uid_biz_type_id"`
+	Biz   string `gorm:"type:varchar(128);uniqueIndex:uid_biz_type_id"`
+	UTime int64
+	CTime int64
+}
+
+// UserReactionBiz 某个人对某个东西发的表情，👍 还是记在 UserLikeBiz 里面
+type UserReactionBiz struct {
+	Id       int64  `gorm:"primaryKey,autoIncrement"`
+	Uid      int64  `gorm:"uniqueIndex:uid_biz_type_id_reaction"`
+	BizId    int64  `gorm:"uniqueIndex:uid_biz_type_id_reaction"`
+	Biz      string `gorm:"type:varchar(128);uniqueIndex:uid_biz_type_id_reaction"`
+	Reaction string `gorm:"type:varchar(32);uniqueIndex:uid_biz_type_id_reaction"`
+	CTime    int64
+}
+
+// ReactionCnt 每种表情的数量，👍 的数量还是 Interactive.LikeCnt
+type ReactionCnt struct {
+	Id       int64  `gorm:"primaryKey,autoIncrement"`
+	BizId    int64  `gorm:"uniqueIndex:biz_type_id_reaction"`
+	Biz      string `gorm:"type:varchar(128);uniqueIndex:biz_type_id_reaction"`
+	Reaction string `gorm:"type:varchar(32);uniqueIndex:biz_type_id_reaction"`
+	Cnt      int64
+	UTime    int64
+	CTime    int64
+}
@@ -0,0 +1,202 @@
+// Copyright@daidai53 2024
+package dao
+
+import (
+	"context"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"testing"
+)
+
+// expectLock 锁住 UserReactionLock，再查出来 uid 已经发过的表情
+func expectLock(mock sqlmock.Sqlmock, reactions []string, liked bool) {
+	mock.ExpectExec("INSERT INTO `user_reaction_locks` .* ON DUPLICATE KEY UPDATE .*").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectQuery("SELECT \\* FROM `user_reaction_locks` WHERE uid=\\? AND biz_id=\\? AND biz=\\? .* FOR UPDATE").
+		WithArgs(int64(123), int64(1), "article").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "uid", "biz_id", "biz"}).AddRow(1, 123, 1, "article"))
+	rows := sqlmock.NewRows([]string{"reaction"})
+	for _, re := range reactions {
+		rows.AddRow(re)
+	}
+	mock.ExpectQuery("SELECT `reaction` FROM `user_reaction_bizs` WHERE uid=\\? AND biz_id=\\? AND biz=\\?").
+		WillReturnRows(rows)
+	cnt := 0
+	if liked {
+		cnt = 1
+	}
+	mock.ExpectQuery("SELECT count\\(\\*\\) FROM `user_like_bizs` WHERE uid=\\? AND biz_id=\\? AND biz=\\? AND status=\\?").
+		WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(cnt))
+	mock.ExpectExec("UPDATE `user_reaction_locks` SET `u_time`=\\? WHERE `id` = \\?").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+}
+
+func TestGORMInteractiveDAO_InsertReaction(t *testing.T) {
+	testCases := []struct {
+		name      string
+		mock      func(mock sqlmock.Sqlmock)
+		reaction  string
+		exclusive bool
+
+		wantRemoved []string
+		wantErr     error
+	}{
+		{
+			name:      "只能发一种，点赞换成笑脸",
+			reaction:  "laugh",
+			exclusive: true,
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectLock(mock, nil, true)
+				mock.ExpectExec("UPDATE `user_like_bizs` SET .* WHERE uid=\\? AND biz_id=\\? AND biz=\\? AND status=\\?").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE `interactives` SET `like_cnt`=`like_cnt`-1.*").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO `user_reaction_bizs` .*").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO `reaction_cnts` .* ON DUPLICATE KEY UPDATE .*").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO `interactives` .* ON DUPLICATE KEY UPDATE .*").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+			},
+			wantRemoved: []string{ReactionLike},
+		},
+		{
+			name:      "可以发好几种，不撤掉之前的",
+			reaction:  ReactionLike,
+			exclusive: false,
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectLock(mock, []string{"heart"}, false)
+				mock.ExpectExec("INSERT INTO `user_like_bizs` .* ON DUPLICATE KEY UPDATE .*").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO `interactives` .* ON DUPLICATE KEY UPDATE .*").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:      "已经发过了",
+			reaction:  "heart",
+			exclusive: true,
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectLock(mock, []string{"heart"}, false)
+				mock.ExpectRollback()
+			},
+			wantErr: gorm.ErrDuplicatedKey,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sqlDB, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			tc.mock(mock)
+			dao := NewGORMInteractiveDAO(newMockDB(t, sqlDB))
+			removed, err := dao.InsertReaction(context.Background(), "article", 1, 123, tc.reaction, tc.exclusive)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantRemoved, removed)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestGORMInteractiveDAO_InsertReactionRecord(t *testing.T) {
+	testCases := []struct {
+		name     string
+		mock     func(mock sqlmock.Sqlmock)
+		reaction string
+
+		wantRemoved []string
+	}{
+		{
+			name:     "点赞只记录谁点了赞，不加点赞数",
+			reaction: ReactionLike,
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectLock(mock, nil, false)
+				mock.ExpectExec("INSERT INTO `user_like_bizs` .* ON DUPLICATE KEY UPDATE .*").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:     "点赞换成笑脸，也不减点赞数",
+			reaction: "laugh",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectLock(mock, nil, true)
+				mock.ExpectExec("UPDATE `user_like_bizs` SET .* WHERE uid=\\? AND biz_id=\\? AND biz=\\? AND status=\\?").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO `user_reaction_bizs` .*").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO `reaction_cnts` .* ON DUPLICATE KEY UPDATE .*").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO `interactives` .* ON DUPLICATE KEY UPDATE .*").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+			},
+			wantRemoved: []string{ReactionLike},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sqlDB, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			tc.mock(mock)
+			dao := NewGORMInteractiveDAO(newMockDB(t, sqlDB))
+			removed, err := dao.InsertReactionRecord(context.Background(), "article", 1, 123, tc.reaction, true)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantRemoved, removed)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestGORMInteractiveDAO_DeleteReaction(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(mock sqlmock.Sqlmock)
+
+		wantErr error
+	}{
+		{
+			name: "撤回表情，数量减一",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectLock(mock, []string{"heart"}, false)
+				mock.ExpectExec("DELETE FROM `user_reaction_bizs` WHERE uid=\\? AND biz_id=\\? AND biz=\\? AND reaction=\\?").
+					WithArgs(int64(123), int64(1), "article", "heart").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE `reaction_cnts` SET `cnt`=`cnt`-1.*").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "没有发过",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectLock(mock, nil, false)
+				mock.ExpectExec("DELETE FROM `user_reaction_bizs` .*").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+			},
+			wantErr: gorm.ErrRecordNotFound,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sqlDB, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			tc.mock(mock)
+			dao := NewGORMInteractiveDAO(newMockDB(t, sqlDB))
+			err = dao.DeleteReaction(context.Background(), "article", 1, 123, "heart")
+			assert.Equal(t, tc.wantErr, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
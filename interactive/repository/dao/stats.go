@@ -0,0 +1,92 @@
+// Copyright@daidai53 2024
+package dao
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// StatsDAO 每天的互动数据快照
+type StatsDAO interface {
+	// FindChanged 按照 id 从小到大分批查 utime 在 since 之后的
+	FindChanged(ctx context.Context, since int64, minId int64, limit int) ([]Interactive, error)
+	// FindLatest 每个东西在 before 之前最近的一次快照
+	FindLatest(ctx context.Context, bizIds []int64, before string) ([]InteractiveDailyStat, error)
+	Upsert(ctx context.Context, stats []InteractiveDailyStat) error
+	// Find 查 [start, end] 这几天的快照，按天从前往后
+	Find(ctx context.Context, biz string, bizIds []int64, start, end string) ([]InteractiveDailyStat, error)
+}
+
+type GORMStatsDAO struct {
+	db *gorm.DB
+}
+
+func NewGORMStatsDAO(db *gorm.DB) StatsDAO {
+	return &GORMStatsDAO{
+		db: db,
+	}
+}
+
+func (g *GORMStatsDAO) FindChanged(ctx context.Context, since int64, minId int64, limit int) ([]Interactive, error) {
+	var res []Interactive
+	// 一天只跑一次，走主键扫一遍就可以了，不用专门给 u_time 建索引
+	err := g.db.WithContext(ctx).
+		Where("id>? AND u_time>=?", minId, since).
+		Order("id ASC").
+		Limit(limit).
+		Find(&res).Error
+	return res, err
+}
+
+func (g *GORMStatsDAO) FindLatest(ctx context.Context, bizIds []int64, before string) ([]InteractiveDailyStat, error) {
+	var res []InteractiveDailyStat
+	latest := g.db.Model(&InteractiveDailyStat{}).
+		Select("biz, biz_id, MAX(day) AS day").
+		Where("biz_id IN ? AND day<?", bizIds, before).
+		Group("biz, biz_id")
+	err := g.db.WithContext(ctx).
+		Joins("JOIN (?) AS l ON l.biz=interactive_daily_stats.biz AND l.biz_id=interactive_daily_stats.biz_id AND l.day=interactive_daily_stats.day", latest).
+		Find(&res).Error
+	return res, err
+}
+
+func (g *GORMStatsDAO) Upsert(ctx context.Context, stats []InteractiveDailyStat) error {
+	if len(stats) == 0 {
+		return nil
+	}
+	return g.db.WithContext(ctx).Clauses(clause.OnConflict{
+		DoUpdates: clause.AssignmentColumns([]string{
+			"read_cnt", "unique_read_cnt", "like_cnt", "collect_cnt",
+			"total_read_cnt", "total_unique_read_cnt", "total_like_cnt", "total_collect_cnt",
+		}),
+	}).Create(&stats).Error
+}
+
+func (g *GORMStatsDAO) Find(ctx context.Context, biz string, bizIds []int64, start, end string) ([]InteractiveDailyStat, error) {
+	var res []InteractiveDailyStat
+	err := g.db.WithContext(ctx).
+		Where("biz=? AND biz_id IN ? AND day>=? AND day<=?", biz, bizIds, start, end).
+		Order("day ASC").
+		Find(&res).Error
+	return res, err
+}
+
+// InteractiveDailyStat 某个东西某一天的增量，那一天没有变化就没有这一行
+type InteractiveDailyStat struct {
+	Id    int64  `gorm:"primaryKey,autoIncrement"`
+	BizId int64  `gorm:"uniqueIndex:biz_type_id_day"`
+	Biz   string `gorm:"type:varchar(128);uniqueIndex:biz_type_id_day"`
+	// Day 2006-01-02 这种格式
+	Day           string `gorm:"type:varchar(10);uniqueIndex:biz_type_id_day"`
+	ReadCnt       int64
+	UniqueReadCnt int64
+	LikeCnt       int64
+	CollectCnt    int64
+	// 下面这些是那天结束的时候的总数
+	TotalReadCnt       int64
+	TotalUniqueReadCnt int64
+	TotalLikeCnt       int64
+	TotalCollectCnt    int64
+	CTime              int64
+}
@@ -15,43 +15,80 @@ import (
 
 var ErrRecordNotFound = gorm.ErrRecordNotFound
 
+// 对账的时候用来说明是哪个计数对不上
+const (
+	FieldReadCnt       = "read_cnt"
+	FieldUniqueReadCnt = "unique_read_cnt"
+	FieldLikeCnt       = "like_cnt"
+	FieldCollectCnt    = "collect_cnt"
+	FieldCommentCnt    = "comment_cnt"
+)
+
 type InteractiveRepository interface {
 	IncrReadCnt(ctx context.Context, biz string, bizId int64) error
-	BatchIncrReadCnt(ctx context.Context, biz []string, bizId []int64) error
+	// BatchIncrReadCnt 阅读数每次都加，去重之后的阅读数同一个人一天只加一次
+	BatchIncrReadCnt(ctx context.Context, biz []string, bizId []int64, uid []int64) error
 	IncrLike(ctx context.Context, biz string, id int64, uid int64) error
 	DecrLike(ctx context.Context, biz string, id int64, uid int64) error
 	AddCollectionItem(ctx context.Context, biz string, id int64, uid int64, cid int64) error
+	// RemoveCollectionItem 没有收藏过返回 ErrRecordNotFound
+	RemoveCollectionItem(ctx context.Context, biz string, id int64, uid int64) error
+	// AddReaction 发一个表情，👍 也算。exclusive 的时候会把 uid 之前发的别的表情撤掉，返回撤掉了哪些，
+	// 已经发过这个表情了就什么都不做，added 是 false
+	AddReaction(ctx context.Context, biz string, bizId int64, uid int64, reaction string,
+		exclusive bool) (removed []string, added bool, err error)
+	// RemoveReaction 撤回一个表情，👍 也算，没有发过这个表情返回 ErrRecordNotFound
+	RemoveReaction(ctx context.Context, biz string, bizId int64, uid int64, reaction string) error
+	// UserReactions uid 发过的 👍 以外的表情
+	UserReactions(ctx context.Context, biz string, bizId int64, uid int64) ([]string, error)
 	Get(ctx context.Context, biz string, bizId int64) (domain.Interactive, error)
 	Liked(ctx context.Context, biz string, bizId int64, uid int64) (bool, error)
+	// GetLikers 第一页走缓存
+	GetLikers(ctx context.Context, biz string, bizId int64, offset, limit int) ([]domain.UserLike, error)
+	GetLikedItems(ctx context.Context, uid int64, biz string, offset, limit int) ([]domain.UserLike, error)
 	Collected(ctx context.Context, biz string, bizId int64, uid int64) (bool, error)
-	TopIds(ctx context.Context, n int) ([]int64, error)
+	// TopN 点赞排行榜
+	TopN(ctx context.Context, biz string, window domain.Window, n int) ([]domain.LikeRank, error)
+	// IncrLikeRank 消费点赞事件的时候更新排行榜
+	IncrLikeRank(ctx context.Context, deltas []domain.LikeDelta) error
+	// RebuildLeaderboard 总榜不在的时候从数据库重建
+	RebuildLeaderboard(ctx context.Context, biz string) error
+	// ScanDrift 扫一批缓存了计数的东西，和数据库对不上的返回出来，不会修。
+	// cursor 从 0 开始，返回的 next 是 0 表示扫完了一轮
+	ScanDrift(ctx context.Context, cursor uint64, count int) (drifts []domain.CntDrift, checked int, next uint64, err error)
+	// RepairCache 删掉缓存，下次查询的时候从数据库重新加载
+	RepairCache(ctx context.Context, biz string, bizId int64) error
+	// SetCommentCnt 评论服务那边同步过来的评论数，直接覆盖
+	SetCommentCnt(ctx context.Context, biz string, bizId int64, cnt int64) error
 	GetByIds(ctx context.Context, biz string, ids []int64) ([]domain.Interactive, error)
+	// BatchGet 先查缓存，没有缓存的一次性从数据库查出来再回写。数据库里面也没有的不返回
+	BatchGet(ctx context.Context, biz string, ids []int64) ([]domain.Interactive, error)
+	// LikedIds 和 CollectedIds 返回 uid 在这一批东西里面点赞了或者收藏了哪些
+	LikedIds(ctx context.Context, biz string, ids []int64, uid int64) ([]int64, error)
+	CollectedIds(ctx context.Context, biz string, ids []int64, uid int64) ([]int64, error)
 }
 
+// driftRecheckDelay 读缓存和读数据库之间可能刚好有更新，对不上的过一会再看一次，还对不上才算
+const driftRecheckDelay = 200 * time.Millisecond
+
+// rebuildBatchSize 重建排行榜的时候一次从数据库读多少条
+const rebuildBatchSize = 1000
+
 type CachedInteractiveRepository struct {
 	dao   dao2.InteractiveDAO
 	cache cache2.InteractiveCache
-	top   cache2.TopLikesArticleCache
+	board cache2.LeaderboardCache
 	l     logger.LoggerV1
 }
 
-func NewCachedInteractiveRepository(dao dao2.InteractiveDAO, cache cache2.InteractiveCache, top cache2.TopLikesArticleCache,
+func NewCachedInteractiveRepository(dao dao2.InteractiveDAO, cache cache2.InteractiveCache, board cache2.LeaderboardCache,
 	l logger.LoggerV1) InteractiveRepository {
-	ret := &CachedInteractiveRepository{
+	return &CachedInteractiveRepository{
 		dao:   dao,
 		cache: cache,
-		top:   top,
+		board: board,
 		l:     l,
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Minute)
-	defer cancel()
-	allLikes, err := ret.dao.GetAllArticleLikes()
-	if err != nil {
-		ret.l.Error("GetAllArticleLikes失败", logger.Error(err))
-	} else {
-		ret.top.Init(ctx, allLikes)
-	}
-	return ret
 }
 
 func (c *CachedInteractiveRepository) GetByIds(ctx context.Context, biz string, ids []int64) ([]domain.Interactive, error) {
@@ -59,17 +96,274 @@ func (c *CachedInteractiveRepository) GetByIds(ctx context.Context, biz string,
 	if err != nil {
 		return nil, err
 	}
+	cnts, err := c.dao.FindReactionCnts(ctx, biz, ids)
+	if err != nil {
+		return nil, err
+	}
+	reactions := make(map[int64][]dao2.ReactionCnt, len(ids))
+	for _, cnt := range cnts {
+		reactions[cnt.BizId] = append(reactions[cnt.BizId], cnt)
+	}
 	return slice.Map(intrs, func(idx int, src dao2.Interactive) domain.Interactive {
-		return c.toDomain(src)
+		return c.toDomain(src, reactions[src.BizId])
+	}), nil
+}
+
+func (c *CachedInteractiveRepository) BatchGet(ctx context.Context, biz string, ids []int64) ([]domain.Interactive, error) {
+	cached, err := c.cache.BatchGet(ctx, biz, ids)
+	if err != nil {
+		// 缓存出问题了就全部查数据库
+		c.l.Error("批量查询计数缓存失败",
+			logger.Error(err),
+			logger.String("biz", biz))
+		cached = map[int64]domain.Interactive{}
+	}
+	res := make([]domain.Interactive, 0, len(ids))
+	missed := make([]int64, 0, len(ids)-len(cached))
+	for _, id := range ids {
+		intr, ok := cached[id]
+		if !ok {
+			missed = append(missed, id)
+			continue
+		}
+		c.addLikeReaction(&intr)
+		res = append(res, intr)
+	}
+	if len(missed) == 0 {
+		return res, nil
+	}
+	loaded, err := c.GetByIds(ctx, biz, missed)
+	if err != nil {
+		return nil, err
+	}
+	err = c.cache.BatchSet(ctx, biz, loaded)
+	if err != nil {
+		c.l.Error("批量回写计数缓存失败",
+			logger.Error(err),
+			logger.String("biz", biz))
+	}
+	return append(res, loaded...), nil
+}
+
+func (c *CachedInteractiveRepository) LikedIds(ctx context.Context, biz string, ids []int64, uid int64) ([]int64, error) {
+	likes, err := c.dao.FindLikeInfos(ctx, biz, ids, uid)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(likes, func(idx int, src dao2.UserLikeBiz) int64 {
+		return src.BizId
+	}), nil
+}
+
+func (c *CachedInteractiveRepository) CollectedIds(ctx context.Context, biz string, ids []int64, uid int64) ([]int64, error) {
+	collects, err := c.dao.FindCollectInfos(ctx, biz, ids, uid)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(collects, func(idx int, src dao2.UserCollectionBiz) int64 {
+		return src.BizId
+	}), nil
+}
+
+func (c *CachedInteractiveRepository) AddReaction(ctx context.Context, biz string, bizId int64, uid int64,
+	reaction string, exclusive bool) ([]string, bool, error) {
+	removed, err := c.dao.InsertReaction(ctx, biz, bizId, uid, reaction, exclusive)
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	for _, re := range removed {
+		err = c.reactionCacheRemoved(ctx, biz, bizId, re)
+		if err != nil {
+			return removed, true, err
+		}
+	}
+	if reaction != dao2.ReactionLike {
+		return removed, true, c.cache.IncrReactionCntIfPresent(ctx, biz, bizId, reaction, 1)
+	}
+	err = c.cache.AddLikerIfPresent(ctx, biz, bizId, uid, time.Now())
+	if err != nil {
+		c.l.Error("更新点赞人缓存失败", logger.Error(err))
+	}
+	return removed, true, c.cache.IncrLikeCntIfPresent(ctx, biz, bizId)
+}
+
+func (c *CachedInteractiveRepository) RemoveReaction(ctx context.Context, biz string, bizId int64, uid int64, reaction string) error {
+	err := c.dao.DeleteReaction(ctx, biz, bizId, uid, reaction)
+	if err != nil {
+		return err
+	}
+	return c.reactionCacheRemoved(ctx, biz, bizId, reaction)
+}
+
+// reactionCacheRemoved 数据库里面撤掉了一个表情之后更新缓存
+func (c *CachedInteractiveRepository) reactionCacheRemoved(ctx context.Context, biz string, bizId int64, reaction string) error {
+	if reaction != dao2.ReactionLike {
+		return c.cache.IncrReactionCntIfPresent(ctx, biz, bizId, reaction, -1)
+	}
+	err := c.cache.DelLikers(ctx, biz, bizId)
+	if err != nil {
+		c.l.Error("删除点赞人缓存失败", logger.Error(err))
+	}
+	return c.cache.DecrLikeCntIfPresent(ctx, biz, bizId)
+}
+
+func (c *CachedInteractiveRepository) UserReactions(ctx context.Context, biz string, bizId int64, uid int64) ([]string, error) {
+	reactions, err := c.dao.FindUserReactions(ctx, biz, bizId, uid)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(reactions, func(idx int, src dao2.UserReactionBiz) string {
+		return src.Reaction
 	}), nil
 }
 
-func (c *CachedInteractiveRepository) TopIds(ctx context.Context, n int) ([]int64, error) {
-	return c.top.GetTopLikesIds(ctx, n)
+func (c *CachedInteractiveRepository) TopN(ctx context.Context, biz string, window domain.Window, n int) ([]domain.LikeRank, error) {
+	return c.board.TopN(ctx, biz, window, n)
+}
+
+func (c *CachedInteractiveRepository) IncrLikeRank(ctx context.Context, deltas []domain.LikeDelta) error {
+	return c.board.IncrLike(ctx, deltas)
+}
+
+func (c *CachedInteractiveRepository) RebuildLeaderboard(ctx context.Context, biz string) error {
+	ok, err := c.board.AllTimeExists(ctx, biz)
+	if err != nil || ok {
+		return err
+	}
+	var minId int64
+	for {
+		likes, err := c.dao.FindLikes(ctx, biz, minId, rebuildBatchSize)
+		if err != nil {
+			return err
+		}
+		err = c.board.AddToRebuild(ctx, biz, slice.Map(likes, func(idx int, src dao2.Likes) domain.LikeRank {
+			return domain.LikeRank{
+				BizId:   src.BizId,
+				LikeCnt: src.LikeCnt,
+			}
+		}))
+		if err != nil {
+			return err
+		}
+		if len(likes) < rebuildBatchSize {
+			break
+		}
+		minId = likes[len(likes)-1].Id
+	}
+	return c.board.FinishRebuild(ctx, biz)
+}
+
+func (c *CachedInteractiveRepository) ScanDrift(ctx context.Context, cursor uint64, count int) ([]domain.CntDrift, int, uint64, error) {
+	keys, next, err := c.cache.ScanKeys(ctx, cursor, int64(count))
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	var (
+		res      []domain.CntDrift
+		checked  int
+		suspects []cache2.BizKey
+	)
+	for _, key := range keys {
+		drifts, ok, err := c.compare(ctx, key.Biz, key.BizId)
+		if err != nil {
+			return nil, 0, 0, err
+		}
+		// 缓存已经过期了
+		if !ok {
+			continue
+		}
+		if len(drifts) > 0 {
+			suspects = append(suspects, key)
+			continue
+		}
+		checked++
+	}
+	if len(suspects) == 0 {
+		return res, checked, next, nil
+	}
+	// 对不上的一起等一次再看，不用每个都等
+	select {
+	case <-ctx.Done():
+		return nil, 0, 0, ctx.Err()
+	case <-time.After(driftRecheckDelay):
+	}
+	for _, key := range suspects {
+		drifts, ok, err := c.compare(ctx, key.Biz, key.BizId)
+		if err != nil {
+			return nil, 0, 0, err
+		}
+		if !ok {
+			continue
+		}
+		checked++
+		res = append(res, drifts...)
+	}
+	return res, checked, next, nil
 }
 
-func (c *CachedInteractiveRepository) BatchIncrReadCnt(ctx context.Context, biz []string, bizId []int64) error {
-	err := c.dao.BatchIncrReadCnt(ctx, biz, bizId)
+// compare 缓存不在了返回 false
+func (c *CachedInteractiveRepository) compare(ctx context.Context, biz string, bizId int64) ([]domain.CntDrift, bool, error) {
+	cached, err := c.cache.Get(ctx, biz, bizId)
+	if errors.Is(err, cache2.ErrKeyNotFound) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	ie, err := c.dao.Get(ctx, biz, bizId)
+	if err != nil && !errors.Is(err, ErrRecordNotFound) {
+		return nil, false, err
+	}
+	db := c.toDomain(ie, nil)
+	var res []domain.CntDrift
+	check := func(field string, cacheVal, dbVal int64) {
+		if cacheVal != dbVal {
+			res = append(res, domain.CntDrift{
+				Biz:   biz,
+				BizId: bizId,
+				Field: field,
+				Cache: cacheVal,
+				DB:    dbVal,
+			})
+		}
+	}
+	check(FieldReadCnt, cached.ReadCnt, db.ReadCnt)
+	check(FieldUniqueReadCnt, cached.UniqueReadCnt, db.UniqueReadCnt)
+	check(FieldLikeCnt, cached.LikeCnt, db.LikeCnt)
+	check(FieldCollectCnt, cached.CollectCnt, db.CollectCnt)
+	check(FieldCommentCnt, cached.CommentCnt, db.CommentCnt)
+	return res, true, nil
+}
+
+func (c *CachedInteractiveRepository) SetCommentCnt(ctx context.Context, biz string, bizId int64, cnt int64) error {
+	err := c.dao.SetCommentCnt(ctx, biz, bizId, cnt)
+	if err != nil {
+		return err
+	}
+	return c.cache.Del(ctx, biz, bizId)
+}
+
+func (c *CachedInteractiveRepository) RepairCache(ctx context.Context, biz string, bizId int64) error {
+	return c.cache.Del(ctx, biz, bizId)
+}
+
+func (c *CachedInteractiveRepository) BatchIncrReadCnt(ctx context.Context, biz []string, bizId []int64, uid []int64) error {
+	unique, err := c.cache.AddReaders(ctx, biz, bizId, uid)
+	if err != nil {
+		// Redis 出问题的时候宁可少算，也不要让刷新把去重之后的阅读数刷上去
+		c.l.Error("记录阅读的人失败", logger.Error(err))
+		unique = make([]bool, len(biz))
+	}
+	for i := range uid {
+		// 没有登录的不算
+		if uid[i] <= 0 {
+			unique[i] = false
+		}
+	}
+	err = c.dao.BatchIncrReadCnt(ctx, biz, bizId, unique)
 	if err != nil {
 		return err
 	}
@@ -80,7 +374,20 @@ func (c *CachedInteractiveRepository) BatchIncrReadCnt(ctx context.Context, biz
 		for i := 0; i < len(biz); i++ {
 			er := c.cache.IncrReadCntIfPresent(ctx1, biz[i], bizId[i])
 			if er != nil {
-				// log
+				c.l.Error("更新缓存的阅读数失败",
+					logger.String("biz", biz[i]),
+					logger.Int64("biz_id", bizId[i]),
+					logger.Error(er))
+			}
+			if !unique[i] {
+				continue
+			}
+			er = c.cache.IncrUniqueReadCntIfPresent(ctx1, biz[i], bizId[i])
+			if er != nil {
+				c.l.Error("更新缓存的去重阅读数失败",
+					logger.String("biz", biz[i]),
+					logger.Int64("biz_id", bizId[i]),
+					logger.Error(er))
 			}
 		}
 	}()
@@ -90,14 +397,19 @@ func (c *CachedInteractiveRepository) BatchIncrReadCnt(ctx context.Context, biz
 func (c *CachedInteractiveRepository) Get(ctx context.Context, biz string, bizId int64) (domain.Interactive, error) {
 	intr, err := c.cache.Get(ctx, biz, bizId)
 	if err == nil {
+		c.addLikeReaction(&intr)
 		return intr, err
 	}
 	ie, err := c.dao.Get(ctx, biz, bizId)
 	if err != nil {
 		return domain.Interactive{}, err
 	}
+	cnts, err := c.dao.FindReactionCnts(ctx, biz, []int64{bizId})
+	if err != nil {
+		return domain.Interactive{}, err
+	}
 
-	res := c.toDomain(ie)
+	res := c.toDomain(ie, cnts)
 	err = c.cache.Set(ctx, res, biz, bizId)
 	if err != nil {
 		c.l.Error("回写缓存失败。",
@@ -120,6 +432,54 @@ func (c *CachedInteractiveRepository) Liked(ctx context.Context, biz string, biz
 	}
 }
 
+func (c *CachedInteractiveRepository) GetLikers(ctx context.Context, biz string, bizId int64, offset, limit int) ([]domain.UserLike, error) {
+	if offset+limit > cache2.LikersCacheSize {
+		return c.findLikers(ctx, biz, bizId, offset, limit)
+	}
+	res, err := c.cache.GetLikers(ctx, biz, bizId, offset, limit)
+	if err == nil {
+		return res, nil
+	}
+	firstPage, err := c.findLikers(ctx, biz, bizId, 0, cache2.LikersCacheSize)
+	if err != nil {
+		return nil, err
+	}
+	// 点赞的人不够一页说明不热门，直接查数据库也很快，就不缓存了
+	if len(firstPage) == cache2.LikersCacheSize {
+		er := c.cache.SetLikers(ctx, biz, bizId, firstPage)
+		if er != nil {
+			c.l.Error("回写点赞人缓存失败",
+				logger.Error(er),
+				logger.String("biz", biz),
+				logger.Int64("bizId", bizId))
+		}
+	}
+	if offset >= len(firstPage) {
+		return []domain.UserLike{}, nil
+	}
+	return firstPage[offset:min(offset+limit, len(firstPage))], nil
+}
+
+func (c *CachedInteractiveRepository) GetLikedItems(ctx context.Context, uid int64, biz string, offset, limit int) ([]domain.UserLike, error) {
+	likes, err := c.dao.FindLikedItems(ctx, uid, biz, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(likes, func(idx int, src dao2.UserLikeBiz) domain.UserLike {
+		return c.toUserLike(src)
+	}), nil
+}
+
+func (c *CachedInteractiveRepository) findLikers(ctx context.Context, biz string, bizId int64, offset, limit int) ([]domain.UserLike, error) {
+	likes, err := c.dao.FindLikers(ctx, biz, bizId, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(likes, func(idx int, src dao2.UserLikeBiz) domain.UserLike {
+		return c.toUserLike(src)
+	}), nil
+}
+
 func (c *CachedInteractiveRepository) Collected(ctx context.Context, biz string, bizId int64, uid int64) (bool, error) {
 	_, err := c.dao.GetCollectInfo(ctx, biz, bizId, uid)
 	switch {
@@ -148,14 +508,22 @@ func (c *CachedInteractiveRepository) AddCollectionItem(ctx context.Context, biz
 	return c.cache.IncrCollectCntIfPresent(ctx, biz, bizId)
 }
 
+func (c *CachedInteractiveRepository) RemoveCollectionItem(ctx context.Context, biz string, bizId int64, uid int64) error {
+	err := c.dao.DeleteCollectionBiz(ctx, biz, bizId, uid)
+	if err != nil {
+		return err
+	}
+	return c.cache.DecrCollectCntIfPresent(ctx, biz, bizId)
+}
+
 func (c *CachedInteractiveRepository) IncrLike(ctx context.Context, biz string, id int64, uid int64) error {
 	err := c.dao.InsertLikeInfo(ctx, biz, id, uid)
 	if err != nil {
 		return err
 	}
-	err = c.top.IncrLike(ctx, id, 1)
+	err = c.cache.AddLikerIfPresent(ctx, biz, id, uid, time.Now())
 	if err != nil {
-		c.l.Error("增加点赞数失败", logger.Error(err))
+		c.l.Error("更新点赞人缓存失败", logger.Error(err))
 	}
 	return c.cache.IncrLikeCntIfPresent(ctx, biz, id)
 }
@@ -165,9 +533,10 @@ func (c *CachedInteractiveRepository) DecrLike(ctx context.Context, biz string,
 	if err != nil {
 		return err
 	}
-	err = c.top.IncrLike(ctx, id, -1)
+	// 删掉一个人之后缓存里面就不够一页了，直接删掉让下次查询重新加载
+	err = c.cache.DelLikers(ctx, biz, id)
 	if err != nil {
-		c.l.Error("减少点赞数失败", logger.Error(err))
+		c.l.Error("删除点赞人缓存失败", logger.Error(err))
 	}
 	return c.cache.DecrLikeCntIfPresent(ctx, biz, id)
 }
@@ -181,11 +550,39 @@ func (c *CachedInteractiveRepository) IncrReadCnt(ctx context.Context, biz strin
 	return c.cache.IncrReadCntIfPresent(ctx, biz, bizId)
 }
 
-func (c *CachedInteractiveRepository) toDomain(ie dao2.Interactive) domain.Interactive {
-	return domain.Interactive{
-		BizId:      ie.BizId,
-		ReadCnt:    ie.ReadCnt,
-		LikeCnt:    ie.LikeCnt,
-		CollectCnt: ie.CollectCnt,
+func (c *CachedInteractiveRepository) toDomain(ie dao2.Interactive, cnts []dao2.ReactionCnt) domain.Interactive {
+	res := domain.Interactive{
+		BizId:         ie.BizId,
+		ReadCnt:       ie.ReadCnt,
+		UniqueReadCnt: ie.UniqueReadCnt,
+		LikeCnt:       ie.LikeCnt,
+		CollectCnt:    ie.CollectCnt,
+		CommentCnt:    ie.CommentCnt,
+		Reactions:     make(map[string]int64, len(cnts)+1),
+	}
+	for _, cnt := range cnts {
+		res.Reactions[cnt.Reaction] = cnt.Cnt
+	}
+	c.addLikeReaction(&res)
+	return res
+}
+
+// addLikeReaction 👍 的数量就是点赞数，没有单独存
+func (c *CachedInteractiveRepository) addLikeReaction(intr *domain.Interactive) {
+	if intr.LikeCnt <= 0 {
+		return
+	}
+	if intr.Reactions == nil {
+		intr.Reactions = make(map[string]int64, 1)
+	}
+	intr.Reactions[domain.ReactionLike] = intr.LikeCnt
+}
+
+func (c *CachedInteractiveRepository) toUserLike(src dao2.UserLikeBiz) domain.UserLike {
+	return domain.UserLike{
+		Uid:   src.Uid,
+		Biz:   src.Biz,
+		BizId: src.BizId,
+		Ctime: time.UnixMilli(src.UTime),
 	}
 }
@@ -0,0 +1,51 @@
+// Copyright@daidai53 2024
+package repository
+
+import (
+	"context"
+	"github.com/daidai53/webook/interactive/domain"
+	cache2 "github.com/daidai53/webook/interactive/repository/cache"
+	cachemocks "github.com/daidai53/webook/interactive/repository/cache/mocks"
+	dao2 "github.com/daidai53/webook/interactive/repository/dao"
+	daomocks "github.com/daidai53/webook/interactive/repository/dao/mocks"
+	"github.com/daidai53/webook/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+)
+
+func TestCachedInteractiveRepository_ScanDrift(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	c := cachemocks.NewMockInteractiveCache(ctrl)
+	d := daomocks.NewMockInteractiveDAO(ctrl)
+	c.EXPECT().ScanKeys(gomock.Any(), uint64(0), int64(10)).Return([]cache2.BizKey{
+		{Biz: "article", BizId: 1},
+		{Biz: "article", BizId: 2},
+		{Biz: "article", BizId: 3},
+	}, uint64(5), nil)
+	// 1 一直对得上，2 第一次对不上，过一会就好了，3 一直对不上
+	c.EXPECT().Get(gomock.Any(), "article", int64(1)).Return(domain.Interactive{ReadCnt: 1}, nil)
+	d.EXPECT().Get(gomock.Any(), "article", int64(1)).Return(dao2.Interactive{ReadCnt: 1}, nil)
+	c.EXPECT().Get(gomock.Any(), "article", int64(2)).Return(domain.Interactive{ReadCnt: 2}, nil).Times(2)
+	gomock.InOrder(
+		d.EXPECT().Get(gomock.Any(), "article", int64(2)).Return(dao2.Interactive{ReadCnt: 1}, nil),
+		d.EXPECT().Get(gomock.Any(), "article", int64(2)).Return(dao2.Interactive{ReadCnt: 2}, nil),
+	)
+	c.EXPECT().Get(gomock.Any(), "article", int64(3)).Return(domain.Interactive{ReadCnt: 3}, nil).Times(2)
+	d.EXPECT().Get(gomock.Any(), "article", int64(3)).Return(dao2.Interactive{ReadCnt: 1}, nil).Times(2)
+
+	repo := NewCachedInteractiveRepository(d, c, nil, logger.NewNopLogger())
+	start := time.Now()
+	drifts, checked, next, err := repo.ScanDrift(context.Background(), 0, 10)
+	require.NoError(t, err)
+	// 对不上的一起只等一次
+	assert.Less(t, time.Since(start), 2*driftRecheckDelay)
+	assert.Equal(t, 3, checked)
+	assert.Equal(t, uint64(5), next)
+	assert.Equal(t, []domain.CntDrift{
+		{Biz: "article", BizId: 3, Field: FieldReadCnt, Cache: 3, DB: 1},
+	}, drifts)
+}
@@ -0,0 +1,392 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./interactive/repository/interactive.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./interactive/repository/interactive.go -package=repomocks -destination=./interactive/repository/mocks/interactive.mock.go
+//
+// Package repomocks is a generated GoMock package.
+package repomocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/daidai53/webook/interactive/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockInteractiveRepository is a mock of InteractiveRepository interface.
+type MockInteractiveRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockInteractiveRepositoryMockRecorder
+}
+
+// MockInteractiveRepositoryMockRecorder is the mock recorder for MockInteractiveRepository.
+type MockInteractiveRepositoryMockRecorder struct {
+	mock *MockInteractiveRepository
+}
+
+// NewMockInteractiveRepository creates a new mock instance.
+func NewMockInteractiveRepository(ctrl *gomock.Controller) *MockInteractiveRepository {
+	mock := &MockInteractiveRepository{ctrl: ctrl}
+	mock.recorder = &MockInteractiveRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInteractiveRepository) EXPECT() *MockInteractiveRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddCollectionItem mocks base method.
+func (m *MockInteractiveRepository) AddCollectionItem(ctx context.Context, biz string, id, uid, cid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddCollectionItem", ctx, biz, id, uid, cid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddCollectionItem indicates an expected call of AddCollectionItem.
+func (mr *MockInteractiveRepositoryMockRecorder) AddCollectionItem(ctx, biz, id, uid, cid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddCollectionItem", reflect.TypeOf((*MockInteractiveRepository)(nil).AddCollectionItem), ctx, biz, id, uid, cid)
+}
+
+// AddReaction mocks base method.
+func (m *MockInteractiveRepository) AddReaction(ctx context.Context, biz string, bizId, uid int64, reaction string, exclusive bool) ([]string, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddReaction", ctx, biz, bizId, uid, reaction, exclusive)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// AddReaction indicates an expected call of AddReaction.
+func (mr *MockInteractiveRepositoryMockRecorder) AddReaction(ctx, biz, bizId, uid, reaction, exclusive any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddReaction", reflect.TypeOf((*MockInteractiveRepository)(nil).AddReaction), ctx, biz, bizId, uid, reaction, exclusive)
+}
+
+// BatchGet mocks base method.
+func (m *MockInteractiveRepository) BatchGet(ctx context.Context, biz string, ids []int64) ([]domain.Interactive, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchGet", ctx, biz, ids)
+	ret0, _ := ret[0].([]domain.Interactive)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchGet indicates an expected call of BatchGet.
+func (mr *MockInteractiveRepositoryMockRecorder) BatchGet(ctx, biz, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchGet", reflect.TypeOf((*MockInteractiveRepository)(nil).BatchGet), ctx, biz, ids)
+}
+
+// BatchIncrReadCnt mocks base method.
+func (m *MockInteractiveRepository) BatchIncrReadCnt(ctx context.Context, biz []string, bizId, uid []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchIncrReadCnt", ctx, biz, bizId, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BatchIncrReadCnt indicates an expected call of BatchIncrReadCnt.
+func (mr *MockInteractiveRepositoryMockRecorder) BatchIncrReadCnt(ctx, biz, bizId, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchIncrReadCnt", reflect.TypeOf((*MockInteractiveRepository)(nil).BatchIncrReadCnt), ctx, biz, bizId, uid)
+}
+
+// Collected mocks base method.
+func (m *MockInteractiveRepository) Collected(ctx context.Context, biz string, bizId, uid int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Collected", ctx, biz, bizId, uid)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Collected indicates an expected call of Collected.
+func (mr *MockInteractiveRepositoryMockRecorder) Collected(ctx, biz, bizId, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Collected", reflect.TypeOf((*MockInteractiveRepository)(nil).Collected), ctx, biz, bizId, uid)
+}
+
+// CollectedIds mocks base method.
+func (m *MockInteractiveRepository) CollectedIds(ctx context.Context, biz string, ids []int64, uid int64) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CollectedIds", ctx, biz, ids, uid)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CollectedIds indicates an expected call of CollectedIds.
+func (mr *MockInteractiveRepositoryMockRecorder) CollectedIds(ctx, biz, ids, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CollectedIds", reflect.TypeOf((*MockInteractiveRepository)(nil).CollectedIds), ctx, biz, ids, uid)
+}
+
+// DecrLike mocks base method.
+func (m *MockInteractiveRepository) DecrLike(ctx context.Context, biz string, id, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DecrLike", ctx, biz, id, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DecrLike indicates an expected call of DecrLike.
+func (mr *MockInteractiveRepositoryMockRecorder) DecrLike(ctx, biz, id, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecrLike", reflect.TypeOf((*MockInteractiveRepository)(nil).DecrLike), ctx, biz, id, uid)
+}
+
+// Get mocks base method.
+func (m *MockInteractiveRepository) Get(ctx context.Context, biz string, bizId int64) (domain.Interactive, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, biz, bizId)
+	ret0, _ := ret[0].(domain.Interactive)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockInteractiveRepositoryMockRecorder) Get(ctx, biz, bizId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockInteractiveRepository)(nil).Get), ctx, biz, bizId)
+}
+
+// GetByIds mocks base method.
+func (m *MockInteractiveRepository) GetByIds(ctx context.Context, biz string, ids []int64) ([]domain.Interactive, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIds", ctx, biz, ids)
+	ret0, _ := ret[0].([]domain.Interactive)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIds indicates an expected call of GetByIds.
+func (mr *MockInteractiveRepositoryMockRecorder) GetByIds(ctx, biz, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIds", reflect.TypeOf((*MockInteractiveRepository)(nil).GetByIds), ctx, biz, ids)
+}
+
+// GetLikedItems mocks base method.
+func (m *MockInteractiveRepository) GetLikedItems(ctx context.Context, uid int64, biz string, offset, limit int) ([]domain.UserLike, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLikedItems", ctx, uid, biz, offset, limit)
+	ret0, _ := ret[0].([]domain.UserLike)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLikedItems indicates an expected call of GetLikedItems.
+func (mr *MockInteractiveRepositoryMockRecorder) GetLikedItems(ctx, uid, biz, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLikedItems", reflect.TypeOf((*MockInteractiveRepository)(nil).GetLikedItems), ctx, uid, biz, offset, limit)
+}
+
+// GetLikers mocks base method.
+func (m *MockInteractiveRepository) GetLikers(ctx context.Context, biz string, bizId int64, offset, limit int) ([]domain.UserLike, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLikers", ctx, biz, bizId, offset, limit)
+	ret0, _ := ret[0].([]domain.UserLike)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLikers indicates an expected call of GetLikers.
+func (mr *MockInteractiveRepositoryMockRecorder) GetLikers(ctx, biz, bizId, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLikers", reflect.TypeOf((*MockInteractiveRepository)(nil).GetLikers), ctx, biz, bizId, offset, limit)
+}
+
+// IncrLike mocks base method.
+func (m *MockInteractiveRepository) IncrLike(ctx context.Context, biz string, id, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrLike", ctx, biz, id, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncrLike indicates an expected call of IncrLike.
+func (mr *MockInteractiveRepositoryMockRecorder) IncrLike(ctx, biz, id, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrLike", reflect.TypeOf((*MockInteractiveRepository)(nil).IncrLike), ctx, biz, id, uid)
+}
+
+// IncrLikeRank mocks base method.
+func (m *MockInteractiveRepository) IncrLikeRank(ctx context.Context, deltas []domain.LikeDelta) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrLikeRank", ctx, deltas)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncrLikeRank indicates an expected call of IncrLikeRank.
+func (mr *MockInteractiveRepositoryMockRecorder) IncrLikeRank(ctx, deltas any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrLikeRank", reflect.TypeOf((*MockInteractiveRepository)(nil).IncrLikeRank), ctx, deltas)
+}
+
+// IncrReadCnt mocks base method.
+func (m *MockInteractiveRepository) IncrReadCnt(ctx context.Context, biz string, bizId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrReadCnt", ctx, biz, bizId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncrReadCnt indicates an expected call of IncrReadCnt.
+func (mr *MockInteractiveRepositoryMockRecorder) IncrReadCnt(ctx, biz, bizId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrReadCnt", reflect.TypeOf((*MockInteractiveRepository)(nil).IncrReadCnt), ctx, biz, bizId)
+}
+
+// Liked mocks base method.
+func (m *MockInteractiveRepository) Liked(ctx context.Context, biz string, bizId, uid int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Liked", ctx, biz, bizId, uid)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Liked indicates an expected call of Liked.
+func (mr *MockInteractiveRepositoryMockRecorder) Liked(ctx, biz, bizId, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Liked", reflect.TypeOf((*MockInteractiveRepository)(nil).Liked), ctx, biz, bizId, uid)
+}
+
+// LikedIds mocks base method.
+func (m *MockInteractiveRepository) LikedIds(ctx context.Context, biz string, ids []int64, uid int64) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LikedIds", ctx, biz, ids, uid)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LikedIds indicates an expected call of LikedIds.
+func (mr *MockInteractiveRepositoryMockRecorder) LikedIds(ctx, biz, ids, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LikedIds", reflect.TypeOf((*MockInteractiveRepository)(nil).LikedIds), ctx, biz, ids, uid)
+}
+
+// RebuildLeaderboard mocks base method.
+func (m *MockInteractiveRepository) RebuildLeaderboard(ctx context.Context, biz string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RebuildLeaderboard", ctx, biz)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RebuildLeaderboard indicates an expected call of RebuildLeaderboard.
+func (mr *MockInteractiveRepositoryMockRecorder) RebuildLeaderboard(ctx, biz any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RebuildLeaderboard", reflect.TypeOf((*MockInteractiveRepository)(nil).RebuildLeaderboard), ctx, biz)
+}
+
+// RemoveCollectionItem mocks base method.
+func (m *MockInteractiveRepository) RemoveCollectionItem(ctx context.Context, biz string, id, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveCollectionItem", ctx, biz, id, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveCollectionItem indicates an expected call of RemoveCollectionItem.
+func (mr *MockInteractiveRepositoryMockRecorder) RemoveCollectionItem(ctx, biz, id, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveCollectionItem", reflect.TypeOf((*MockInteractiveRepository)(nil).RemoveCollectionItem), ctx, biz, id, uid)
+}
+
+// RemoveReaction mocks base method.
+func (m *MockInteractiveRepository) RemoveReaction(ctx context.Context, biz string, bizId, uid int64, reaction string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveReaction", ctx, biz, bizId, uid, reaction)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveReaction indicates an expected call of RemoveReaction.
+func (mr *MockInteractiveRepositoryMockRecorder) RemoveReaction(ctx, biz, bizId, uid, reaction any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveReaction", reflect.TypeOf((*MockInteractiveRepository)(nil).RemoveReaction), ctx, biz, bizId, uid, reaction)
+}
+
+// RepairCache mocks base method.
+func (m *MockInteractiveRepository) RepairCache(ctx context.Context, biz string, bizId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RepairCache", ctx, biz, bizId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RepairCache indicates an expected call of RepairCache.
+func (mr *MockInteractiveRepositoryMockRecorder) RepairCache(ctx, biz, bizId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RepairCache", reflect.TypeOf((*MockInteractiveRepository)(nil).RepairCache), ctx, biz, bizId)
+}
+
+// ScanDrift mocks base method.
+func (m *MockInteractiveRepository) ScanDrift(ctx context.Context, cursor uint64, count int) ([]domain.CntDrift, int, uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScanDrift", ctx, cursor, count)
+	ret0, _ := ret[0].([]domain.CntDrift)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(uint64)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
+}
+
+// ScanDrift indicates an expected call of ScanDrift.
+func (mr *MockInteractiveRepositoryMockRecorder) ScanDrift(ctx, cursor, count any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScanDrift", reflect.TypeOf((*MockInteractiveRepository)(nil).ScanDrift), ctx, cursor, count)
+}
+
+// SetCommentCnt mocks base method.
+func (m *MockInteractiveRepository) SetCommentCnt(ctx context.Context, biz string, bizId, cnt int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCommentCnt", ctx, biz, bizId, cnt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetCommentCnt indicates an expected call of SetCommentCnt.
+func (mr *MockInteractiveRepositoryMockRecorder) SetCommentCnt(ctx, biz, bizId, cnt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCommentCnt", reflect.TypeOf((*MockInteractiveRepository)(nil).SetCommentCnt), ctx, biz, bizId, cnt)
+}
+
+// TopN mocks base method.
+func (m *MockInteractiveRepository) TopN(ctx context.Context, biz string, window domain.Window, n int) ([]domain.LikeRank, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TopN", ctx, biz, window, n)
+	ret0, _ := ret[0].([]domain.LikeRank)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TopN indicates an expected call of TopN.
+func (mr *MockInteractiveRepositoryMockRecorder) TopN(ctx, biz, window, n any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TopN", reflect.TypeOf((*MockInteractiveRepository)(nil).TopN), ctx, biz, window, n)
+}
+
+// UserReactions mocks base method.
+func (m *MockInteractiveRepository) UserReactions(ctx context.Context, biz string, bizId, uid int64) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserReactions", ctx, biz, bizId, uid)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UserReactions indicates an expected call of UserReactions.
+func (mr *MockInteractiveRepositoryMockRecorder) UserReactions(ctx, biz, bizId, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserReactions", reflect.TypeOf((*MockInteractiveRepository)(nil).UserReactions), ctx, biz, bizId, uid)
+}
@@ -0,0 +1,110 @@
+// Copyright@daidai53 2024
+package repository
+
+import (
+	"context"
+	"github.com/daidai53/webook/interactive/domain"
+	dao2 "github.com/daidai53/webook/interactive/repository/dao"
+	"github.com/ecodeclub/ekit/slice"
+	"time"
+)
+
+const dayLayout = "2006-01-02"
+
+type StatsRepository interface {
+	// FindChanged 按照 id 分批查 since 之后变过的，返回下一批的起点
+	FindChanged(ctx context.Context, since time.Time, minId int64, limit int) ([]domain.Interactive, int64, error)
+	// FindLatest 每个东西在 before 那一天之前最近的一次快照
+	FindLatest(ctx context.Context, bizIds []int64, before time.Time) ([]domain.DailyStat, error)
+	Save(ctx context.Context, stats []domain.DailyStat) error
+	Find(ctx context.Context, biz string, bizIds []int64, start, end time.Time) ([]domain.DailyStat, error)
+}
+
+type statsRepository struct {
+	dao dao2.StatsDAO
+}
+
+func NewStatsRepository(dao dao2.StatsDAO) StatsRepository {
+	return &statsRepository{
+		dao: dao,
+	}
+}
+
+func (s *statsRepository) FindChanged(ctx context.Context, since time.Time, minId int64, limit int) ([]domain.Interactive, int64, error) {
+	intrs, err := s.dao.FindChanged(ctx, since.UnixMilli(), minId, limit)
+	if err != nil || len(intrs) == 0 {
+		return nil, minId, err
+	}
+	return slice.Map(intrs, func(idx int, src dao2.Interactive) domain.Interactive {
+		return domain.Interactive{
+			Biz:           src.Biz,
+			BizId:         src.BizId,
+			ReadCnt:       src.ReadCnt,
+			UniqueReadCnt: src.UniqueReadCnt,
+			LikeCnt:       src.LikeCnt,
+			CollectCnt:    src.CollectCnt,
+			Ctime:         time.UnixMilli(src.CTime),
+		}
+	}), intrs[len(intrs)-1].Id, nil
+}
+
+func (s *statsRepository) FindLatest(ctx context.Context, bizIds []int64, before time.Time) ([]domain.DailyStat, error) {
+	stats, err := s.dao.FindLatest(ctx, bizIds, before.Format(dayLayout))
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(stats, func(idx int, src dao2.InteractiveDailyStat) domain.DailyStat {
+		return s.toDomain(src)
+	}), nil
+}
+
+func (s *statsRepository) Save(ctx context.Context, stats []domain.DailyStat) error {
+	now := time.Now().UnixMilli()
+	return s.dao.Upsert(ctx, slice.Map(stats, func(idx int, src domain.DailyStat) dao2.InteractiveDailyStat {
+		return dao2.InteractiveDailyStat{
+			Biz:                src.Biz,
+			BizId:              src.BizId,
+			Day:                src.Day.Format(dayLayout),
+			ReadCnt:            src.ReadCnt,
+			UniqueReadCnt:      src.UniqueReadCnt,
+			LikeCnt:            src.LikeCnt,
+			CollectCnt:         src.CollectCnt,
+			TotalReadCnt:       src.Total.ReadCnt,
+			TotalUniqueReadCnt: src.Total.UniqueReadCnt,
+			TotalLikeCnt:       src.Total.LikeCnt,
+			TotalCollectCnt:    src.Total.CollectCnt,
+			CTime:              now,
+		}
+	}))
+}
+
+func (s *statsRepository) Find(ctx context.Context, biz string, bizIds []int64, start, end time.Time) ([]domain.DailyStat, error) {
+	stats, err := s.dao.Find(ctx, biz, bizIds, start.Format(dayLayout), end.Format(dayLayout))
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(stats, func(idx int, src dao2.InteractiveDailyStat) domain.DailyStat {
+		return s.toDomain(src)
+	}), nil
+}
+
+func (s *statsRepository) toDomain(src dao2.InteractiveDailyStat) domain.DailyStat {
+	day, _ := time.ParseInLocation(dayLayout, src.Day, time.Local)
+	return domain.DailyStat{
+		Biz:           src.Biz,
+		BizId:         src.BizId,
+		Day:           day,
+		ReadCnt:       src.ReadCnt,
+		UniqueReadCnt: src.UniqueReadCnt,
+		LikeCnt:       src.LikeCnt,
+		CollectCnt:    src.CollectCnt,
+		Total: domain.Interactive{
+			Biz:           src.Biz,
+			BizId:         src.BizId,
+			ReadCnt:       src.TotalReadCnt,
+			UniqueReadCnt: src.TotalUniqueReadCnt,
+			LikeCnt:       src.TotalLikeCnt,
+			CollectCnt:    src.TotalCollectCnt,
+		},
+	}
+}
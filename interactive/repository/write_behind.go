@@ -0,0 +1,280 @@
+// Copyright@daidai53 2024
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/daidai53/webook/interactive/domain"
+	cache2 "github.com/daidai53/webook/interactive/repository/cache"
+	dao2 "github.com/daidai53/webook/interactive/repository/dao"
+	"github.com/daidai53/webook/pkg/logger"
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/ecodeclub/ekit/syncx/atomicx"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"time"
+)
+
+// WriteBehindInteractiveRepository 开启了 write-behind 的 biz，点赞数和阅读数不再每次都去更新 interactive 表，
+// 而是把增量攒在 Redis 里面，由 Flush 定时整批刷到数据库，避免热门文章的行锁竞争。
+// 缓存里面的计数还是实时更新的，数据库会落后最多一个刷新周期。
+// 没有开启的 biz 还是走原来的 InteractiveRepository。
+type WriteBehindInteractiveRepository struct {
+	InteractiveRepository
+	dao       dao2.InteractiveDAO
+	cache     cache2.InteractiveCache
+	deltas    cache2.DeltaCache
+	bizs      *atomicx.Value[map[string]struct{}]
+	batchSize int
+	l         logger.LoggerV1
+}
+
+func NewWriteBehindInteractiveRepository(repo InteractiveRepository, dao dao2.InteractiveDAO,
+	cache cache2.InteractiveCache, deltas cache2.DeltaCache,
+	bizs []string, batchSize int, l logger.LoggerV1) *WriteBehindInteractiveRepository {
+	ret := &WriteBehindInteractiveRepository{
+		InteractiveRepository: repo,
+		dao:                   dao,
+		cache:                 cache,
+		deltas:                deltas,
+		bizs:                  atomicx.NewValue[map[string]struct{}](),
+		batchSize:             batchSize,
+		l:                     l,
+	}
+	ret.UpdateBizs(bizs)
+	return ret
+}
+
+// UpdateBizs 修改开启 write-behind 的 biz，配置变更的时候调用
+func (w *WriteBehindInteractiveRepository) UpdateBizs(bizs []string) {
+	m := make(map[string]struct{}, len(bizs))
+	for _, biz := range bizs {
+		m[biz] = struct{}{}
+	}
+	w.bizs.Store(m)
+}
+
+func (w *WriteBehindInteractiveRepository) enabled(biz string) bool {
+	_, ok := w.bizs.Load()[biz]
+	return ok
+}
+
+func (w *WriteBehindInteractiveRepository) IncrReadCnt(ctx context.Context, biz string, bizId int64) error {
+	if !w.enabled(biz) {
+		return w.InteractiveRepository.IncrReadCnt(ctx, biz, bizId)
+	}
+	err := w.deltas.IncrReadCnt(ctx, biz, bizId, false)
+	if err != nil {
+		return err
+	}
+	return w.cache.IncrReadCntIfPresent(ctx, biz, bizId)
+}
+
+func (w *WriteBehindInteractiveRepository) BatchIncrReadCnt(ctx context.Context, biz []string, bizId []int64, uid []int64) error {
+	var (
+		wbBiz, fallbackBiz     []string
+		wbBizId, fallbackBizId []int64
+		wbUid, fallbackUid     []int64
+	)
+	for i := range biz {
+		if w.enabled(biz[i]) {
+			wbBiz, wbBizId, wbUid = append(wbBiz, biz[i]), append(wbBizId, bizId[i]), append(wbUid, uid[i])
+		} else {
+			fallbackBiz, fallbackBizId, fallbackUid = append(fallbackBiz, biz[i]), append(fallbackBizId, bizId[i]), append(fallbackUid, uid[i])
+		}
+	}
+	if len(fallbackBiz) > 0 {
+		err := w.InteractiveRepository.BatchIncrReadCnt(ctx, fallbackBiz, fallbackBizId, fallbackUid)
+		if err != nil {
+			return err
+		}
+	}
+	if len(wbBiz) == 0 {
+		return nil
+	}
+	unique, err := w.cache.AddReaders(ctx, wbBiz, wbBizId, wbUid)
+	if err != nil {
+		// 和 CachedInteractiveRepository 一样，宁可少算
+		w.l.Error("记录阅读的人失败", logger.Error(err))
+		unique = make([]bool, len(wbBiz))
+	}
+	for i := range wbBiz {
+		uq := unique[i] && wbUid[i] > 0
+		err = w.deltas.IncrReadCnt(ctx, wbBiz[i], wbBizId[i], uq)
+		if err != nil {
+			return err
+		}
+		er := w.cache.IncrReadCntIfPresent(ctx, wbBiz[i], wbBizId[i])
+		if er != nil {
+			w.l.Error("更新缓存阅读数失败", logger.Error(er))
+		}
+		if !uq {
+			continue
+		}
+		er = w.cache.IncrUniqueReadCntIfPresent(ctx, wbBiz[i], wbBizId[i])
+		if er != nil {
+			w.l.Error("更新缓存去重阅读数失败", logger.Error(er))
+		}
+	}
+	return nil
+}
+
+func (w *WriteBehindInteractiveRepository) IncrLike(ctx context.Context, biz string, id int64, uid int64) error {
+	if !w.enabled(biz) {
+		return w.InteractiveRepository.IncrLike(ctx, biz, id, uid)
+	}
+	err := w.dao.InsertLikeRecord(ctx, biz, id, uid)
+	if err != nil {
+		return err
+	}
+	// 部分失败问题-点赞记录有了但是计数没加上
+	err = w.deltas.IncrLikeCnt(ctx, biz, id, 1)
+	if err != nil {
+		return err
+	}
+	err = w.cache.AddLikerIfPresent(ctx, biz, id, uid, time.Now())
+	if err != nil {
+		w.l.Error("更新点赞人缓存失败", logger.Error(err))
+	}
+	return w.cache.IncrLikeCntIfPresent(ctx, biz, id)
+}
+
+func (w *WriteBehindInteractiveRepository) DecrLike(ctx context.Context, biz string, id int64, uid int64) error {
+	if !w.enabled(biz) {
+		return w.InteractiveRepository.DecrLike(ctx, biz, id, uid)
+	}
+	err := w.dao.DeleteLikeRecord(ctx, biz, id, uid)
+	if err != nil {
+		return err
+	}
+	err = w.deltas.IncrLikeCnt(ctx, biz, id, -1)
+	if err != nil {
+		return err
+	}
+	err = w.cache.DelLikers(ctx, biz, id)
+	if err != nil {
+		w.l.Error("删除点赞人缓存失败", logger.Error(err))
+	}
+	return w.cache.DecrLikeCntIfPresent(ctx, biz, id)
+}
+
+// AddReaction 点赞也是走表情的，👍 的点赞数和 IncrLike 一样攒在 Redis 里面，别的表情的数量还是直接写数据库
+func (w *WriteBehindInteractiveRepository) AddReaction(ctx context.Context, biz string, bizId int64, uid int64,
+	reaction string, exclusive bool) ([]string, bool, error) {
+	if !w.enabled(biz) {
+		return w.InteractiveRepository.AddReaction(ctx, biz, bizId, uid, reaction, exclusive)
+	}
+	removed, err := w.dao.InsertReactionRecord(ctx, biz, bizId, uid, reaction, exclusive)
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	for _, re := range removed {
+		err = w.reactionRemoved(ctx, biz, bizId, re)
+		if err != nil {
+			return removed, true, err
+		}
+	}
+	if reaction != dao2.ReactionLike {
+		return removed, true, w.cache.IncrReactionCntIfPresent(ctx, biz, bizId, reaction, 1)
+	}
+	// 部分失败问题-点赞记录有了但是计数没加上
+	err = w.deltas.IncrLikeCnt(ctx, biz, bizId, 1)
+	if err != nil {
+		return removed, true, err
+	}
+	err = w.cache.AddLikerIfPresent(ctx, biz, bizId, uid, time.Now())
+	if err != nil {
+		w.l.Error("更新点赞人缓存失败", logger.Error(err))
+	}
+	return removed, true, w.cache.IncrLikeCntIfPresent(ctx, biz, bizId)
+}
+
+func (w *WriteBehindInteractiveRepository) RemoveReaction(ctx context.Context, biz string, bizId int64, uid int64, reaction string) error {
+	if !w.enabled(biz) {
+		return w.InteractiveRepository.RemoveReaction(ctx, biz, bizId, uid, reaction)
+	}
+	err := w.dao.DeleteReactionRecord(ctx, biz, bizId, uid, reaction)
+	if err != nil {
+		return err
+	}
+	return w.reactionRemoved(ctx, biz, bizId, reaction)
+}
+
+// reactionRemoved 数据库里面撤掉了一个表情之后，👍 记一个减一的增量，再更新缓存
+func (w *WriteBehindInteractiveRepository) reactionRemoved(ctx context.Context, biz string, bizId int64, reaction string) error {
+	if reaction != dao2.ReactionLike {
+		return w.cache.IncrReactionCntIfPresent(ctx, biz, bizId, reaction, -1)
+	}
+	err := w.deltas.IncrLikeCnt(ctx, biz, bizId, -1)
+	if err != nil {
+		return err
+	}
+	err = w.cache.DelLikers(ctx, biz, bizId)
+	if err != nil {
+		w.l.Error("删除点赞人缓存失败", logger.Error(err))
+	}
+	return w.cache.DecrLikeCntIfPresent(ctx, biz, bizId)
+}
+
+// ScanDrift 开启了 write-behind 的 biz，阅读数和点赞数本来就是数据库落后于缓存，不算对不上
+func (w *WriteBehindInteractiveRepository) ScanDrift(ctx context.Context, cursor uint64, count int) ([]domain.CntDrift, int, uint64, error) {
+	drifts, checked, next, err := w.InteractiveRepository.ScanDrift(ctx, cursor, count)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	res := drifts[:0]
+	for _, d := range drifts {
+		// 收藏数和评论数不是 write-behind 的，缓存里面的就是准的
+		if w.enabled(d.Biz) && d.Field != FieldCollectCnt && d.Field != FieldCommentCnt {
+			continue
+		}
+		res = append(res, d)
+	}
+	return res, checked, next, nil
+}
+
+// Flush 把攒下来的增量刷到数据库。
+// 上一次没刷完（比如刷到一半进程挂了）的批次会先刷，数据库那边按批次去重，所以重刷不会多加。
+// 关掉 write-behind 之后也要继续调用，把剩下的增量刷完。
+func (w *WriteBehindInteractiveRepository) Flush(ctx context.Context) error {
+	batch := uuid.New().String()
+	_, err := w.deltas.Seal(ctx, batch)
+	if err != nil {
+		return err
+	}
+	// 刚封好的批次也在里面
+	batches, err := w.deltas.PendingBatches(ctx)
+	if err != nil {
+		return err
+	}
+	for _, b := range batches {
+		err = w.flushBatch(ctx, b)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *WriteBehindInteractiveRepository) flushBatch(ctx context.Context, batch string) error {
+	deltas, err := w.deltas.GetBatch(ctx, batch)
+	if err != nil {
+		return err
+	}
+	// GetBatch 的顺序是固定的，所以重刷的时候每一段的内容和编号都对得上
+	for i := 0; i < len(deltas); i += w.batchSize {
+		seg := deltas[i:min(i+w.batchSize, len(deltas))]
+		err = w.dao.ApplyDeltas(ctx, fmt.Sprintf("%s:%d", batch, i/w.batchSize),
+			slice.Map(seg, func(idx int, src cache2.CntDelta) dao2.CntDelta {
+				return dao2.CntDelta(src)
+			}))
+		if err != nil {
+			return err
+		}
+	}
+	return w.deltas.DelBatch(ctx, batch)
+}
@@ -0,0 +1,168 @@
+// Copyright@daidai53 2024
+package repository
+
+import (
+	"context"
+	"errors"
+	cache2 "github.com/daidai53/webook/interactive/repository/cache"
+	cachemocks "github.com/daidai53/webook/interactive/repository/cache/mocks"
+	dao2 "github.com/daidai53/webook/interactive/repository/dao"
+	daomocks "github.com/daidai53/webook/interactive/repository/dao/mocks"
+	repomocks "github.com/daidai53/webook/interactive/repository/mocks"
+	"github.com/daidai53/webook/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+)
+
+func TestWriteBehindInteractiveRepository_Flush(t *testing.T) {
+	deltas := []cache2.CntDelta{
+		{Biz: "article", BizId: 1, ReadCnt: 3},
+		{Biz: "article", BizId: 2, LikeCnt: 1},
+		{Biz: "article", BizId: 3, ReadCnt: 1, UniqueReadCnt: 1},
+	}
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) (dao2.InteractiveDAO, cache2.DeltaCache)
+
+		wantErr error
+	}{
+		{
+			name: "按照 batchSize 分段刷，每段用 批次:段号 去重",
+			mock: func(ctrl *gomock.Controller) (dao2.InteractiveDAO, cache2.DeltaCache) {
+				d := daomocks.NewMockInteractiveDAO(ctrl)
+				c := cachemocks.NewMockDeltaCache(ctrl)
+				c.EXPECT().Seal(gomock.Any(), gomock.Any()).Return(true, nil)
+				c.EXPECT().PendingBatches(gomock.Any()).Return([]string{"b1"}, nil)
+				c.EXPECT().GetBatch(gomock.Any(), "b1").Return(deltas, nil)
+				d.EXPECT().ApplyDeltas(gomock.Any(), "b1:0", []dao2.CntDelta{
+					{Biz: "article", BizId: 1, ReadCnt: 3},
+					{Biz: "article", BizId: 2, LikeCnt: 1},
+				}).Return(nil)
+				d.EXPECT().ApplyDeltas(gomock.Any(), "b1:1", []dao2.CntDelta{
+					{Biz: "article", BizId: 3, ReadCnt: 1, UniqueReadCnt: 1},
+				}).Return(nil)
+				c.EXPECT().DelBatch(gomock.Any(), "b1").Return(nil)
+				return d, c
+			},
+		},
+		{
+			name: "刷到一半失败，批次留着下次重刷",
+			mock: func(ctrl *gomock.Controller) (dao2.InteractiveDAO, cache2.DeltaCache) {
+				d := daomocks.NewMockInteractiveDAO(ctrl)
+				c := cachemocks.NewMockDeltaCache(ctrl)
+				c.EXPECT().Seal(gomock.Any(), gomock.Any()).Return(true, nil)
+				c.EXPECT().PendingBatches(gomock.Any()).Return([]string{"b1"}, nil)
+				c.EXPECT().GetBatch(gomock.Any(), "b1").Return(deltas, nil)
+				d.EXPECT().ApplyDeltas(gomock.Any(), "b1:0", gomock.Any()).Return(nil)
+				d.EXPECT().ApplyDeltas(gomock.Any(), "b1:1", gomock.Any()).Return(errors.New("mock db error"))
+				return d, c
+			},
+			wantErr: errors.New("mock db error"),
+		},
+		{
+			name: "重刷上次没刷完的批次，每段的编号和内容都和上次一样",
+			mock: func(ctrl *gomock.Controller) (dao2.InteractiveDAO, cache2.DeltaCache) {
+				d := daomocks.NewMockInteractiveDAO(ctrl)
+				c := cachemocks.NewMockDeltaCache(ctrl)
+				c.EXPECT().Seal(gomock.Any(), gomock.Any()).Return(false, nil)
+				c.EXPECT().PendingBatches(gomock.Any()).Return([]string{"b1"}, nil)
+				c.EXPECT().GetBatch(gomock.Any(), "b1").Return(deltas, nil)
+				// 第一段上次已经刷过了，数据库那边按照 b1:0 去重，不会重复加
+				d.EXPECT().ApplyDeltas(gomock.Any(), "b1:0", []dao2.CntDelta{
+					{Biz: "article", BizId: 1, ReadCnt: 3},
+					{Biz: "article", BizId: 2, LikeCnt: 1},
+				}).Return(nil)
+				d.EXPECT().ApplyDeltas(gomock.Any(), "b1:1", []dao2.CntDelta{
+					{Biz: "article", BizId: 3, ReadCnt: 1, UniqueReadCnt: 1},
+				}).Return(nil)
+				c.EXPECT().DelBatch(gomock.Any(), "b1").Return(nil)
+				return d, c
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			d, c := tc.mock(ctrl)
+			repo := NewWriteBehindInteractiveRepository(nil, d, nil, c, []string{"article"}, 2, logger.NewNopLogger())
+			err := repo.Flush(context.Background())
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
+
+func TestWriteBehindInteractiveRepository_AddReaction(t *testing.T) {
+	testCases := []struct {
+		name     string
+		mock     func(ctrl *gomock.Controller) (InteractiveRepository, dao2.InteractiveDAO, cache2.InteractiveCache, cache2.DeltaCache)
+		biz      string
+		reaction string
+
+		wantRemoved []string
+		wantAdded   bool
+	}{
+		{
+			name:     "点赞不写数据库里面的点赞数，攒到增量里面",
+			biz:      "article",
+			reaction: dao2.ReactionLike,
+			mock: func(ctrl *gomock.Controller) (InteractiveRepository, dao2.InteractiveDAO, cache2.InteractiveCache, cache2.DeltaCache) {
+				d := daomocks.NewMockInteractiveDAO(ctrl)
+				c := cachemocks.NewMockInteractiveCache(ctrl)
+				deltas := cachemocks.NewMockDeltaCache(ctrl)
+				d.EXPECT().InsertReactionRecord(gomock.Any(), "article", int64(1), int64(123), dao2.ReactionLike, true).
+					Return(nil, nil)
+				deltas.EXPECT().IncrLikeCnt(gomock.Any(), "article", int64(1), int64(1)).Return(nil)
+				c.EXPECT().AddLikerIfPresent(gomock.Any(), "article", int64(1), int64(123), gomock.Any()).Return(nil)
+				c.EXPECT().IncrLikeCntIfPresent(gomock.Any(), "article", int64(1)).Return(nil)
+				return nil, d, c, deltas
+			},
+			wantAdded: true,
+		},
+		{
+			name:     "点赞换成别的表情，点赞数记一个减一的增量",
+			biz:      "article",
+			reaction: "heart",
+			mock: func(ctrl *gomock.Controller) (InteractiveRepository, dao2.InteractiveDAO, cache2.InteractiveCache, cache2.DeltaCache) {
+				d := daomocks.NewMockInteractiveDAO(ctrl)
+				c := cachemocks.NewMockInteractiveCache(ctrl)
+				deltas := cachemocks.NewMockDeltaCache(ctrl)
+				d.EXPECT().InsertReactionRecord(gomock.Any(), "article", int64(1), int64(123), "heart", true).
+					Return([]string{dao2.ReactionLike}, nil)
+				deltas.EXPECT().IncrLikeCnt(gomock.Any(), "article", int64(1), int64(-1)).Return(nil)
+				c.EXPECT().DelLikers(gomock.Any(), "article", int64(1)).Return(nil)
+				c.EXPECT().DecrLikeCntIfPresent(gomock.Any(), "article", int64(1)).Return(nil)
+				c.EXPECT().IncrReactionCntIfPresent(gomock.Any(), "article", int64(1), "heart", int64(1)).Return(nil)
+				return nil, d, c, deltas
+			},
+			wantRemoved: []string{dao2.ReactionLike},
+			wantAdded:   true,
+		},
+		{
+			name:     "没有开启 write-behind 的 biz 还是直接写数据库",
+			biz:      "comment",
+			reaction: dao2.ReactionLike,
+			mock: func(ctrl *gomock.Controller) (InteractiveRepository, dao2.InteractiveDAO, cache2.InteractiveCache, cache2.DeltaCache) {
+				repo := repomocks.NewMockInteractiveRepository(ctrl)
+				repo.EXPECT().AddReaction(gomock.Any(), "comment", int64(1), int64(123), dao2.ReactionLike, true).
+					Return(nil, true, nil)
+				return repo, daomocks.NewMockInteractiveDAO(ctrl), cachemocks.NewMockInteractiveCache(ctrl),
+					cachemocks.NewMockDeltaCache(ctrl)
+			},
+			wantAdded: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo, d, c, deltas := tc.mock(ctrl)
+			wb := NewWriteBehindInteractiveRepository(repo, d, c, deltas, []string{"article"}, 2, logger.NewNopLogger())
+			removed, added, err := wb.AddReaction(context.Background(), tc.biz, 1, 123, tc.reaction, true)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantRemoved, removed)
+			assert.Equal(t, tc.wantAdded, added)
+		})
+	}
+}
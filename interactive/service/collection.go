@@ -0,0 +1,135 @@
+// Copyright@daidai53 2024
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/daidai53/webook/interactive/domain"
+	"github.com/daidai53/webook/interactive/repository"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	ErrCollectionNotFound    = errors.New("收藏夹不存在")
+	ErrInvalidCollectionName = errors.New("收藏夹名字不合法")
+)
+
+const maxCollectionNameLen = 64
+
+type CollectionService interface {
+	Create(ctx context.Context, c domain.Collection) (int64, error)
+	// Update 改名字、描述和是否公开
+	Update(ctx context.Context, c domain.Collection) error
+	// Delete 删除收藏夹，里面收藏的东西回到默认收藏夹
+	Delete(ctx context.Context, uid, cid int64) error
+	// List viewer 查看 uid 的收藏夹，不是自己的只能看到公开的
+	List(ctx context.Context, uid, viewer int64, offset, limit int) ([]domain.Collection, error)
+	// ListItems viewer 查看 uid 的 cid 收藏夹里面的东西，cid 为 0 是默认收藏夹，只有自己能看
+	ListItems(ctx context.Context, uid, cid, viewer int64, offset, limit int) ([]domain.CollectionItem, error)
+	// MoveItems 把收藏的东西挪到 cid 收藏夹
+	MoveItems(ctx context.Context, uid int64, biz string, bizIds []int64, cid int64) error
+}
+
+type collectionService struct {
+	repo repository.CollectionRepository
+}
+
+func NewCollectionService(repo repository.CollectionRepository) CollectionService {
+	return &collectionService{
+		repo: repo,
+	}
+}
+
+func (c *collectionService) Create(ctx context.Context, col domain.Collection) (int64, error) {
+	name, err := c.checkName(col.Name)
+	if err != nil {
+		return 0, err
+	}
+	col.Name = name
+	return c.repo.Create(ctx, col)
+}
+
+func (c *collectionService) Update(ctx context.Context, col domain.Collection) error {
+	name, err := c.checkName(col.Name)
+	if err != nil {
+		return err
+	}
+	col.Name = name
+	err = c.repo.Update(ctx, col)
+	if errors.Is(err, repository.ErrRecordNotFound) {
+		return ErrCollectionNotFound
+	}
+	return err
+}
+
+func (c *collectionService) Delete(ctx context.Context, uid, cid int64) error {
+	if cid == 0 {
+		// 默认收藏夹不能删
+		return ErrCollectionNotFound
+	}
+	err := c.repo.Delete(ctx, uid, cid)
+	if errors.Is(err, repository.ErrRecordNotFound) {
+		return ErrCollectionNotFound
+	}
+	return err
+}
+
+func (c *collectionService) List(ctx context.Context, uid, viewer int64, offset, limit int) ([]domain.Collection, error) {
+	return c.repo.FindByUid(ctx, uid, uid != viewer, offset, limit)
+}
+
+func (c *collectionService) ListItems(ctx context.Context, uid, cid, viewer int64, offset, limit int) ([]domain.CollectionItem, error) {
+	if cid == 0 {
+		if uid != viewer {
+			return nil, ErrCollectionNotFound
+		}
+		return c.repo.FindItems(ctx, uid, cid, offset, limit)
+	}
+	col, err := findOwnedCollection(ctx, c.repo, uid, cid)
+	if err != nil {
+		return nil, err
+	}
+	// 别人的私密收藏夹，当作不存在，不暴露出去
+	if !col.Public && uid != viewer {
+		return nil, ErrCollectionNotFound
+	}
+	return c.repo.FindItems(ctx, uid, cid, offset, limit)
+}
+
+func (c *collectionService) MoveItems(ctx context.Context, uid int64, biz string, bizIds []int64, cid int64) error {
+	if len(bizIds) == 0 {
+		return nil
+	}
+	if cid != 0 {
+		// 只能挪到自己的收藏夹
+		_, err := findOwnedCollection(ctx, c.repo, uid, cid)
+		if err != nil {
+			return err
+		}
+	}
+	return c.repo.MoveItems(ctx, uid, biz, bizIds, cid)
+}
+
+// findOwnedCollection 别人的收藏夹也当作不存在，收藏和挪到某个收藏夹之前都要查一下
+func findOwnedCollection(ctx context.Context, repo repository.CollectionRepository, uid, cid int64) (domain.Collection, error) {
+	col, err := repo.FindById(ctx, cid)
+	switch {
+	case errors.Is(err, repository.ErrRecordNotFound):
+		return domain.Collection{}, ErrCollectionNotFound
+	case err != nil:
+		return domain.Collection{}, err
+	case col.Uid != uid:
+		return domain.Collection{}, ErrCollectionNotFound
+	default:
+		return col, nil
+	}
+}
+
+func (c *collectionService) checkName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > maxCollectionNameLen {
+		return "", ErrInvalidCollectionName
+	}
+	return name, nil
+}
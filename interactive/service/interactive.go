@@ -3,6 +3,7 @@ package service
 
 import (
 	"context"
+	"errors"
 	"github.com/daidai53/webook/interactive/domain"
 	events2 "github.com/daidai53/webook/interactive/events"
 	"github.com/daidai53/webook/interactive/repository"
@@ -16,21 +17,44 @@ type InteractiveService interface {
 	IncrReadCnt(ctx context.Context, biz string, bizId int64) error
 	Like(ctx context.Context, biz string, id int64, uid int64) error
 	CancelLike(ctx context.Context, biz string, id int64, uid int64) error
+	// Collect cid 为 0 是默认收藏夹，cid 不是 uid 自己的收藏夹返回 ErrCollectionNotFound
 	Collect(ctx context.Context, biz string, bizId int64, uid int64, cid int64) error
+	// CancelCollect 取消收藏，没有收藏过也算成功
+	CancelCollect(ctx context.Context, biz string, bizId int64, uid int64) error
 	Get(ctx context.Context, biz string, bizId int64, uid int64) (domain.Interactive, error)
 	GetByIds(ctx context.Context, biz string, ids []int64) (map[int64]domain.Interactive, error)
+	// BatchGet 列表页用，计数和 uid 有没有点赞、收藏一次查出来。
+	// 没有任何互动的也会返回，计数都是 0，ids 最多 maxPageSize 个
+	BatchGet(ctx context.Context, biz string, ids []int64, uid int64) (map[int64]domain.Interactive, error)
+	// GetLikers 谁点赞了这个东西，最近点赞的在前面
+	GetLikers(ctx context.Context, biz string, bizId int64, offset, limit int) ([]domain.UserLike, error)
+	// GetLikedItems uid 点赞过的 biz 类的东西，最近点赞的在前面
+	GetLikedItems(ctx context.Context, uid int64, biz string, offset, limit int) ([]domain.UserLike, error)
+	// TopN 点赞排行榜，n 最多是 maxPageSize，不传按照 maxPageSize 算
+	TopN(ctx context.Context, biz string, window domain.Window, n int) ([]domain.LikeRank, error)
 }
 
+// maxPageSize 分页查询一次最多查多少条
+const maxPageSize = 100
+
+var ErrTooManyIds = errors.New("一次查询的数量太多")
+
 type interactiveService struct {
 	repo     repository.InteractiveRepository
+	colRepo  repository.CollectionRepository
 	producer events2.Producer
+	events   likeEvents
 	l        logger.LoggerV1
 }
 
-func NewInteractiveService(repo repository.InteractiveRepository, producer events2.Producer) InteractiveService {
+func NewInteractiveService(repo repository.InteractiveRepository, colRepo repository.CollectionRepository,
+	producer events2.Producer, l logger.LoggerV1) InteractiveService {
 	return &interactiveService{
 		repo:     repo,
+		colRepo:  colRepo,
 		producer: producer,
+		events:   likeEvents{producer: producer, l: l},
+		l:        l,
 	}
 }
 
@@ -46,6 +70,66 @@ func (i *interactiveService) GetByIds(ctx context.Context, biz string, ids []int
 	return res, nil
 }
 
+func (i *interactiveService) BatchGet(ctx context.Context, biz string, ids []int64, uid int64) (map[int64]domain.Interactive, error) {
+	if len(ids) > maxPageSize {
+		return nil, ErrTooManyIds
+	}
+	res := make(map[int64]domain.Interactive, len(ids))
+	if len(ids) == 0 {
+		return res, nil
+	}
+	var (
+		eg        errgroup.Group
+		intrs     []domain.Interactive
+		liked     []int64
+		collected []int64
+	)
+	eg.Go(func() error {
+		var er error
+		intrs, er = i.repo.BatchGet(ctx, biz, ids)
+		return er
+	})
+	eg.Go(func() error {
+		var er error
+		liked, er = i.repo.LikedIds(ctx, biz, ids, uid)
+		return er
+	})
+	eg.Go(func() error {
+		var er error
+		collected, er = i.repo.CollectedIds(ctx, biz, ids, uid)
+		return er
+	})
+	err := eg.Wait()
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range ids {
+		res[id] = domain.Interactive{BizId: id}
+	}
+	for _, intr := range intrs {
+		res[intr.BizId] = intr
+	}
+	for _, id := range liked {
+		intr := res[id]
+		intr.Liked = true
+		res[id] = intr
+	}
+	for _, id := range collected {
+		intr := res[id]
+		intr.Collected = true
+		res[id] = intr
+	}
+	return res, nil
+}
+
+func (i *interactiveService) GetLikers(ctx context.Context, biz string, bizId int64, offset, limit int) ([]domain.UserLike, error) {
+	return i.repo.GetLikers(ctx, biz, bizId, offset, min(limit, maxPageSize))
+}
+
+func (i *interactiveService) GetLikedItems(ctx context.Context, uid int64, biz string, offset, limit int) ([]domain.UserLike, error) {
+	return i.repo.GetLikedItems(ctx, uid, biz, offset, min(limit, maxPageSize))
+}
+
 func (i *interactiveService) Get(ctx context.Context, biz string, bizId int64, uid int64) (domain.Interactive, error) {
 	intr, err := i.repo.Get(ctx, biz, bizId)
 	if err != nil {
@@ -62,10 +146,31 @@ func (i *interactiveService) Get(ctx context.Context, biz string, bizId int64, u
 		intr.Collected, er = i.repo.Collected(ctx, biz, bizId, uid)
 		return er
 	})
-	return intr, eg.Wait()
+	var reactions []string
+	eg.Go(func() error {
+		var er error
+		reactions, er = i.repo.UserReactions(ctx, biz, bizId, uid)
+		return er
+	})
+	err = eg.Wait()
+	if err != nil {
+		return domain.Interactive{}, err
+	}
+	if intr.Liked {
+		intr.MyReactions = append(intr.MyReactions, domain.ReactionLike)
+	}
+	intr.MyReactions = append(intr.MyReactions, reactions...)
+	return intr, nil
 }
 
 func (i *interactiveService) Collect(ctx context.Context, biz string, bizId int64, uid int64, cid int64) error {
+	if cid != 0 {
+		// 只能收藏到自己的收藏夹
+		_, err := findOwnedCollection(ctx, i.colRepo, uid, cid)
+		if err != nil {
+			return err
+		}
+	}
 	err := i.repo.AddCollectionItem(ctx, biz, bizId, uid, cid)
 	if err != nil {
 		return err
@@ -90,33 +195,113 @@ func (i *interactiveService) Collect(ctx context.Context, biz string, bizId int6
 	return nil
 }
 
+func (i *interactiveService) CancelCollect(ctx context.Context, biz string, bizId int64, uid int64) error {
+	err := i.repo.RemoveCollectionItem(ctx, biz, bizId, uid)
+	if errors.Is(err, repository.ErrRecordNotFound) {
+		// 本来就没有收藏，计数也没有变，不需要发事件
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		err := i.producer.ProduceInteractiveEvent(ctx, events2.InteractiveEvent{
+			Uid:   uid,
+			Biz:   biz,
+			BizId: bizId,
+			Type:  events2.TypeCancelCollect,
+		})
+		if err != nil {
+			i.l.Error("发送取消收藏事件到Kafka失败",
+				logger.Error(err),
+				logger.Int64("uid", uid),
+				logger.String("biz", biz),
+				logger.Int64("biz_id", bizId))
+		}
+	}()
+	return nil
+}
+
 func (i *interactiveService) Like(ctx context.Context, biz string, id int64, uid int64) error {
 	err := i.repo.IncrLike(ctx, biz, id, uid)
 	if err != nil {
 		return err
 	}
+	i.events.liked(biz, id, uid)
+	return nil
+}
+
+func (i *interactiveService) CancelLike(ctx context.Context, biz string, id int64, uid int64) error {
+	err := i.repo.DecrLike(ctx, biz, id, uid)
+	if err != nil {
+		return err
+	}
+	i.events.unliked(biz, id, uid)
+	return nil
+}
+
+// likeEvents 点赞和取消点赞之后要发的事件，表情回应里面的 👍 也走这里
+type likeEvents struct {
+	producer events2.Producer
+	l        logger.LoggerV1
+}
+
+func (e likeEvents) liked(biz string, id int64, uid int64) {
 	go func() {
 		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 		defer cancel()
-		err := i.producer.ProduceInteractiveEvent(ctx, events2.InteractiveEvent{
+		err := e.producer.ProduceInteractiveEvent(ctx, events2.InteractiveEvent{
 			Uid:   uid,
 			Biz:   biz,
 			BizId: id,
 			Type:  events2.TypeLike,
 		})
 		if err != nil {
-			i.l.Error("发送点赞事件到Kafka失败",
+			e.l.Error("发送点赞事件到Kafka失败",
 				logger.Error(err),
 				logger.Int64("uid", uid),
 				logger.String("biz", biz),
 				logger.Int64("biz_id", id))
 		}
 	}()
-	return nil
+	e.produceLikeEvent(biz, id, uid, 1)
 }
 
-func (i *interactiveService) CancelLike(ctx context.Context, biz string, id int64, uid int64) error {
-	return i.repo.DecrLike(ctx, biz, id, uid)
+func (e likeEvents) unliked(biz string, id int64, uid int64) {
+	e.produceLikeEvent(biz, id, uid, -1)
+}
+
+// produceLikeEvent 排行榜是靠点赞事件维护的
+func (e likeEvents) produceLikeEvent(biz string, id int64, uid int64, delta int64) {
+	now := time.Now()
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		err := e.producer.ProduceLikeEvent(ctx, events2.LikeEvent{
+			Uid:   uid,
+			Biz:   biz,
+			BizId: id,
+			Delta: delta,
+			Ctime: now.UnixMilli(),
+		})
+		if err != nil {
+			e.l.Error("发送点赞排行榜事件到Kafka失败",
+				logger.Error(err),
+				logger.Int64("uid", uid),
+				logger.String("biz", biz),
+				logger.Int64("biz_id", id))
+		}
+	}()
+}
+
+func (i *interactiveService) TopN(ctx context.Context, biz string, window domain.Window, n int) ([]domain.LikeRank, error) {
+	// n 不对的时候不能原样传下去，ZREVRANGE 0 -1 会把整个榜单都查出来
+	if n <= 0 || n > maxPageSize {
+		n = maxPageSize
+	}
+	return i.repo.TopN(ctx, biz, window, n)
 }
 
 func (i *interactiveService) IncrReadCnt(ctx context.Context, biz string, bizId int64) error {
@@ -0,0 +1,125 @@
+// Copyright@daidai53 2024
+package service
+
+import (
+	"context"
+	"github.com/daidai53/webook/interactive/domain"
+	events2 "github.com/daidai53/webook/interactive/events"
+	"github.com/daidai53/webook/interactive/repository"
+	repomocks "github.com/daidai53/webook/interactive/repository/mocks"
+	"github.com/daidai53/webook/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+)
+
+func TestInteractiveService_Collect(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) (repository.InteractiveRepository, repository.CollectionRepository)
+		cid  int64
+
+		wantErr error
+	}{
+		{
+			name: "收藏到默认收藏夹",
+			mock: func(ctrl *gomock.Controller) (repository.InteractiveRepository, repository.CollectionRepository) {
+				repo := repomocks.NewMockInteractiveRepository(ctrl)
+				repo.EXPECT().AddCollectionItem(gomock.Any(), "article", int64(1), int64(123), int64(0)).Return(nil)
+				return repo, repomocks.NewMockCollectionRepository(ctrl)
+			},
+		},
+		{
+			name: "收藏到自己的收藏夹",
+			mock: func(ctrl *gomock.Controller) (repository.InteractiveRepository, repository.CollectionRepository) {
+				repo := repomocks.NewMockInteractiveRepository(ctrl)
+				repo.EXPECT().AddCollectionItem(gomock.Any(), "article", int64(1), int64(123), int64(2)).Return(nil)
+				colRepo := repomocks.NewMockCollectionRepository(ctrl)
+				colRepo.EXPECT().FindById(gomock.Any(), int64(2)).Return(domain.Collection{Id: 2, Uid: 123}, nil)
+				return repo, colRepo
+			},
+			cid: 2,
+		},
+		{
+			name: "别人的收藏夹",
+			mock: func(ctrl *gomock.Controller) (repository.InteractiveRepository, repository.CollectionRepository) {
+				colRepo := repomocks.NewMockCollectionRepository(ctrl)
+				colRepo.EXPECT().FindById(gomock.Any(), int64(2)).Return(domain.Collection{Id: 2, Uid: 456}, nil)
+				return repomocks.NewMockInteractiveRepository(ctrl), colRepo
+			},
+			cid:     2,
+			wantErr: ErrCollectionNotFound,
+		},
+		{
+			name: "收藏夹不存在",
+			mock: func(ctrl *gomock.Controller) (repository.InteractiveRepository, repository.CollectionRepository) {
+				colRepo := repomocks.NewMockCollectionRepository(ctrl)
+				colRepo.EXPECT().FindById(gomock.Any(), int64(2)).Return(domain.Collection{}, repository.ErrRecordNotFound)
+				return repomocks.NewMockInteractiveRepository(ctrl), colRepo
+			},
+			cid:     2,
+			wantErr: ErrCollectionNotFound,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo, colRepo := tc.mock(ctrl)
+			svc := NewInteractiveService(repo, colRepo, nopProducer{}, logger.NewNopLogger())
+			err := svc.Collect(context.Background(), "article", 1, 123, tc.cid)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
+
+func TestInteractiveService_TopN(t *testing.T) {
+	testCases := []struct {
+		name string
+		n    int
+
+		wantN int
+	}{
+		{
+			name:  "正常查",
+			n:     10,
+			wantN: 10,
+		},
+		{
+			name:  "太多了",
+			n:     1000,
+			wantN: maxPageSize,
+		},
+		{
+			name:  "没有传",
+			wantN: maxPageSize,
+		},
+		{
+			name:  "负数",
+			n:     -1,
+			wantN: maxPageSize,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo := repomocks.NewMockInteractiveRepository(ctrl)
+			repo.EXPECT().TopN(gomock.Any(), "article", domain.WindowDay, tc.wantN).Return(nil, nil)
+			svc := NewInteractiveService(repo, repomocks.NewMockCollectionRepository(ctrl), nopProducer{}, logger.NewNopLogger())
+			_, err := svc.TopN(context.Background(), "article", domain.WindowDay, tc.n)
+			assert.NoError(t, err)
+		})
+	}
+}
+
+// nopProducer 发事件是异步的，测试里面不关心
+type nopProducer struct{}
+
+func (nopProducer) ProduceInteractiveEvent(ctx context.Context, evt events2.InteractiveEvent) error {
+	return nil
+}
+
+func (nopProducer) ProduceLikeEvent(ctx context.Context, evt events2.LikeEvent) error {
+	return nil
+}
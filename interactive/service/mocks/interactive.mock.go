@@ -39,6 +39,35 @@ func (m *MockInteractiveService) EXPECT() *MockInteractiveServiceMockRecorder {
 	return m.recorder
 }
 
+// BatchGet mocks base method.
+func (m *MockInteractiveService) BatchGet(ctx context.Context, biz string, ids []int64, uid int64) (map[int64]domain.Interactive, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchGet", ctx, biz, ids, uid)
+	ret0, _ := ret[0].(map[int64]domain.Interactive)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchGet indicates an expected call of BatchGet.
+func (mr *MockInteractiveServiceMockRecorder) BatchGet(ctx, biz, ids, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchGet", reflect.TypeOf((*MockInteractiveService)(nil).BatchGet), ctx, biz, ids, uid)
+}
+
+// CancelCollect mocks base method.
+func (m *MockInteractiveService) CancelCollect(ctx context.Context, biz string, bizId, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelCollect", ctx, biz, bizId, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelCollect indicates an expected call of CancelCollect.
+func (mr *MockInteractiveServiceMockRecorder) CancelCollect(ctx, biz, bizId, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelCollect", reflect.TypeOf((*MockInteractiveService)(nil).CancelCollect), ctx, biz, bizId, uid)
+}
+
 // CancelLike mocks base method.
 func (m *MockInteractiveService) CancelLike(ctx context.Context, biz string, id, uid int64) error {
 	m.ctrl.T.Helper()
@@ -97,6 +126,36 @@ func (mr *MockInteractiveServiceMockRecorder) GetByIds(ctx, biz, ids any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIds", reflect.TypeOf((*MockInteractiveService)(nil).GetByIds), ctx, biz, ids)
 }
 
+// GetLikedItems mocks base method.
+func (m *MockInteractiveService) GetLikedItems(ctx context.Context, uid int64, biz string, offset, limit int) ([]domain.UserLike, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLikedItems", ctx, uid, biz, offset, limit)
+	ret0, _ := ret[0].([]domain.UserLike)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLikedItems indicates an expected call of GetLikedItems.
+func (mr *MockInteractiveServiceMockRecorder) GetLikedItems(ctx, uid, biz, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLikedItems", reflect.TypeOf((*MockInteractiveService)(nil).GetLikedItems), ctx, uid, biz, offset, limit)
+}
+
+// GetLikers mocks base method.
+func (m *MockInteractiveService) GetLikers(ctx context.Context, biz string, bizId int64, offset, limit int) ([]domain.UserLike, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLikers", ctx, biz, bizId, offset, limit)
+	ret0, _ := ret[0].([]domain.UserLike)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLikers indicates an expected call of GetLikers.
+func (mr *MockInteractiveServiceMockRecorder) GetLikers(ctx, biz, bizId, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLikers", reflect.TypeOf((*MockInteractiveService)(nil).GetLikers), ctx, biz, bizId, offset, limit)
+}
+
 // IncrReadCnt mocks base method.
 func (m *MockInteractiveService) IncrReadCnt(ctx context.Context, biz string, bizId int64) error {
 	m.ctrl.T.Helper()
@@ -124,3 +183,18 @@ func (mr *MockInteractiveServiceMockRecorder) Like(ctx, biz, id, uid any) *gomoc
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Like", reflect.TypeOf((*MockInteractiveService)(nil).Like), ctx, biz, id, uid)
 }
+
+// TopN mocks base method.
+func (m *MockInteractiveService) TopN(ctx context.Context, biz string, window domain.Window, n int) ([]domain.LikeRank, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TopN", ctx, biz, window, n)
+	ret0, _ := ret[0].([]domain.LikeRank)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TopN indicates an expected call of TopN.
+func (mr *MockInteractiveServiceMockRecorder) TopN(ctx, biz, window, n any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TopN", reflect.TypeOf((*MockInteractiveService)(nil).TopN), ctx, biz, window, n)
+}
@@ -0,0 +1,84 @@
+// Copyright@daidai53 2024
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/daidai53/webook/interactive/domain"
+	events2 "github.com/daidai53/webook/interactive/events"
+	"github.com/daidai53/webook/interactive/repository"
+	"github.com/daidai53/webook/pkg/logger"
+)
+
+var ErrInvalidReaction = errors.New("这个表情不能用")
+
+// ReactionSets 每个 biz 可以用哪些表情，没有配置的 biz 用 domain.DefaultReactionSet
+type ReactionSets map[string]domain.ReactionSet
+
+// ReactionService 表情回应。👍 就是原来的点赞，还是记在点赞的表里面，
+// 所以老的点赞数据不用迁移，点赞数和排行榜也都还是对的。点赞和取消点赞也是走这里
+type ReactionService interface {
+	// ChangeReaction 发一个表情，只能发一种表情的 biz 会把之前发过的换掉，点 👍 也一样
+	ChangeReaction(ctx context.Context, biz string, bizId int64, uid int64, reaction string) error
+	// RemoveReaction 撤回一个表情，没有发过也算成功
+	RemoveReaction(ctx context.Context, biz string, bizId int64, uid int64, reaction string) error
+}
+
+type reactionService struct {
+	repo   repository.InteractiveRepository
+	events likeEvents
+	sets   ReactionSets
+}
+
+func NewReactionService(repo repository.InteractiveRepository, producer events2.Producer, sets ReactionSets,
+	l logger.LoggerV1) ReactionService {
+	return &reactionService{
+		repo:   repo,
+		events: likeEvents{producer: producer, l: l},
+		sets:   sets,
+	}
+}
+
+func (r *reactionService) ChangeReaction(ctx context.Context, biz string, bizId int64, uid int64, reaction string) error {
+	set := r.reactionSet(biz)
+	// 👍 就是点赞，没有把 👍 配成表情的 biz 也能点
+	if !set.Contains(reaction) && reaction != domain.ReactionLike {
+		return ErrInvalidReaction
+	}
+	// 换表情是在数据库的一个事务里面做的，并发换表情也只会留下一个
+	removed, added, err := r.repo.AddReaction(ctx, biz, bizId, uid, reaction, !set.Multi)
+	if err != nil || !added {
+		return err
+	}
+	for _, re := range removed {
+		if re == domain.ReactionLike {
+			r.events.unliked(biz, bizId, uid)
+		}
+	}
+	if reaction == domain.ReactionLike {
+		r.events.liked(biz, bizId, uid)
+	}
+	return nil
+}
+
+func (r *reactionService) RemoveReaction(ctx context.Context, biz string, bizId int64, uid int64, reaction string) error {
+	err := r.repo.RemoveReaction(ctx, biz, bizId, uid, reaction)
+	if errors.Is(err, repository.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if reaction == domain.ReactionLike {
+		r.events.unliked(biz, bizId, uid)
+	}
+	return nil
+}
+
+func (r *reactionService) reactionSet(biz string) domain.ReactionSet {
+	set, ok := r.sets[biz]
+	if !ok {
+		return domain.DefaultReactionSet
+	}
+	return set
+}
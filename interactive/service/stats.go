@@ -0,0 +1,114 @@
+// Copyright@daidai53 2024
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/daidai53/webook/interactive/domain"
+	"github.com/daidai53/webook/interactive/repository"
+	"github.com/ecodeclub/ekit/slice"
+	"time"
+)
+
+var ErrInvalidStatsRange = errors.New("统计的时间范围不对")
+
+const (
+	// snapshotBatchSize 快照的时候一次处理多少条
+	snapshotBatchSize = 500
+	// maxStatsDays 一次最多查多少天
+	maxStatsDays = 90
+)
+
+// StatsService 每天的互动数据
+type StatsService interface {
+	// Snapshot 记录 day 那一天的增量，重复跑结果是一样的
+	Snapshot(ctx context.Context, day time.Time) error
+	// GetDailyStats 查 [start, end] 这几天每个东西每天的增量，没有变化的那天没有数据。
+	// bizIds 最多 maxPageSize 个，多了返回 ErrTooManyIds
+	GetDailyStats(ctx context.Context, biz string, bizIds []int64, start, end time.Time) ([]domain.DailyStat, error)
+}
+
+type statsService struct {
+	repo repository.StatsRepository
+}
+
+func NewStatsService(repo repository.StatsRepository) StatsService {
+	return &statsService{
+		repo: repo,
+	}
+}
+
+func (s *statsService) Snapshot(ctx context.Context, day time.Time) error {
+	start := s.dayOf(day)
+	var minId int64
+	for {
+		// 快照是第二天跑的，那时候读到的总数会带上第二天的一点点数据，算在第二天之前的那一天里面，不影响总数
+		intrs, next, err := s.repo.FindChanged(ctx, start, minId, snapshotBatchSize)
+		if err != nil {
+			return err
+		}
+		if len(intrs) == 0 {
+			return nil
+		}
+		prev, err := s.repo.FindLatest(ctx, slice.Map(intrs, func(idx int, src domain.Interactive) int64 {
+			return src.BizId
+		}), start)
+		if err != nil {
+			return err
+		}
+		prevMap := make(map[string]domain.Interactive, len(prev))
+		for _, p := range prev {
+			prevMap[s.key(p.Biz, p.BizId)] = p.Total
+		}
+		stats := make([]domain.DailyStat, 0, len(intrs))
+		for _, intr := range intrs {
+			p, ok := prevMap[s.key(intr.Biz, intr.BizId)]
+			if !ok && intr.Ctime.Before(start) {
+				// 开始做快照之前就有的东西，之前的增量不知道是哪天的，这一次只当作起点
+				p = intr
+			}
+			stats = append(stats, domain.DailyStat{
+				Biz:           intr.Biz,
+				BizId:         intr.BizId,
+				Day:           start,
+				ReadCnt:       intr.ReadCnt - p.ReadCnt,
+				UniqueReadCnt: intr.UniqueReadCnt - p.UniqueReadCnt,
+				LikeCnt:       intr.LikeCnt - p.LikeCnt,
+				CollectCnt:    intr.CollectCnt - p.CollectCnt,
+				Total:         intr,
+			})
+		}
+		err = s.repo.Save(ctx, stats)
+		if err != nil {
+			return err
+		}
+		if len(intrs) < snapshotBatchSize {
+			return nil
+		}
+		minId = next
+	}
+}
+
+func (s *statsService) GetDailyStats(ctx context.Context, biz string, bizIds []int64, start, end time.Time) ([]domain.DailyStat, error) {
+	start, end = s.dayOf(start), s.dayOf(end)
+	if end.Before(start) || end.Sub(start) > maxStatsDays*24*time.Hour {
+		return nil, ErrInvalidStatsRange
+	}
+	if len(bizIds) > maxPageSize {
+		return nil, ErrTooManyIds
+	}
+	if len(bizIds) == 0 {
+		return []domain.DailyStat{}, nil
+	}
+	return s.repo.Find(ctx, biz, bizIds, start, end)
+}
+
+func (s *statsService) dayOf(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
+
+func (s *statsService) key(biz string, bizId int64) string {
+	return fmt.Sprintf("%s:%d", biz, bizId)
+}
@@ -0,0 +1,44 @@
+// Copyright@daidai53 2024
+package service
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestStatsService_GetDailyStats(t *testing.T) {
+	end := time.Date(2024, 5, 1, 10, 0, 0, 0, time.Local)
+	testCases := []struct {
+		name   string
+		bizIds []int64
+		start  time.Time
+
+		wantErr error
+	}{
+		{
+			name:    "时间范围太长",
+			bizIds:  []int64{1},
+			start:   end.AddDate(0, 0, -maxStatsDays-1),
+			wantErr: ErrInvalidStatsRange,
+		},
+		{
+			name:    "一次查的太多",
+			bizIds:  make([]int64, maxPageSize+1),
+			start:   end.AddDate(0, 0, -maxStatsDays),
+			wantErr: ErrTooManyIds,
+		},
+		{
+			name:  "没有要查的",
+			start: end,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := NewStatsService(nil)
+			_, err := svc.GetDailyStats(context.Background(), "article", tc.bizIds, tc.start, end)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
@@ -28,9 +28,20 @@ var thirdPartySet = wire.NewSet(
 var interactiveSvcSet = wire.NewSet(
 	dao.NewGORMInteractiveDAO,
 	cache.NewInteractiveRedisCache,
-	cache.NewTopLikesCache,
-	repository.NewCachedInteractiveRepository,
+	cache.NewLeaderboardRedisCache,
+	cache.NewDeltaRedisCache,
+	ioc.InitInteractiveRepository,
+	wire.Bind(new(repository.InteractiveRepository), new(*repository.WriteBehindInteractiveRepository)),
+	events.NewSaramaSyncProducer,
 	service.NewInteractiveService,
+	dao.NewGORMCollectionDAO,
+	repository.NewCollectionRepository,
+	service.NewCollectionService,
+	ioc.InitReactionSets,
+	service.NewReactionService,
+	dao.NewGORMStatsDAO,
+	repository.NewStatsRepository,
+	service.NewStatsService,
 )
 
 func InitApp() *App {
@@ -39,11 +50,18 @@ func InitApp() *App {
 		interactiveSvcSet,
 		grpc.NewInteractiveServiceServer,
 		events.NewInteractiveReadEventConsumer,
+		events.NewLikeLeaderboardConsumer,
+		events.NewCommentCntConsumer,
 		ioc.InitInteractiveProducer,
 		ioc.InitFixerConsumer,
 		ioc.InitConsumers,
 		ioc.NewGrpcxServer,
 		ioc.InitGinxServer,
+		ioc.InitSnapshotJob,
+		ioc.InitReconcileJob,
+		ioc.InitFlushJob,
+		ioc.InitLeaderboardRebuildJob,
+		ioc.InitJobs,
 		wire.Struct(new(App), "*"),
 	)
 	return new(App)
@@ -32,22 +32,40 @@ func InitApp() *App {
 	interactiveDAO := dao.NewGORMInteractiveDAO(db)
 	cmdable := ioc.InitRedisClient()
 	interactiveCache := cache.NewInteractiveRedisCache(cmdable)
-	topLikesArticleCache := cache.NewTopLikesCache(cmdable, loggerV1)
-	interactiveRepository := repository.NewCachedInteractiveRepository(interactiveDAO, interactiveCache, topLikesArticleCache, loggerV1)
+	leaderboardCache := cache.NewLeaderboardRedisCache(cmdable)
+	deltaCache := cache.NewDeltaRedisCache(cmdable)
+	writeBehindInteractiveRepository := ioc.InitInteractiveRepository(interactiveDAO, interactiveCache, leaderboardCache, deltaCache, loggerV1)
 	client := ioc.InitSaramaClient()
-	interactiveReadEventConsumer := events.NewInteractiveReadEventConsumer(interactiveRepository, client, loggerV1)
+	interactiveReadEventConsumer := events.NewInteractiveReadEventConsumer(writeBehindInteractiveRepository, client, loggerV1)
+	likeLeaderboardConsumer := events.NewLikeLeaderboardConsumer(writeBehindInteractiveRepository, client, loggerV1)
+	commentCntConsumer := events.NewCommentCntConsumer(writeBehindInteractiveRepository, client, loggerV1)
 	consumer := ioc.InitFixerConsumer(client, loggerV1, srcDB, dstDB)
-	v := ioc.InitConsumers(interactiveReadEventConsumer, consumer)
-	interactiveService := service.NewInteractiveService(interactiveRepository)
-	interactiveServiceServer := grpc.NewInteractiveServiceServer(interactiveService)
-	server := ioc.NewGrpcxServer(interactiveServiceServer)
+	v := ioc.InitConsumers(interactiveReadEventConsumer, likeLeaderboardConsumer, commentCntConsumer, consumer)
+	collectionDAO := dao.NewGORMCollectionDAO(db)
+	collectionRepository := repository.NewCollectionRepository(collectionDAO)
 	syncProducer := ioc.InitSaramaSyncProducer(client)
-	producer := ioc.InitInteractiveProducer(syncProducer)
-	ginxServer := ioc.InitGinxServer(loggerV1, srcDB, dstDB, doubleWritePool, producer)
+	producer := events.NewSaramaSyncProducer(syncProducer)
+	interactiveService := service.NewInteractiveService(writeBehindInteractiveRepository, collectionRepository, producer, loggerV1)
+	collectionService := service.NewCollectionService(collectionRepository)
+	reactionSets := ioc.InitReactionSets()
+	reactionService := service.NewReactionService(writeBehindInteractiveRepository, producer, reactionSets, loggerV1)
+	statsDAO := dao.NewGORMStatsDAO(db)
+	statsRepository := repository.NewStatsRepository(statsDAO)
+	statsService := service.NewStatsService(statsRepository)
+	interactiveServiceServer := grpc.NewInteractiveServiceServer(interactiveService, collectionService, reactionService, statsService)
+	server := ioc.NewGrpcxServer(interactiveServiceServer)
+	eventsProducer := ioc.InitInteractiveProducer(syncProducer)
+	ginxServer := ioc.InitGinxServer(loggerV1, srcDB, dstDB, doubleWritePool, eventsProducer)
+	snapshotJob := ioc.InitSnapshotJob(statsService)
+	reconcileJob := ioc.InitReconcileJob(writeBehindInteractiveRepository, loggerV1)
+	flushJob := ioc.InitFlushJob(writeBehindInteractiveRepository)
+	leaderboardRebuildJob := ioc.InitLeaderboardRebuildJob(writeBehindInteractiveRepository, loggerV1)
+	cron := ioc.InitJobs(loggerV1, snapshotJob, reconcileJob, flushJob, leaderboardRebuildJob)
 	app := &App{
 		consumers:   v,
 		server:      server,
 		adminServer: ginxServer,
+		cron:        cron,
 	}
 	return app
 }
@@ -56,4 +74,4 @@ func InitApp() *App {
 
 var thirdPartySet = wire.NewSet(ioc.InitDstDB, ioc.InitSrcDB, ioc.InitDoubleWritePool, ioc.InitBizDB, ioc.InitSaramaSyncProducer, ioc.InitRedisClient, ioc.InitLogger, ioc.InitSaramaClient)
 
-var interactiveSvcSet = wire.NewSet(dao.NewGORMInteractiveDAO, cache.NewInteractiveRedisCache, cache.NewTopLikesCache, repository.NewCachedInteractiveRepository, service.NewInteractiveService)
+var interactiveSvcSet = wire.NewSet(dao.NewGORMInteractiveDAO, cache.NewInteractiveRedisCache, cache.NewLeaderboardRedisCache, cache.NewDeltaRedisCache, ioc.InitInteractiveRepository, wire.Bind(new(repository.InteractiveRepository), new(*repository.WriteBehindInteractiveRepository)), events.NewSaramaSyncProducer, service.NewInteractiveService, dao.NewGORMCollectionDAO, repository.NewCollectionRepository, service.NewCollectionService, ioc.InitReactionSets, service.NewReactionService, dao.NewGORMStatsDAO, repository.NewStatsRepository, service.NewStatsService)
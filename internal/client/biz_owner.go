@@ -0,0 +1,35 @@
+// Copyright@daidai53 2024
+package client
+
+import (
+	"context"
+	"errors"
+	"github.com/daidai53/webook/internal/repository/dao"
+	"github.com/daidai53/webook/internal/service"
+)
+
+// BizOwnerResolver 给评论服务用，找出被评论的资源的作者
+type BizOwnerResolver struct {
+	artSvc service.ArticleService
+}
+
+func NewBizOwnerResolver(artSvc service.ArticleService) *BizOwnerResolver {
+	return &BizOwnerResolver{
+		artSvc: artSvc,
+	}
+}
+
+func (b *BizOwnerResolver) Owner(ctx context.Context, biz string, bizId int64) (int64, error) {
+	// 目前只有文章能评论
+	if biz != "article" {
+		return 0, nil
+	}
+	art, err := b.artSvc.GetById(ctx, bizId)
+	if errors.Is(err, dao.ErrRecordNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return art.Author.Id, nil
+}
@@ -0,0 +1,29 @@
+// Copyright@daidai53 2024
+package client
+
+import (
+	"context"
+	followv1 "github.com/daidai53/webook/api/proto/gen/follow/v1"
+)
+
+// BlockChecker 给评论服务用，拉黑关系在关注服务里面
+type BlockChecker struct {
+	client followv1.FollowServiceClient
+}
+
+func NewBlockChecker(client followv1.FollowServiceClient) *BlockChecker {
+	return &BlockChecker{
+		client: client,
+	}
+}
+
+func (b *BlockChecker) Blockers(ctx context.Context, uid int64, candidates []int64) ([]int64, error) {
+	resp, err := b.client.GetBlockers(ctx, &followv1.GetBlockersRequest{
+		Uid:        uid,
+		Candidates: candidates,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetBlockers(), nil
+}
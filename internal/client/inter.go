@@ -34,6 +34,10 @@ func (i *InteractiveClient) Collect(ctx context.Context, in *interv1.CollectRequ
 	return i.selectClient().Collect(ctx, in, opts...)
 }
 
+func (i *InteractiveClient) CancelCollect(ctx context.Context, in *interv1.CancelCollectRequest, opts ...grpc.CallOption) (*interv1.CancelCollectResponse, error) {
+	return i.selectClient().CancelCollect(ctx, in, opts...)
+}
+
 func (i *InteractiveClient) Get(ctx context.Context, in *interv1.GetRequest, opts ...grpc.CallOption) (*interv1.GetResponse, error) {
 	return i.selectClient().Get(ctx, in, opts...)
 }
@@ -42,6 +46,58 @@ func (i *InteractiveClient) GetByIds(ctx context.Context, in *interv1.GetByIdsRe
 	return i.selectClient().GetByIds(ctx, in, opts...)
 }
 
+func (i *InteractiveClient) BatchGet(ctx context.Context, in *interv1.BatchGetRequest, opts ...grpc.CallOption) (*interv1.BatchGetResponse, error) {
+	return i.selectClient().BatchGet(ctx, in, opts...)
+}
+
+func (i *InteractiveClient) GetLikers(ctx context.Context, in *interv1.GetLikersRequest, opts ...grpc.CallOption) (*interv1.GetLikersResponse, error) {
+	return i.selectClient().GetLikers(ctx, in, opts...)
+}
+
+func (i *InteractiveClient) GetLikedItems(ctx context.Context, in *interv1.GetLikedItemsRequest, opts ...grpc.CallOption) (*interv1.GetLikedItemsResponse, error) {
+	return i.selectClient().GetLikedItems(ctx, in, opts...)
+}
+
+func (i *InteractiveClient) TopN(ctx context.Context, in *interv1.TopNRequest, opts ...grpc.CallOption) (*interv1.TopNResponse, error) {
+	return i.selectClient().TopN(ctx, in, opts...)
+}
+
+func (i *InteractiveClient) ChangeReaction(ctx context.Context, in *interv1.ChangeReactionRequest, opts ...grpc.CallOption) (*interv1.ChangeReactionResponse, error) {
+	return i.selectClient().ChangeReaction(ctx, in, opts...)
+}
+
+func (i *InteractiveClient) RemoveReaction(ctx context.Context, in *interv1.RemoveReactionRequest, opts ...grpc.CallOption) (*interv1.RemoveReactionResponse, error) {
+	return i.selectClient().RemoveReaction(ctx, in, opts...)
+}
+
+func (i *InteractiveClient) GetDailyStats(ctx context.Context, in *interv1.GetDailyStatsRequest, opts ...grpc.CallOption) (*interv1.GetDailyStatsResponse, error) {
+	return i.selectClient().GetDailyStats(ctx, in, opts...)
+}
+
+func (i *InteractiveClient) CreateCollection(ctx context.Context, in *interv1.CreateCollectionRequest, opts ...grpc.CallOption) (*interv1.CreateCollectionResponse, error) {
+	return i.selectClient().CreateCollection(ctx, in, opts...)
+}
+
+func (i *InteractiveClient) UpdateCollection(ctx context.Context, in *interv1.UpdateCollectionRequest, opts ...grpc.CallOption) (*interv1.UpdateCollectionResponse, error) {
+	return i.selectClient().UpdateCollection(ctx, in, opts...)
+}
+
+func (i *InteractiveClient) DeleteCollection(ctx context.Context, in *interv1.DeleteCollectionRequest, opts ...grpc.CallOption) (*interv1.DeleteCollectionResponse, error) {
+	return i.selectClient().DeleteCollection(ctx, in, opts...)
+}
+
+func (i *InteractiveClient) ListCollections(ctx context.Context, in *interv1.ListCollectionsRequest, opts ...grpc.CallOption) (*interv1.ListCollectionsResponse, error) {
+	return i.selectClient().ListCollections(ctx, in, opts...)
+}
+
+func (i *InteractiveClient) ListCollectionItems(ctx context.Context, in *interv1.ListCollectionItemsRequest, opts ...grpc.CallOption) (*interv1.ListCollectionItemsResponse, error) {
+	return i.selectClient().ListCollectionItems(ctx, in, opts...)
+}
+
+func (i *InteractiveClient) MoveCollectionItems(ctx context.Context, in *interv1.MoveCollectionItemsRequest, opts ...grpc.CallOption) (*interv1.MoveCollectionItemsResponse, error) {
+	return i.selectClient().MoveCollectionItems(ctx, in, opts...)
+}
+
 func (i *InteractiveClient) selectClient() interv1.InteractiveServiceClient {
 	zap.L().Info(fmt.Sprintf("selectClient:%v", i.threshold.Load()))
 	num := rand.Int31n(100)
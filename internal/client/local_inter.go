@@ -6,15 +6,21 @@ import (
 	interv1 "github.com/daidai53/webook/api/proto/gen/inter/v1"
 	"github.com/daidai53/webook/interactive/domain"
 	"github.com/daidai53/webook/interactive/service"
+	"github.com/ecodeclub/ekit/slice"
 	"google.golang.org/grpc"
+	"time"
 )
 
 type LocalInteractiveServiceAdaptor struct {
-	svc service.InteractiveService
+	svc      service.InteractiveService
+	colSvc   service.CollectionService
+	reactSvc service.ReactionService
+	statsSvc service.StatsService
 }
 
-func NewLocalInteractiveServiceAdaptor(svc service.InteractiveService) *LocalInteractiveServiceAdaptor {
-	return &LocalInteractiveServiceAdaptor{svc: svc}
+func NewLocalInteractiveServiceAdaptor(svc service.InteractiveService, colSvc service.CollectionService,
+	reactSvc service.ReactionService, statsSvc service.StatsService) *LocalInteractiveServiceAdaptor {
+	return &LocalInteractiveServiceAdaptor{svc: svc, colSvc: colSvc, reactSvc: reactSvc, statsSvc: statsSvc}
 }
 
 func (l *LocalInteractiveServiceAdaptor) IncrReadCnt(ctx context.Context, in *interv1.IncrReadCntRequest, opts ...grpc.CallOption) (*interv1.IncrReadCntResponse, error) {
@@ -23,12 +29,12 @@ func (l *LocalInteractiveServiceAdaptor) IncrReadCnt(ctx context.Context, in *in
 }
 
 func (l *LocalInteractiveServiceAdaptor) Like(ctx context.Context, in *interv1.LikeRequest, opts ...grpc.CallOption) (*interv1.LikeResponse, error) {
-	err := l.svc.Like(ctx, in.GetBiz(), in.GetId(), in.GetUid())
+	err := l.reactSvc.ChangeReaction(ctx, in.GetBiz(), in.GetId(), in.GetUid(), domain.ReactionLike)
 	return &interv1.LikeResponse{}, err
 }
 
 func (l *LocalInteractiveServiceAdaptor) CancelLike(ctx context.Context, in *interv1.CancelLikeRequest, opts ...grpc.CallOption) (*interv1.CancelLikeResponse, error) {
-	err := l.svc.CancelLike(ctx, in.GetBiz(), in.GetId(), in.GetUid())
+	err := l.reactSvc.RemoveReaction(ctx, in.GetBiz(), in.GetId(), in.GetUid(), domain.ReactionLike)
 	return &interv1.CancelLikeResponse{}, err
 }
 
@@ -37,6 +43,11 @@ func (l *LocalInteractiveServiceAdaptor) Collect(ctx context.Context, in *interv
 	return &interv1.CollectResponse{}, err
 }
 
+func (l *LocalInteractiveServiceAdaptor) CancelCollect(ctx context.Context, in *interv1.CancelCollectRequest, opts ...grpc.CallOption) (*interv1.CancelCollectResponse, error) {
+	err := l.svc.CancelCollect(ctx, in.GetBiz(), in.GetId(), in.GetUid())
+	return &interv1.CancelCollectResponse{}, err
+}
+
 func (l *LocalInteractiveServiceAdaptor) Get(ctx context.Context, in *interv1.GetRequest, opts ...grpc.CallOption) (*interv1.GetResponse, error) {
 	resp, err := l.svc.Get(ctx, in.GetBiz(), in.GetId(), in.GetUid())
 	return &interv1.GetResponse{Inter: l.toDTO(resp)}, err
@@ -53,14 +64,158 @@ func (l *LocalInteractiveServiceAdaptor) GetByIds(ctx context.Context, in *inter
 	}, err
 }
 
+func (l *LocalInteractiveServiceAdaptor) BatchGet(ctx context.Context, in *interv1.BatchGetRequest, opts ...grpc.CallOption) (*interv1.BatchGetResponse, error) {
+	resp, err := l.svc.BatchGet(ctx, in.GetBiz(), in.GetIds(), in.GetUid())
+	inters := make(map[int64]*interv1.Interactive, len(resp))
+	for k, v := range resp {
+		inters[k] = l.toDTO(v)
+	}
+	return &interv1.BatchGetResponse{
+		Inters: inters,
+	}, err
+}
+
+func (l *LocalInteractiveServiceAdaptor) GetLikers(ctx context.Context, in *interv1.GetLikersRequest, opts ...grpc.CallOption) (*interv1.GetLikersResponse, error) {
+	likes, err := l.svc.GetLikers(ctx, in.GetBiz(), in.GetBizId(), int(in.GetOffset()), int(in.GetLimit()))
+	return &interv1.GetLikersResponse{
+		Likes: slice.Map(likes, func(idx int, src domain.UserLike) *interv1.UserLike {
+			return l.toUserLikeDTO(src)
+		}),
+	}, err
+}
+
+func (l *LocalInteractiveServiceAdaptor) GetLikedItems(ctx context.Context, in *interv1.GetLikedItemsRequest, opts ...grpc.CallOption) (*interv1.GetLikedItemsResponse, error) {
+	likes, err := l.svc.GetLikedItems(ctx, in.GetUid(), in.GetBiz(), int(in.GetOffset()), int(in.GetLimit()))
+	return &interv1.GetLikedItemsResponse{
+		Likes: slice.Map(likes, func(idx int, src domain.UserLike) *interv1.UserLike {
+			return l.toUserLikeDTO(src)
+		}),
+	}, err
+}
+
+func (l *LocalInteractiveServiceAdaptor) TopN(ctx context.Context, in *interv1.TopNRequest, opts ...grpc.CallOption) (*interv1.TopNResponse, error) {
+	ranks, err := l.svc.TopN(ctx, in.GetBiz(), domain.Window(in.GetWindow()), int(in.GetN()))
+	return &interv1.TopNResponse{
+		Items: slice.Map(ranks, func(idx int, src domain.LikeRank) *interv1.TopItem {
+			return &interv1.TopItem{
+				BizId:   src.BizId,
+				LikeCnt: src.LikeCnt,
+			}
+		}),
+	}, err
+}
+
+func (l *LocalInteractiveServiceAdaptor) ChangeReaction(ctx context.Context, in *interv1.ChangeReactionRequest, opts ...grpc.CallOption) (*interv1.ChangeReactionResponse, error) {
+	err := l.reactSvc.ChangeReaction(ctx, in.GetBiz(), in.GetBizId(), in.GetUid(), in.GetReaction())
+	return &interv1.ChangeReactionResponse{}, err
+}
+
+func (l *LocalInteractiveServiceAdaptor) RemoveReaction(ctx context.Context, in *interv1.RemoveReactionRequest, opts ...grpc.CallOption) (*interv1.RemoveReactionResponse, error) {
+	err := l.reactSvc.RemoveReaction(ctx, in.GetBiz(), in.GetBizId(), in.GetUid(), in.GetReaction())
+	return &interv1.RemoveReactionResponse{}, err
+}
+
+func (l *LocalInteractiveServiceAdaptor) GetDailyStats(ctx context.Context, in *interv1.GetDailyStatsRequest, opts ...grpc.CallOption) (*interv1.GetDailyStatsResponse, error) {
+	stats, err := l.statsSvc.GetDailyStats(ctx, in.GetBiz(), in.GetBizIds(),
+		time.UnixMilli(in.GetStart()), time.UnixMilli(in.GetEnd()))
+	return &interv1.GetDailyStatsResponse{
+		Stats: slice.Map(stats, func(idx int, src domain.DailyStat) *interv1.DailyStat {
+			return &interv1.DailyStat{
+				BizId:         src.BizId,
+				Day:           src.Day.UnixMilli(),
+				ReadCnt:       src.ReadCnt,
+				UniqueReadCnt: src.UniqueReadCnt,
+				LikeCnt:       src.LikeCnt,
+				CollectCnt:    src.CollectCnt,
+			}
+		}),
+	}, err
+}
+
+func (l *LocalInteractiveServiceAdaptor) CreateCollection(ctx context.Context, in *interv1.CreateCollectionRequest, opts ...grpc.CallOption) (*interv1.CreateCollectionResponse, error) {
+	id, err := l.colSvc.Create(ctx, l.toCollectionDomain(in.GetCollection()))
+	return &interv1.CreateCollectionResponse{Id: id}, err
+}
+
+func (l *LocalInteractiveServiceAdaptor) UpdateCollection(ctx context.Context, in *interv1.UpdateCollectionRequest, opts ...grpc.CallOption) (*interv1.UpdateCollectionResponse, error) {
+	err := l.colSvc.Update(ctx, l.toCollectionDomain(in.GetCollection()))
+	return &interv1.UpdateCollectionResponse{}, err
+}
+
+func (l *LocalInteractiveServiceAdaptor) DeleteCollection(ctx context.Context, in *interv1.DeleteCollectionRequest, opts ...grpc.CallOption) (*interv1.DeleteCollectionResponse, error) {
+	err := l.colSvc.Delete(ctx, in.GetUid(), in.GetId())
+	return &interv1.DeleteCollectionResponse{}, err
+}
+
+func (l *LocalInteractiveServiceAdaptor) ListCollections(ctx context.Context, in *interv1.ListCollectionsRequest, opts ...grpc.CallOption) (*interv1.ListCollectionsResponse, error) {
+	cols, err := l.colSvc.List(ctx, in.GetUid(), in.GetViewer(), int(in.GetOffset()), int(in.GetLimit()))
+	return &interv1.ListCollectionsResponse{
+		Collections: slice.Map(cols, func(idx int, src domain.Collection) *interv1.Collection {
+			return &interv1.Collection{
+				Id:          src.Id,
+				Uid:         src.Uid,
+				Name:        src.Name,
+				Description: src.Description,
+				Public:      src.Public,
+				Ctime:       src.Ctime.UnixMilli(),
+				Utime:       src.Utime.UnixMilli(),
+			}
+		}),
+	}, err
+}
+
+func (l *LocalInteractiveServiceAdaptor) ListCollectionItems(ctx context.Context, in *interv1.ListCollectionItemsRequest, opts ...grpc.CallOption) (*interv1.ListCollectionItemsResponse, error) {
+	items, err := l.colSvc.ListItems(ctx, in.GetUid(), in.GetCid(), in.GetViewer(), int(in.GetOffset()), int(in.GetLimit()))
+	return &interv1.ListCollectionItemsResponse{
+		Items: slice.Map(items, func(idx int, src domain.CollectionItem) *interv1.CollectionItem {
+			return &interv1.CollectionItem{
+				Cid:   src.Cid,
+				Uid:   src.Uid,
+				Biz:   src.Biz,
+				BizId: src.BizId,
+				Ctime: src.Ctime.UnixMilli(),
+				Utime: src.Utime.UnixMilli(),
+			}
+		}),
+	}, err
+}
+
+func (l *LocalInteractiveServiceAdaptor) MoveCollectionItems(ctx context.Context, in *interv1.MoveCollectionItemsRequest, opts ...grpc.CallOption) (*interv1.MoveCollectionItemsResponse, error) {
+	err := l.colSvc.MoveItems(ctx, in.GetUid(), in.GetBiz(), in.GetBizIds(), in.GetCid())
+	return &interv1.MoveCollectionItemsResponse{}, err
+}
+
+func (l *LocalInteractiveServiceAdaptor) toCollectionDomain(col *interv1.Collection) domain.Collection {
+	return domain.Collection{
+		Id:          col.GetId(),
+		Uid:         col.GetUid(),
+		Name:        col.GetName(),
+		Description: col.GetDescription(),
+		Public:      col.GetPublic(),
+	}
+}
+
+func (l *LocalInteractiveServiceAdaptor) toUserLikeDTO(like domain.UserLike) *interv1.UserLike {
+	return &interv1.UserLike{
+		Uid:   like.Uid,
+		Biz:   like.Biz,
+		BizId: like.BizId,
+		Ctime: like.Ctime.UnixMilli(),
+	}
+}
+
 func (l *LocalInteractiveServiceAdaptor) toDTO(inter domain.Interactive) *interv1.Interactive {
 	return &interv1.Interactive{
-		Biz:        inter.Biz,
-		BizId:      inter.BizId,
-		ReadCnt:    inter.ReadCnt,
-		CollectCnt: inter.CollectCnt,
-		LikeCnt:    inter.LikeCnt,
-		Liked:      inter.Liked,
-		Collected:  inter.Collected,
+		Biz:           inter.Biz,
+		BizId:         inter.BizId,
+		ReadCnt:       inter.ReadCnt,
+		UniqueReadCnt: inter.UniqueReadCnt,
+		CollectCnt:    inter.CollectCnt,
+		CommentCnt:    inter.CommentCnt,
+		LikeCnt:       inter.LikeCnt,
+		Liked:         inter.Liked,
+		Collected:     inter.Collected,
+		Reactions:     inter.Reactions,
+		MyReactions:   inter.MyReactions,
 	}
 }
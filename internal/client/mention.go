@@ -0,0 +1,66 @@
+// Copyright@daidai53 2024
+package client
+
+import (
+	"context"
+	followv1 "github.com/daidai53/webook/api/proto/gen/follow/v1"
+	"github.com/daidai53/webook/internal/service"
+)
+
+// MentionResolver 给评论服务用，把评论里面 @ 的昵称换成用户 id
+type MentionResolver struct {
+	svc          service.UserService
+	followClient followv1.FollowServiceClient
+}
+
+func NewMentionResolver(svc service.UserService, followClient followv1.FollowServiceClient) *MentionResolver {
+	return &MentionResolver{
+		svc:          svc,
+		followClient: followClient,
+	}
+}
+
+func (m *MentionResolver) Resolve(ctx context.Context, uid int64, nicknames []string) (map[string]int64, error) {
+	users, err := m.svc.FindByNicknames(ctx, nicknames)
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[string]int64, len(users))
+	// 重名的不知道 @ 的是谁，都不算
+	dup := make(map[string]struct{})
+	for _, u := range users {
+		if _, ok := res[u.Nickname]; ok {
+			dup[u.Nickname] = struct{}{}
+			continue
+		}
+		res[u.Nickname] = u.Id
+	}
+	for nickname := range dup {
+		delete(res, nickname)
+	}
+	if len(res) == 0 {
+		return res, nil
+	}
+	// 拉黑了 uid 的人不能 @
+	candidates := make([]int64, 0, len(res))
+	for _, id := range res {
+		candidates = append(candidates, id)
+	}
+	resp, err := m.followClient.GetBlockers(ctx, &followv1.GetBlockersRequest{
+		Uid:        uid,
+		Candidates: candidates,
+	})
+	if err != nil {
+		return nil, err
+	}
+	blockers := make(map[int64]struct{}, len(resp.GetBlockers()))
+	for _, id := range resp.GetBlockers() {
+		blockers[id] = struct{}{}
+	}
+	for nickname, id := range res {
+		if _, ok := blockers[id]; ok {
+			delete(res, nickname)
+		}
+	}
+	return res, nil
+}
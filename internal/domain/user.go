@@ -10,6 +10,7 @@ type User struct {
 	Birthday string
 	AboutMe  string
 	Phone    string
+	Avatar   string
 
 	Active     bool
 	WeChatInfo WeChatInfo
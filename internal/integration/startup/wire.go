@@ -30,7 +30,11 @@ var thirdPartySet = wire.NewSet(
 	InitSaramaClient,
 	InitSyncProducer,
 	ioc.InitLogger,
+	ioc.InitEtcd,
 	ioc.InitInterClient,
+	ioc.InitCodeClient,
+	ioc.InitCommentClient,
+	ioc.InitRewardClient,
 )
 
 var jobProviderSet = wire.NewSet(
@@ -54,7 +58,6 @@ func InitWebServer() *gin.Engine {
 		dao.NewArticleGormDAO,
 		ijwt.NewRedisJWTHandler,
 		ioc.InitWechatService,
-		dao2.NewGORMInteractiveDAO,
 
 		//ioc.NewLocalCacheDefault,
 
@@ -62,16 +65,13 @@ func InitWebServer() *gin.Engine {
 		cache3.NewRedisCodeCache,
 		//cache.NewLocalCodeCache,
 		cache.NewArticleRedisCache,
-		cache2.NewInteractiveRedisCache,
 		cache.NewUserCache,
-		cache2.NewTopLikesCache,
 		cache.NewRankingRedisCache,
 
 		// repository部分
 		repository3.NewCachedCodeRepository,
 		repository.NewCachedUserRepository,
 		repository.NewCachedArticleRepository,
-		repository2.NewCachedInteractiveRepository,
 		repository.NewCachedRankingRepository,
 
 		article.NewSaramaSyncProducer,
@@ -81,13 +81,13 @@ func InitWebServer() *gin.Engine {
 		service.NewUserService,
 		service3.NewCodeService,
 		service.NewArticleService,
-		service2.NewInteractiveService,
 		service.NewBatchRankingService,
 
 		// handler部分
 		web.NewUserHandler,
 		web.NewOAuth2WechatHandler,
 		web.NewArticleHandler,
+		web.NewCommentHandler,
 
 		ioc.InitWebServer,
 		ioc.InitGinMiddlewares,
@@ -101,15 +101,11 @@ func InitArticleHandler(artDao dao.ArticleDAO, interDao dao2.InteractiveDAO, use
 		article.NewSaramaSyncProducer,
 		repository.NewCachedUserRepository,
 		repository.NewCachedArticleRepository,
-		repository2.NewCachedInteractiveRepository,
 		repository.NewCachedRankingRepository,
 		service.NewArticleService,
-		service2.NewInteractiveService,
 		service.NewBatchRankingService,
-		cache2.NewInteractiveRedisCache,
 		cache.NewArticleRedisCache,
 		cache.NewUserCache,
-		cache2.NewTopLikesCache,
 		cache.NewRankingRedisCache,
 		web.NewArticleHandler,
 	)
@@ -7,13 +7,13 @@
 package startup
 
 import (
-	repository3 "github.com/daidai53/webook/code/repository"
-	cache3 "github.com/daidai53/webook/code/repository/cache"
-	service3 "github.com/daidai53/webook/code/service"
-	repository2 "github.com/daidai53/webook/interactive/repository"
-	cache2 "github.com/daidai53/webook/interactive/repository/cache"
+	repository2 "github.com/daidai53/webook/code/repository"
+	cache2 "github.com/daidai53/webook/code/repository/cache"
+	service2 "github.com/daidai53/webook/code/service"
+	repository3 "github.com/daidai53/webook/interactive/repository"
+	cache3 "github.com/daidai53/webook/interactive/repository/cache"
 	dao2 "github.com/daidai53/webook/interactive/repository/dao"
-	service2 "github.com/daidai53/webook/interactive/service"
+	service3 "github.com/daidai53/webook/interactive/service"
 	"github.com/daidai53/webook/internal/events/article"
 	"github.com/daidai53/webook/internal/job"
 	"github.com/daidai53/webook/internal/repository"
@@ -39,11 +39,12 @@ func InitWebServer() *gin.Engine {
 	userCache := cache.NewUserCache(cmdable)
 	userRepository := repository.NewCachedUserRepository(userDAO, userCache)
 	userService := service.NewUserService(userRepository)
-	codeCache := cache3.NewRedisCodeCache(cmdable)
-	codeRepository := repository3.NewCachedCodeRepository(codeCache)
+	codeCache := cache2.NewRedisCodeCache(cmdable)
+	codeRepository := repository2.NewCachedCodeRepository(codeCache)
 	smsService := ioc.InitSmsService()
-	codeService := service3.NewCodeService(codeRepository, smsService)
-	userHandler := web.NewUserHandler(userService, codeService, handler)
+	codeService := service2.NewCodeService(codeRepository, smsService)
+	codeServiceClient := ioc.InitCodeClient(codeService)
+	userHandler := web.NewUserHandler(userService, codeServiceClient, handler)
 	articleDAO := dao.NewArticleGormDAO(db)
 	articleCache := cache.NewArticleRedisCache(cmdable)
 	articleRepository := repository.NewCachedArticleRepository(articleDAO, articleCache, userRepository)
@@ -51,19 +52,18 @@ func InitWebServer() *gin.Engine {
 	syncProducer := InitSyncProducer(client)
 	producer := article.NewSaramaSyncProducer(syncProducer)
 	articleService := service.NewArticleService(articleRepository, producer)
-	interactiveDAO := dao2.NewGORMInteractiveDAO(db)
-	interactiveCache := cache2.NewInteractiveRedisCache(cmdable)
-	topLikesArticleCache := cache2.NewTopLikesCache(cmdable, loggerV1)
-	interactiveRepository := repository2.NewCachedInteractiveRepository(interactiveDAO, interactiveCache, topLikesArticleCache, loggerV1)
-	interactiveService := service2.NewInteractiveService(interactiveRepository)
-	interactiveServiceClient := ioc.InitInterClient(interactiveService)
+	clientv3Client := ioc.InitEtcd()
+	interactiveServiceClient := ioc.InitInterClient(clientv3Client)
 	rankingCache := cache.NewRankingRedisCache(cmdable)
 	rankingRepository := repository.NewCachedRankingRepository(rankingCache)
 	rankingService := service.NewBatchRankingService(interactiveServiceClient, articleService, rankingRepository)
-	articleHandler := web.NewArticleHandler(loggerV1, articleService, interactiveServiceClient, rankingService)
+	rewardServiceClient := ioc.InitRewardClient(clientv3Client)
+	articleHandler := web.NewArticleHandler(loggerV1, articleService, interactiveServiceClient, rankingService, rewardServiceClient)
 	wechatService := ioc.InitWechatService(loggerV1)
 	oAuth2WechatHandler := web.NewOAuth2WechatHandler(wechatService, userService, handler)
-	engine := ioc.InitWebServer(v, userHandler, articleHandler, oAuth2WechatHandler)
+	commentServiceClient := ioc.InitCommentClient(clientv3Client)
+	commentHandler := web.NewCommentHandler(commentServiceClient, userService, loggerV1)
+	engine := ioc.InitWebServer(v, userHandler, articleHandler, oAuth2WechatHandler, commentHandler)
 	return engine
 }
 
@@ -78,15 +78,13 @@ func InitArticleHandler(artDao dao.ArticleDAO, interDao dao2.InteractiveDAO, use
 	syncProducer := InitSyncProducer(client)
 	producer := article.NewSaramaSyncProducer(syncProducer)
 	articleService := service.NewArticleService(articleRepository, producer)
-	interactiveCache := cache2.NewInteractiveRedisCache(cmdable)
-	topLikesArticleCache := cache2.NewTopLikesCache(cmdable, loggerV1)
-	interactiveRepository := repository2.NewCachedInteractiveRepository(interDao, interactiveCache, topLikesArticleCache, loggerV1)
-	interactiveService := service2.NewInteractiveService(interactiveRepository)
-	interactiveServiceClient := ioc.InitInterClient(interactiveService)
+	clientv3Client := ioc.InitEtcd()
+	interactiveServiceClient := ioc.InitInterClient(clientv3Client)
 	rankingCache := cache.NewRankingRedisCache(cmdable)
 	rankingRepository := repository.NewCachedRankingRepository(rankingCache)
 	rankingService := service.NewBatchRankingService(interactiveServiceClient, articleService, rankingRepository)
-	articleHandler := web.NewArticleHandler(loggerV1, articleService, interactiveServiceClient, rankingService)
+	rewardServiceClient := ioc.InitRewardClient(clientv3Client)
+	articleHandler := web.NewArticleHandler(loggerV1, articleService, interactiveServiceClient, rankingService, rewardServiceClient)
 	return articleHandler
 }
 
@@ -100,24 +98,15 @@ func InitJobScheduler() *job.Scheduler {
 	return scheduler
 }
 
-func InitCodeService() *service3.CodeServiceImpl {
-	cmdable := InitRedis()
-	codeCache := cache3.NewRedisCodeCache(cmdable)
-	codeRepository := repository3.NewCachedCodeRepository(codeCache)
-	smsService := ioc.InitSmsService()
-	codeServiceImpl := service3.NewCodeServiceImpl(codeRepository, smsService)
-	return codeServiceImpl
-}
-
 // wire.go:
 
 var thirdPartySet = wire.NewSet(
 	InitDB,
 	InitRedis,
 	InitSaramaClient,
-	InitSyncProducer, ioc.InitLogger, ioc.InitInterClient,
+	InitSyncProducer, ioc.InitLogger, ioc.InitEtcd, ioc.InitInterClient, ioc.InitCodeClient, ioc.InitCommentClient, ioc.InitRewardClient,
 )
 
 var jobProviderSet = wire.NewSet(service.NewCronJobService, repository.NewPreemptJobRepository, dao.NewGormJobDAO)
 
-var interactiveSvcSet = wire.NewSet(dao2.NewGORMInteractiveDAO, cache2.NewInteractiveRedisCache, repository2.NewCachedInteractiveRepository, service2.NewInteractiveService)
+var interactiveSvcSet = wire.NewSet(dao2.NewGORMInteractiveDAO, cache3.NewInteractiveRedisCache, repository3.NewCachedInteractiveRepository, service3.NewInteractiveService)
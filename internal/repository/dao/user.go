@@ -22,6 +22,8 @@ type UserDAO interface {
 	Update(ctx context.Context, idInt64 int64, user User) error
 	FindByPhone(ctx context.Context, phone string) (User, error)
 	FindByWeChat(ctx context.Context, openId string) (User, error)
+	FindByNicknames(ctx context.Context, nicknames []string) ([]User, error)
+	FindByIds(ctx context.Context, ids []int64) ([]User, error)
 }
 
 type GormUserDAO struct {
@@ -42,7 +44,7 @@ type User struct {
 	CreateTime int64
 	UpdateTime int64
 
-	Nickname string
+	Nickname string         `gorm:"index"`
 	Phone    sql.NullString `gorm:"unique"`
 
 	// 如果查询要求同时使用openid和unionid，就要创建联合唯一索引
@@ -52,6 +54,8 @@ type User struct {
 	WechatUnionId sql.NullString
 	Birthday      string
 	AboutMe       string
+	// Avatar 头像的 URL
+	Avatar string
 }
 
 func (dao *GormUserDAO) Insert(ctx context.Context, u User) error {
@@ -98,3 +102,15 @@ func (dao *GormUserDAO) FindByWeChat(ctx context.Context, openId string) (User,
 	err := dao.db.WithContext(ctx).Where("wechat_open_id=?", openId).First(&u).Error
 	return u, err
 }
+
+func (dao *GormUserDAO) FindByNicknames(ctx context.Context, nicknames []string) ([]User, error) {
+	var res []User
+	err := dao.db.WithContext(ctx).Where("nickname IN ?", nicknames).Find(&res).Error
+	return res, err
+}
+
+func (dao *GormUserDAO) FindByIds(ctx context.Context, ids []int64) ([]User, error) {
+	var res []User
+	err := dao.db.WithContext(ctx).Where("id IN ?", ids).Find(&res).Error
+	return res, err
+}
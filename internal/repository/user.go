@@ -22,6 +22,10 @@ type UserRepository interface {
 	FindByPhone(c context.Context, phone string) (domain.User, error)
 	Update(ctx context.Context, idInt64 int64, user domain.User) error
 	FindByWeChat(ctx context.Context, id string) (domain.User, error)
+	// FindByNicknames 昵称可以重名，一个昵称可能对应好几个人
+	FindByNicknames(ctx context.Context, nicknames []string) ([]domain.User, error)
+	// FindByIds 找不到的不返回
+	FindByIds(ctx context.Context, ids []int64) ([]domain.User, error)
 }
 
 type CachedUserRepository struct {
@@ -80,6 +84,30 @@ func (u *CachedUserRepository) FindById(ctx context.Context, id int64) (domain.U
 	return du, nil
 }
 
+func (u *CachedUserRepository) FindByNicknames(ctx context.Context, nicknames []string) ([]domain.User, error) {
+	users, err := u.dao.FindByNicknames(ctx, nicknames)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]domain.User, 0, len(users))
+	for _, usr := range users {
+		res = append(res, u.toDomainUser(usr))
+	}
+	return res, nil
+}
+
+func (u *CachedUserRepository) FindByIds(ctx context.Context, ids []int64) ([]domain.User, error) {
+	users, err := u.dao.FindByIds(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]domain.User, 0, len(users))
+	for _, usr := range users {
+		res = append(res, u.toDomainUser(usr))
+	}
+	return res, nil
+}
+
 func (u *CachedUserRepository) toDomainUser(usr dao.User) domain.User {
 	return domain.User{
 		Id:       usr.Id,
@@ -89,6 +117,7 @@ func (u *CachedUserRepository) toDomainUser(usr dao.User) domain.User {
 		Phone:    usr.Phone.String,
 		Birthday: usr.Birthday,
 		AboutMe:  usr.AboutMe,
+		Avatar:   usr.Avatar,
 		WeChatInfo: domain.WeChatInfo{
 			OpenId:  usr.WechatOpenId.String,
 			UnionId: usr.WechatUnionId.String,
@@ -135,5 +164,6 @@ func (u *CachedUserRepository) ToEntity(usr domain.User) dao.User {
 		},
 		Birthday: usr.Birthday,
 		AboutMe:  usr.AboutMe,
+		Avatar:   usr.Avatar,
 	}
 }
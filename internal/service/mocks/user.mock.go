@@ -54,6 +54,36 @@ func (mr *MockUserServiceMockRecorder) Edit(c, idInt64, nickname, birthday, abou
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Edit", reflect.TypeOf((*MockUserService)(nil).Edit), c, idInt64, nickname, birthday, aboutMe)
 }
 
+// FindByIds mocks base method.
+func (m *MockUserService) FindByIds(ctx context.Context, ids []int64) ([]domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByIds", ctx, ids)
+	ret0, _ := ret[0].([]domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByIds indicates an expected call of FindByIds.
+func (mr *MockUserServiceMockRecorder) FindByIds(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIds", reflect.TypeOf((*MockUserService)(nil).FindByIds), ctx, ids)
+}
+
+// FindByNicknames mocks base method.
+func (m *MockUserService) FindByNicknames(ctx context.Context, nicknames []string) ([]domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByNicknames", ctx, nicknames)
+	ret0, _ := ret[0].([]domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByNicknames indicates an expected call of FindByNicknames.
+func (mr *MockUserServiceMockRecorder) FindByNicknames(ctx, nicknames any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByNicknames", reflect.TypeOf((*MockUserService)(nil).FindByNicknames), ctx, nicknames)
+}
+
 // FindOrCreate mocks base method.
 func (m *MockUserService) FindOrCreate(c *gin.Context, phone string) (domain.User, error) {
 	m.ctrl.T.Helper()
@@ -84,6 +114,21 @@ func (mr *MockUserServiceMockRecorder) FindOrCreateByWeChat(c, info any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOrCreateByWeChat", reflect.TypeOf((*MockUserService)(nil).FindOrCreateByWeChat), c, info)
 }
 
+// IsActiveUser mocks base method.
+func (m *MockUserService) IsActiveUser(ctx context.Context, uid int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsActiveUser", ctx, uid)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsActiveUser indicates an expected call of IsActiveUser.
+func (mr *MockUserServiceMockRecorder) IsActiveUser(ctx, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsActiveUser", reflect.TypeOf((*MockUserService)(nil).IsActiveUser), ctx, uid)
+}
+
 // Login mocks base method.
 func (m *MockUserService) Login(ctx context.Context, email, password string) (domain.User, error) {
 	m.ctrl.T.Helper()
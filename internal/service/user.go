@@ -25,6 +25,10 @@ type UserService interface {
 	FindOrCreate(c *gin.Context, phone string) (domain.User, error)
 	FindOrCreateByWeChat(c context.Context, info domain.WeChatInfo) (domain.User, error)
 	IsActiveUser(ctx context.Context, uid int64) (bool, error)
+	// FindByNicknames 昵称可以重名，一个昵称可能对应好几个人
+	FindByNicknames(ctx context.Context, nicknames []string) ([]domain.User, error)
+	// FindByIds 找不到的不返回
+	FindByIds(ctx context.Context, ids []int64) ([]domain.User, error)
 }
 
 type userService struct {
@@ -41,6 +45,20 @@ func (u *userService) IsActiveUser(ctx context.Context, uid int64) (bool, error)
 	return true, nil
 }
 
+func (u *userService) FindByNicknames(ctx context.Context, nicknames []string) ([]domain.User, error) {
+	if len(nicknames) == 0 {
+		return []domain.User{}, nil
+	}
+	return u.repo.FindByNicknames(ctx, nicknames)
+}
+
+func (u *userService) FindByIds(ctx context.Context, ids []int64) ([]domain.User, error) {
+	if len(ids) == 0 {
+		return []domain.User{}, nil
+	}
+	return u.repo.FindByIds(ctx, ids)
+}
+
 func (u *userService) SignUp(ctx context.Context, user domain.User) error {
 	encryptedPwd, err := bcrypt.GenerateFromPassword([]byte(user.Password), bcrypt.DefaultCost)
 	if err != nil {
@@ -13,6 +13,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"golang.org/x/sync/errgroup"
 	"net/http"
+	"sort"
 	"strconv"
 	"time"
 )
@@ -45,12 +46,15 @@ func (h *ArticleHandler) RegisterRoutes(server *gin.Engine) {
 	// 创作者接口
 	g.GET("/detail/:id", h.Detail)
 	g.POST("/list", h.List)
+	g.GET("/dashboard", h.Dashboard)
 
 	pub := g.Group("/pub")
 	pub.GET("/:id", h.PubDetail)
 	// 传入一个参数，true就是点赞，false就是取消点赞
 	pub.POST("/like", ginx.WrapBodyAndClaims(h.Like))
 	pub.POST("/collect", ginx.WrapBodyAndClaims(h.Collect))
+	pub.POST("/cancel_collect", ginx.WrapBodyAndClaims(h.CancelCollect))
+	pub.POST("/react", ginx.WrapBodyAndClaims(h.React))
 	pub.GET("/rank", h.RankArticle)
 	pub.POST("/reward", ginx.WrapBodyAndClaims[ArticleRewardRequest, jwt.UserClaim](h.Reward))
 }
@@ -254,11 +258,15 @@ func (h *ArticleHandler) PubDetail(ctx *gin.Context) {
 		AuthorId:   art.Author.Id,
 		AuthorName: art.Author.Name,
 
-		ReadCnt:    intr.ReadCnt,
-		LikeCnt:    intr.LikeCnt,
-		CollectCnt: intr.CollectCnt,
-		Liked:      intr.Liked,
-		Collected:  intr.Collected,
+		ReadCnt:       intr.ReadCnt,
+		UniqueReadCnt: intr.UniqueReadCnt,
+		LikeCnt:       intr.LikeCnt,
+		CollectCnt:    intr.CollectCnt,
+		CommentCnt:    intr.CommentCnt,
+		Liked:         intr.Liked,
+		Collected:     intr.Collected,
+		Reactions:     intr.Reactions,
+		MyReactions:   intr.MyReactions,
 
 		Status: art.Status.ToUint8(),
 		CTime:  art.CTime.Format(time.DateTime),
@@ -315,6 +323,53 @@ func (h *ArticleHandler) Collect(ctx *gin.Context, req ArticleCollectReq,
 	}, nil
 }
 
+func (h *ArticleHandler) CancelCollect(ctx *gin.Context, req ArticleCancelCollectReq,
+	uc jwt.UserClaim) (ginx.Result, error) {
+	_, err := h.interSvc.CancelCollect(ctx, &interv1.CancelCollectRequest{
+		Biz: "article",
+		Id:  req.Id,
+		Uid: uc.Uid,
+	})
+	if err != nil {
+		return ginx.Result{
+			Code: 5,
+			Msg:  "系统错误",
+		}, err
+	}
+	return ginx.Result{
+		Msg: "OK",
+	}, nil
+}
+
+func (h *ArticleHandler) React(ctx *gin.Context, req ArticleReactReq,
+	uc jwt.UserClaim) (ginx.Result, error) {
+	var err error
+	if req.React {
+		_, err = h.interSvc.ChangeReaction(ctx, &interv1.ChangeReactionRequest{
+			Biz:      "article",
+			BizId:    req.Id,
+			Uid:      uc.Uid,
+			Reaction: req.Reaction,
+		})
+	} else {
+		_, err = h.interSvc.RemoveReaction(ctx, &interv1.RemoveReactionRequest{
+			Biz:      "article",
+			BizId:    req.Id,
+			Uid:      uc.Uid,
+			Reaction: req.Reaction,
+		})
+	}
+	if err != nil {
+		return ginx.Result{
+			Code: 5,
+			Msg:  "系统错误",
+		}, err
+	}
+	return ginx.Result{
+		Msg: "OK",
+	}, nil
+}
+
 func (h *ArticleHandler) RankArticle(ctx *gin.Context) {
 	res, err := h.rankSvc.GetTopN(ctx)
 	if err != nil {
@@ -365,3 +420,96 @@ type ArticleRewardRequest struct {
 	id  int64 `json:"id"`
 	Amt int64 `json:"amt"`
 }
+
+// Dashboard 作者看自己所有文章最近几天的数据，今天的要明天才有
+func (h *ArticleHandler) Dashboard(ctx *gin.Context) {
+	const (
+		defaultDays = 7
+		maxDays     = 90
+		// 文章太多的作者只看最近的这么多篇
+		maxArticles = 1000
+		pageSize    = 100
+	)
+	days, err := strconv.Atoi(ctx.DefaultQuery("days", strconv.Itoa(defaultDays)))
+	if err != nil || days <= 0 || days > maxDays {
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: 4,
+			Msg:  "days 参数错误",
+		})
+		return
+	}
+	uc := ctx.MustGet("user").(jwt.UserClaim)
+	var arts []domain.Article
+	for offset := 0; offset < maxArticles; offset += pageSize {
+		page, err := h.svc.GetByAuthor(ctx.Request.Context(), uc.Uid, offset, pageSize)
+		if err != nil {
+			ctx.JSON(http.StatusOK, ginx.Result{
+				Code: 5,
+				Msg:  "系统错误",
+			})
+			h.l.Error("查找作者的文章失败", logger.Int64("uid", uc.Uid), logger.Error(err))
+			return
+		}
+		arts = append(arts, page...)
+		if len(page) < pageSize {
+			break
+		}
+	}
+
+	end := time.Now().AddDate(0, 0, -1)
+	start := end.AddDate(0, 0, -(days - 1))
+	// 互动服务一次最多查 pageSize 篇
+	var stats []*interv1.DailyStat
+	for i := 0; i < len(arts); i += pageSize {
+		page := arts[i:min(i+pageSize, len(arts))]
+		resp, err := h.interSvc.GetDailyStats(ctx.Request.Context(), &interv1.GetDailyStatsRequest{
+			Biz: "article",
+			BizIds: slice.Map(page, func(idx int, src domain.Article) int64 {
+				return src.Id
+			}),
+			Start: start.UnixMilli(),
+			End:   end.UnixMilli(),
+		})
+		if err != nil {
+			ctx.JSON(http.StatusOK, ginx.Result{
+				Code: 5,
+				Msg:  "系统错误",
+			})
+			h.l.Error("查询文章每天的数据失败", logger.Int64("uid", uc.Uid), logger.Error(err))
+			return
+		}
+		stats = append(stats, resp.GetStats()...)
+	}
+
+	dayVos := make([]DashboardDayVo, 0, days)
+	dayIdx := make(map[string]int, days)
+	for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+		day := d.Format(time.DateOnly)
+		dayIdx[day] = len(dayVos)
+		dayVos = append(dayVos, DashboardDayVo{Day: day})
+	}
+	artVos := make([]DashboardArticleVo, 0, len(arts))
+	artIdx := make(map[int64]int, len(arts))
+	for _, art := range arts {
+		artIdx[art.Id] = len(artVos)
+		artVos = append(artVos, DashboardArticleVo{Id: art.Id, Title: art.Title})
+	}
+	for _, st := range stats {
+		if i, ok := dayIdx[time.UnixMilli(st.GetDay()).Format(time.DateOnly)]; ok {
+			dayVos[i].add(st)
+		}
+		if i, ok := artIdx[st.GetBizId()]; ok {
+			artVos[i].add(st)
+		}
+	}
+	// 这段时间阅读最多的在前面
+	sort.SliceStable(artVos, func(i, j int) bool {
+		return artVos[i].ReadCnt > artVos[j].ReadCnt
+	})
+	ctx.JSON(http.StatusOK, ginx.Result{
+		Data: DashboardVo{
+			Days:     dayVos,
+			Articles: artVos,
+		},
+	})
+}
@@ -1,6 +1,8 @@
 // Copyright@daidai53 2023
 package web
 
+import interv1 "github.com/daidai53/webook/api/proto/gen/inter/v1"
+
 type ArticleVo struct {
 	Id         int64  `json:"id,omitempty"`
 	Title      string `json:"title,omitempty"`
@@ -12,11 +14,16 @@ type ArticleVo struct {
 	CTime      string `json:"ctime,omitempty"`
 	UTime      string `json:"utime,omitempty"`
 
-	ReadCnt    int64 `json:"readCnt"`
-	LikeCnt    int64 `json:"likeCnt"`
-	CollectCnt int64 `json:"collectCnt"`
-	Liked      bool  `json:"liked"`
-	Collected  bool  `json:"collected"`
+	ReadCnt       int64 `json:"readCnt"`
+	UniqueReadCnt int64 `json:"uniqueReadCnt"`
+	LikeCnt       int64 `json:"likeCnt"`
+	CollectCnt    int64 `json:"collectCnt"`
+	CommentCnt    int64 `json:"commentCnt"`
+	Liked         bool  `json:"liked"`
+	Collected     bool  `json:"collected"`
+	// Reactions 每种表情的数量
+	Reactions   map[string]int64 `json:"reactions"`
+	MyReactions []string         `json:"myReactions"`
 }
 
 type ArticleEditReq struct {
@@ -45,6 +52,50 @@ type ArticleCollectReq struct {
 	Cid int64 `json:"cid"`
 }
 
+type ArticleCancelCollectReq struct {
+	Id int64 `json:"id"`
+}
+
+// ArticleReactReq React 为 false 的时候是撤回这个表情
+type ArticleReactReq struct {
+	Id       int64  `json:"id"`
+	Reaction string `json:"reaction"`
+	React    bool   `json:"react"`
+}
+
 type TopReq struct {
 	N int `json:"n"`
 }
+
+// DashboardVo 作者看板
+type DashboardVo struct {
+	// Days 所有文章加起来每天的数据
+	Days []DashboardDayVo `json:"days"`
+	// Articles 每篇文章这段时间加起来的数据
+	Articles []DashboardArticleVo `json:"articles"`
+}
+
+type DashboardStatVo struct {
+	ReadCnt       int64 `json:"readCnt"`
+	UniqueReadCnt int64 `json:"uniqueReadCnt"`
+	LikeCnt       int64 `json:"likeCnt"`
+	CollectCnt    int64 `json:"collectCnt"`
+}
+
+func (d *DashboardStatVo) add(st *interv1.DailyStat) {
+	d.ReadCnt += st.GetReadCnt()
+	d.UniqueReadCnt += st.GetUniqueReadCnt()
+	d.LikeCnt += st.GetLikeCnt()
+	d.CollectCnt += st.GetCollectCnt()
+}
+
+type DashboardDayVo struct {
+	Day string `json:"day"`
+	DashboardStatVo
+}
+
+type DashboardArticleVo struct {
+	Id    int64  `json:"id"`
+	Title string `json:"title"`
+	DashboardStatVo
+}
@@ -0,0 +1,210 @@
+// Copyright@daidai53 2024
+package web
+
+import (
+	commentv1 "github.com/daidai53/webook/api/proto/gen/comment/v1"
+	"github.com/daidai53/webook/internal/domain"
+	"github.com/daidai53/webook/internal/service"
+	"github.com/daidai53/webook/internal/web/jwt"
+	"github.com/daidai53/webook/pkg/ginx"
+	"github.com/daidai53/webook/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"time"
+)
+
+// maxCommentPageSize 一页最多这么多条评论
+const maxCommentPageSize = 50
+
+type CommentHandler struct {
+	client  commentv1.CommentServiceClient
+	userSvc service.UserService
+	l       logger.LoggerV1
+}
+
+func NewCommentHandler(client commentv1.CommentServiceClient, userSvc service.UserService,
+	l logger.LoggerV1) *CommentHandler {
+	return &CommentHandler{
+		client:  client,
+		userSvc: userSvc,
+		l:       l,
+	}
+}
+
+func (h *CommentHandler) RegisterRoutes(server *gin.Engine) {
+	g := server.Group("/comments")
+	g.POST("/list", ginx.WrapBody(h.List))
+	g.POST("/create", ginx.WrapBodyAndClaims(h.Create))
+	g.POST("/reply", ginx.WrapBodyAndClaims(h.Reply))
+	g.POST("/delete", ginx.WrapBodyAndClaims(h.Delete))
+	g.POST("/replies", ginx.WrapBody(h.Replies))
+}
+
+func (h *CommentHandler) List(ctx *gin.Context, req CommentListReq) (ginx.Result, error) {
+	resp, err := h.client.GetCommentList(ctx, &commentv1.CommentListRequest{
+		Biz:    req.Biz,
+		Bizid:  req.BizId,
+		Sort:   commentv1.CommentSort(req.Sort),
+		Cursor: req.Cursor,
+		Limit:  h.pageSize(req.Limit),
+	})
+	if err != nil {
+		return h.errResult(err)
+	}
+	return ginx.Result{
+		Data: CommentListVo{
+			Comments:   h.toVos(ctx, resp.GetComments()),
+			NextCursor: resp.GetNextCursor(),
+		},
+	}, nil
+}
+
+func (h *CommentHandler) Create(ctx *gin.Context, req CommentCreateReq, uc jwt.UserClaim) (ginx.Result, error) {
+	return h.create(ctx, &commentv1.Comment{
+		Uid:     uc.Uid,
+		Biz:     req.Biz,
+		Bizid:   req.BizId,
+		Content: req.Content,
+	})
+}
+
+func (h *CommentHandler) Reply(ctx *gin.Context, req CommentReplyReq, uc jwt.UserClaim) (ginx.Result, error) {
+	if req.ParentId <= 0 {
+		return ginx.Result{
+			Code: 4,
+			Msg:  "回复的评论不对",
+		}, nil
+	}
+	return h.create(ctx, &commentv1.Comment{
+		Uid:     uc.Uid,
+		Biz:     req.Biz,
+		Bizid:   req.BizId,
+		Content: req.Content,
+		ParentComment: &commentv1.Comment{
+			Id: req.ParentId,
+		},
+	})
+}
+
+func (h *CommentHandler) create(ctx *gin.Context, cmt *commentv1.Comment) (ginx.Result, error) {
+	if cmt.GetBiz() == "" || cmt.GetBizid() <= 0 {
+		return ginx.Result{
+			Code: 4,
+			Msg:  "评论的资源不对",
+		}, nil
+	}
+	resp, err := h.client.CreateComment(ctx, &commentv1.CreateCommentRequest{
+		Comment: cmt,
+	})
+	if err != nil {
+		return h.errResult(err)
+	}
+	return ginx.Result{
+		Data: CommentCreateVo{
+			Id:      resp.GetId(),
+			Pending: resp.GetStatus() == commentv1.CommentStatus_CommentStatusPending,
+		},
+	}, nil
+}
+
+func (h *CommentHandler) Delete(ctx *gin.Context, req CommentDeleteReq, uc jwt.UserClaim) (ginx.Result, error) {
+	_, err := h.client.DeleteComment(ctx, &commentv1.DeleteCommentRequest{
+		Id:  req.Id,
+		Uid: uc.Uid,
+	})
+	if err != nil {
+		return h.errResult(err)
+	}
+	return ginx.Result{
+		Msg: "OK",
+	}, nil
+}
+
+func (h *CommentHandler) Replies(ctx *gin.Context, req CommentRepliesReq) (ginx.Result, error) {
+	resp, err := h.client.GetMoreReplies(ctx, &commentv1.GetMoreRepliesRequest{
+		Rid:   req.Rid,
+		MaxId: req.MaxId,
+		Limit: h.pageSize(req.Limit),
+	})
+	if err != nil {
+		return h.errResult(err)
+	}
+	return ginx.Result{
+		Data: h.toVos(ctx, resp.GetReplies()),
+	}, nil
+}
+
+// errResult 评论服务按照 gRPC 错误码区分业务错误，业务错误把原因告诉用户，其它的都是系统错误
+func (h *CommentHandler) errResult(err error) (ginx.Result, error) {
+	st, _ := status.FromError(err)
+	switch st.Code() {
+	case codes.InvalidArgument, codes.PermissionDenied,
+		codes.FailedPrecondition, codes.NotFound:
+		return ginx.Result{
+			Code: 4,
+			Msg:  st.Message(),
+		}, nil
+	default:
+		return ginx.Result{
+			Code: 5,
+			Msg:  "系统错误",
+		}, err
+	}
+}
+
+func (h *CommentHandler) pageSize(limit int64) int64 {
+	if limit <= 0 || limit > maxCommentPageSize {
+		return maxCommentPageSize
+	}
+	return limit
+}
+
+// toVos 带上评论的人的昵称和头像，查不到的时候只返回 id
+func (h *CommentHandler) toVos(ctx *gin.Context, comments []*commentv1.Comment) []CommentVo {
+	uids := make([]int64, 0, len(comments))
+	for _, cmt := range comments {
+		uids = append(uids, cmt.GetUid())
+	}
+	users := make(map[int64]domain.User, len(uids))
+	us, err := h.userSvc.FindByIds(ctx, uids)
+	if err != nil {
+		h.l.Error("查询评论的人失败", logger.Error(err))
+	}
+	for _, u := range us {
+		users[u.Id] = u
+	}
+	res := make([]CommentVo, 0, len(comments))
+	for _, cmt := range comments {
+		u := users[cmt.GetUid()]
+		vo := CommentVo{
+			Id: cmt.GetId(),
+			Author: CommentAuthorVo{
+				Id:       cmt.GetUid(),
+				Nickname: u.Nickname,
+				Avatar:   u.Avatar,
+			},
+			Content:  cmt.GetContent(),
+			ParentId: cmt.GetParentComment().GetId(),
+			RootId:   cmt.GetRootComment().GetId(),
+			LikeCnt:  cmt.GetLikeCnt(),
+			ReplyCnt: cmt.GetReplyCnt(),
+			Edited:   cmt.GetEdited(),
+			Deleted:  cmt.GetDeleted(),
+			Pinned:   cmt.GetPinned(),
+			IsAuthor: cmt.GetIsAuthor(),
+			CTime:    cmt.GetCtime().AsTime().Local().Format(time.DateTime),
+			UTime:    cmt.GetUtime().AsTime().Local().Format(time.DateTime),
+		}
+		for _, mention := range cmt.GetMentions() {
+			vo.Mentions = append(vo.Mentions, CommentMentionVo{
+				Uid:      mention.GetUid(),
+				Nickname: mention.GetNickname(),
+				Start:    mention.GetStart(),
+				Length:   mention.GetLength(),
+			})
+		}
+		res = append(res, vo)
+	}
+	return res
+}
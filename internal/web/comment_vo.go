@@ -0,0 +1,81 @@
+// Copyright@daidai53 2024
+package web
+
+type CommentVo struct {
+	Id      int64           `json:"id"`
+	Author  CommentAuthorVo `json:"author"`
+	Content string          `json:"content"`
+	// ParentId 和 RootId 是 0 表示根评论
+	ParentId int64 `json:"parentId,omitempty"`
+	RootId   int64 `json:"rootId,omitempty"`
+	LikeCnt  int64 `json:"likeCnt"`
+	ReplyCnt int64 `json:"replyCnt"`
+	Edited   bool  `json:"edited"`
+	Deleted  bool  `json:"deleted"`
+	Pinned   bool  `json:"pinned"`
+	// IsAuthor 评论的人是文章的作者
+	IsAuthor bool               `json:"isAuthor"`
+	Mentions []CommentMentionVo `json:"mentions,omitempty"`
+	CTime    string             `json:"ctime"`
+	UTime    string             `json:"utime"`
+}
+
+type CommentAuthorVo struct {
+	Id       int64  `json:"id"`
+	Nickname string `json:"nickname"`
+	Avatar   string `json:"avatar"`
+}
+
+// CommentMentionVo Start 和 Length 按照字符算
+type CommentMentionVo struct {
+	Uid      int64  `json:"uid"`
+	Nickname string `json:"nickname"`
+	Start    int32  `json:"start"`
+	Length   int32  `json:"length"`
+}
+
+type CommentListVo struct {
+	Comments []CommentVo `json:"comments"`
+	// NextCursor 翻下一页的时候带上，空的就是没有更多了
+	NextCursor string `json:"nextCursor"`
+}
+
+type CommentCreateVo struct {
+	Id int64 `json:"id"`
+	// Pending 要等人工审核，审核通过之前别人看不到
+	Pending bool `json:"pending"`
+}
+
+// CommentListReq Sort 0 是最新，1 是最早，2 是最热
+type CommentListReq struct {
+	Biz    string `json:"biz"`
+	BizId  int64  `json:"bizId"`
+	Sort   int32  `json:"sort"`
+	Cursor string `json:"cursor"`
+	Limit  int64  `json:"limit"`
+}
+
+type CommentCreateReq struct {
+	Biz     string `json:"biz"`
+	BizId   int64  `json:"bizId"`
+	Content string `json:"content"`
+}
+
+type CommentReplyReq struct {
+	Biz   string `json:"biz"`
+	BizId int64  `json:"bizId"`
+	// ParentId 回复的评论
+	ParentId int64  `json:"parentId"`
+	Content  string `json:"content"`
+}
+
+type CommentDeleteReq struct {
+	Id int64 `json:"id"`
+}
+
+// CommentRepliesReq MaxId 是上一页最后一条回复的 id
+type CommentRepliesReq struct {
+	Rid   int64 `json:"rid"`
+	MaxId int64 `json:"maxId"`
+	Limit int64 `json:"limit"`
+}
@@ -3,6 +3,7 @@ package ioc
 
 import (
 	"github.com/daidai53/webook/config"
+	feeddao "github.com/daidai53/webook/feed/repository/dao"
 	dao2 "github.com/daidai53/webook/interactive/repository/dao"
 	"github.com/daidai53/webook/internal/repository/dao"
 	"github.com/daidai53/webook/pkg/gormx"
@@ -71,6 +72,11 @@ func InitDB(l logger.LoggerV1) *gorm.DB {
 	if err != nil {
 		panic(err)
 	}
+	// feed 的清理任务跑在这里，用的是这个库
+	err = feeddao.InitTables(db)
+	if err != nil {
+		panic(err)
+	}
 	return db
 }
 
@@ -38,7 +38,8 @@ func InitInterClient(client *etcdv3.Client) interv1.InteractiveServiceClient {
 	return interv1.NewInteractiveServiceClient(cc)
 }
 
-//func InitInterClientOld(svc service.InteractiveService) interv1.InteractiveServiceClient {
+//func InitInterClientOld(svc service.InteractiveService, colSvc service.CollectionService,
+//	reactSvc service.ReactionService, statsSvc service.StatsService) interv1.InteractiveServiceClient {
 //	type Config struct {
 //		Addr      string `yaml:"addr"`
 //		Secure    bool   `yaml:"secure"`
@@ -58,7 +59,7 @@ func InitInterClient(client *etcdv3.Client) interv1.InteractiveServiceClient {
 //		panic(err)
 //	}
 //	remote := interv1.NewInteractiveServiceClient(cc)
-//	local := client.NewLocalInteractiveServiceAdaptor(svc)
+//	local := client.NewLocalInteractiveServiceAdaptor(svc, colSvc, reactSvc, statsSvc)
 //	res := client.NewInteractiveClient(remote, local)
 //	res.UpdateThreshold(cfg.Threshold)
 //	viper.OnConfigChange(func(in fsnotify.Event) {
@@ -2,6 +2,7 @@
 package ioc
 
 import (
+	fjob "github.com/daidai53/webook/feed/job"
 	"github.com/daidai53/webook/internal/job"
 	"github.com/daidai53/webook/internal/service"
 	"github.com/daidai53/webook/pkg/logger"
@@ -15,7 +16,7 @@ func InitRankingJob(svc service.RankingService, l logger.LoggerV1, client *rlock
 	return job.NewRankingJob(svc, time.Second*30, l, client)
 }
 
-func InitJobs(l logger.LoggerV1, rJob *job.RankingJob) *cron.Cron {
+func InitJobs(l logger.LoggerV1, rJob *job.RankingJob, retentionJob *fjob.RetentionJob) *cron.Cron {
 	builder := job.NewCronJobBuilder(l, prometheus.SummaryOpts{
 		Namespace: "daidai53",
 		Subsystem: "webook",
@@ -33,5 +34,10 @@ func InitJobs(l logger.LoggerV1, rJob *job.RankingJob) *cron.Cron {
 	if err != nil {
 		panic(err)
 	}
+	// feed 的收件箱、发件箱清理，凌晨读少，放在凌晨
+	_, err = expr.AddJob("0 0 3 * * *", builder.Build(retentionJob))
+	if err != nil {
+		panic(err)
+	}
 	return expr
 }
@@ -0,0 +1,39 @@
+// Copyright@daidai53 2024
+package ioc
+
+import (
+	rewardv1 "github.com/daidai53/webook/api/proto/gen/reward/v1"
+	"github.com/spf13/viper"
+	etcdv3 "go.etcd.io/etcd/client/v3"
+	resolver2 "go.etcd.io/etcd/client/v3/naming/resolver"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func InitRewardClient(client *etcdv3.Client) rewardv1.RewardServiceClient {
+	type Config struct {
+		Addr   string `yaml:"addr"`
+		Secure bool   `yaml:"secure"`
+	}
+	var cfg Config
+	err := viper.UnmarshalKey("grpc.client.reward", &cfg)
+	if err != nil {
+		panic(err)
+	}
+
+	resolver, err := resolver2.NewBuilder(client)
+	if err != nil {
+		panic(err)
+	}
+	opts := []grpc.DialOption{
+		grpc.WithResolvers(resolver),
+	}
+	if !cfg.Secure {
+		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	}
+	cc, err := grpc.Dial(cfg.Addr, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return rewardv1.NewRewardServiceClient(cc)
+}
@@ -20,12 +20,14 @@ import (
 	"time"
 )
 
-func InitWebServer(mdlw []gin.HandlerFunc, handlers *web.UserHandler, artHandler *web.ArticleHandler, wechatHdl *web.OAuth2WechatHandler) *gin.Engine {
+func InitWebServer(mdlw []gin.HandlerFunc, handlers *web.UserHandler, artHandler *web.ArticleHandler,
+	wechatHdl *web.OAuth2WechatHandler, commentHdl *web.CommentHandler) *gin.Engine {
 	server := gin.Default()
 	server.Use(mdlw...)
 	handlers.RegisterRoutes(server)
 	wechatHdl.ResiterRoutes(server)
 	artHandler.RegisterRoutes(server)
+	commentHdl.RegisterRoutes(server)
 	return server
 }
 
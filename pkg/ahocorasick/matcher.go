@@ -0,0 +1,143 @@
+// Copyright@daidai53 2024
+package ahocorasick
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// Matcher Aho-Corasick 自动机，一次扫描找出文本里面所有的词。
+// 不区分大小写，建好之后是只读的，可以并发使用
+type Matcher struct {
+	nodes []node
+	words []string
+	// lens 每个词有多少个字符
+	lens []int
+}
+
+type node struct {
+	children map[rune]int
+	fail     int
+	// outputs 走到这里的时候匹配上了哪些词，包括 fail 链上的
+	outputs []int
+}
+
+// Match 匹配上的词，Start 和 End 是在原文里面的字节位置，左闭右开
+type Match struct {
+	Word  string
+	Start int
+	End   int
+}
+
+func New(words []string) *Matcher {
+	m := &Matcher{
+		nodes: []node{{children: map[rune]int{}}},
+	}
+	for _, word := range words {
+		m.add(word)
+	}
+	m.build()
+	return m
+}
+
+func (m *Matcher) add(word string) {
+	cur, cnt := 0, 0
+	for _, r := range word {
+		r = unicode.ToLower(r)
+		next, ok := m.nodes[cur].children[r]
+		if !ok {
+			next = len(m.nodes)
+			m.nodes = append(m.nodes, node{children: map[rune]int{}})
+			m.nodes[cur].children[r] = next
+		}
+		cur = next
+		cnt++
+	}
+	// 空字符串不算
+	if cnt == 0 {
+		return
+	}
+	m.nodes[cur].outputs = append(m.nodes[cur].outputs, len(m.words))
+	m.words = append(m.words, word)
+	m.lens = append(m.lens, cnt)
+}
+
+// build 按层计算 fail 指针
+func (m *Matcher) build() {
+	queue := make([]int, 0, len(m.nodes))
+	for _, child := range m.nodes[0].children {
+		queue = append(queue, child)
+	}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		for r, child := range m.nodes[cur].children {
+			fail := m.nodes[cur].fail
+			for fail != 0 {
+				if _, ok := m.nodes[fail].children[r]; ok {
+					break
+				}
+				fail = m.nodes[fail].fail
+			}
+			if next, ok := m.nodes[fail].children[r]; ok && next != child {
+				m.nodes[child].fail = next
+			}
+			m.nodes[child].outputs = append(m.nodes[child].outputs, m.nodes[m.nodes[child].fail].outputs...)
+			queue = append(queue, child)
+		}
+	}
+}
+
+// FindAll 按照结束的位置从前往后返回所有匹配上的词，会有重叠的
+func (m *Matcher) FindAll(text string) []Match {
+	var res []Match
+	m.Scan(text, func(match Match) bool {
+		res = append(res, match)
+		return true
+	})
+	return res
+}
+
+// Contains 有没有任何一个词出现在文本里面
+func (m *Matcher) Contains(text string) bool {
+	found := false
+	m.Scan(text, func(match Match) bool {
+		found = true
+		return false
+	})
+	return found
+}
+
+// Scan 按照在文本里面结束的位置依次回调匹配上的词，fn 返回 false 就不往下找了
+func (m *Matcher) Scan(text string, fn func(match Match) bool) {
+	// starts 每个字符在原文里面的字节位置
+	starts := make([]int, 0, len(text))
+	cur := 0
+	for pos, r := range text {
+		starts = append(starts, pos)
+		r = unicode.ToLower(r)
+		for cur != 0 {
+			if _, ok := m.nodes[cur].children[r]; ok {
+				break
+			}
+			cur = m.nodes[cur].fail
+		}
+		if next, ok := m.nodes[cur].children[r]; ok {
+			cur = next
+		}
+		if len(m.nodes[cur].outputs) == 0 {
+			continue
+		}
+		_, size := utf8.DecodeRuneInString(text[pos:])
+		for _, idx := range m.nodes[cur].outputs {
+			match := Match{
+				Word:  m.words[idx],
+				Start: starts[len(starts)-m.lens[idx]],
+				End:   pos + size,
+			}
+			if !fn(match) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright@daidai53 2024
+package ahocorasick
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestMatcher_FindAll(t *testing.T) {
+	testCases := []struct {
+		name  string
+		words []string
+		text  string
+		want  []Match
+	}{
+		{
+			name:  "没有匹配",
+			words: []string{"he", "she"},
+			text:  "abc",
+		},
+		{
+			name:  "重叠的词",
+			words: []string{"he", "she", "his", "hers"},
+			text:  "ushers",
+			want: []Match{
+				{Word: "she", Start: 1, End: 4},
+				{Word: "he", Start: 2, End: 4},
+				{Word: "hers", Start: 2, End: 6},
+			},
+		},
+		{
+			name:  "中文",
+			words: []string{"敏感词", "感"},
+			text:  "这是敏感词吗",
+			want: []Match{
+				{Word: "感", Start: 9, End: 12},
+				{Word: "敏感词", Start: 6, End: 15},
+			},
+		},
+		{
+			name:  "不区分大小写",
+			words: []string{"spam"},
+			text:  "SpAm!",
+			want: []Match{
+				{Word: "spam", Start: 0, End: 4},
+			},
+		},
+		{
+			name:  "空的词不算",
+			words: []string{""},
+			text:  "abc",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			m := New(tc.words)
+			assert.Equal(t, tc.want, m.FindAll(tc.text))
+			assert.Equal(t, len(tc.want) > 0, m.Contains(tc.text))
+		})
+	}
+}
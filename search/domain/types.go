@@ -15,6 +15,15 @@ type Article struct {
 	Status  int32
 }
 
+// Comment 只索引能被看到的评论
+type Comment struct {
+	Id      int64
+	Uid     int64
+	Biz     string
+	BizId   int64
+	Content string
+}
+
 type SearchResult struct {
 	Users    []User
 	Articles []Article
@@ -0,0 +1,70 @@
+// Copyright@daidai53 2024
+package events
+
+import (
+	"context"
+	"github.com/IBM/sarama"
+	"github.com/daidai53/webook/pkg/logger"
+	"github.com/daidai53/webook/pkg/saramax"
+	"github.com/daidai53/webook/search/domain"
+	"github.com/daidai53/webook/search/service"
+	"time"
+)
+
+// CommentConsumer 评论服务发出来的评论事件，能被看到的评论才建索引
+type CommentConsumer struct {
+	syncSvc service.SyncService
+	client  sarama.Client
+	l       logger.LoggerV1
+}
+
+// CommentEvent 评论服务的 comment_events，只取搜索用得到的字段
+type CommentEvent struct {
+	Type    string `json:"type"`
+	Cid     int64  `json:"cid"`
+	Uid     int64  `json:"uid"`
+	Biz     string `json:"biz"`
+	BizId   int64  `json:"biz_id"`
+	Content string `json:"content"`
+	Visible bool   `json:"visible"`
+}
+
+func NewCommentConsumer(syncSvc service.SyncService, client sarama.Client, l logger.LoggerV1) *CommentConsumer {
+	return &CommentConsumer{
+		syncSvc: syncSvc,
+		client:  client,
+		l:       l,
+	}
+}
+
+func (c *CommentConsumer) Start() error {
+	cg, err := sarama.NewConsumerGroupFromClient("search_sync_comment", c.client)
+	if err != nil {
+		return err
+	}
+	go func() {
+		err := cg.Consume(context.Background(),
+			[]string{"comment_events"},
+			saramax.NewHandler[CommentEvent](c.l, c.Consume))
+		if err != nil {
+			c.l.Error("退出消费循环异常", logger.Error(err))
+		}
+	}()
+	return err
+}
+
+// Consume 事件可能会重复，建索引和删索引都是幂等的
+func (c *CommentConsumer) Consume(msg *sarama.ConsumerMessage, evt CommentEvent) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if !evt.Visible {
+		return c.syncSvc.DeleteComment(ctx, evt.Cid)
+	}
+	return c.syncSvc.SyncComment(ctx, domain.Comment{
+		Id:      evt.Cid,
+		Uid:     evt.Uid,
+		Biz:     evt.Biz,
+		BizId:   evt.BizId,
+		Content: evt.Content,
+	})
+}
@@ -0,0 +1,32 @@
+// Copyright@daidai53 2024
+package repository
+
+import (
+	"context"
+	"github.com/daidai53/webook/search/domain"
+	"github.com/daidai53/webook/search/repository/dao"
+)
+
+type commentRepository struct {
+	dao dao.CommentSearchDAO
+}
+
+func NewCommentRepository(dao dao.CommentSearchDAO) CommentRepository {
+	return &commentRepository{
+		dao: dao,
+	}
+}
+
+func (c *commentRepository) SyncComment(ctx context.Context, cmt domain.Comment) error {
+	return c.dao.InputComment(ctx, dao.Comment{
+		Id:      cmt.Id,
+		Uid:     cmt.Uid,
+		Biz:     cmt.Biz,
+		BizId:   cmt.BizId,
+		Content: cmt.Content,
+	})
+}
+
+func (c *commentRepository) DeleteComment(ctx context.Context, id int64) error {
+	return c.dao.DeleteComment(ctx, id)
+}
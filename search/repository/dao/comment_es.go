@@ -0,0 +1,36 @@
+// Copyright@daidai53 2024
+package dao
+
+import (
+	"context"
+	"github.com/olivere/elastic/v7"
+	"strconv"
+)
+
+const commentIndexName = "comment_idx"
+
+type CommentElasticDAO struct {
+	client *elastic.Client
+}
+
+func NewCommentElasticDAO(client *elastic.Client) CommentSearchDAO {
+	return &CommentElasticDAO{
+		client: client,
+	}
+}
+
+func (c *CommentElasticDAO) InputComment(ctx context.Context, cmt Comment) error {
+	_, err := c.client.Index().Index(commentIndexName).
+		Id(strconv.FormatInt(cmt.Id, 10)).
+		BodyJson(cmt).Do(ctx)
+	return err
+}
+
+func (c *CommentElasticDAO) DeleteComment(ctx context.Context, id int64) error {
+	_, err := c.client.Delete().Index(commentIndexName).
+		Id(strconv.FormatInt(id, 10)).Do(ctx)
+	if elastic.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
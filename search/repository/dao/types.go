@@ -13,6 +13,12 @@ type ArticleSearchDAO interface {
 	Search(ctx context.Context, tagArgIds []int64, colIds []int64, likeIds []int64, keywords []string) ([]Article, error)
 }
 
+type CommentSearchDAO interface {
+	InputComment(ctx context.Context, cmt Comment) error
+	// DeleteComment 本来就没有索引也算成功
+	DeleteComment(ctx context.Context, id int64) error
+}
+
 type TagSearchDAO interface {
 	SearchBizIds(ctx context.Context, uid int64, biz string, keywords []string) ([]int64, error)
 }
@@ -39,3 +45,11 @@ type Article struct {
 	Content string
 	Status  int32
 }
+
+type Comment struct {
+	Id      int64  `json:"id"`
+	Uid     int64  `json:"uid"`
+	Biz     string `json:"biz"`
+	BizId   int64  `json:"biz_id"`
+	Content string `json:"content"`
+}
@@ -16,6 +16,11 @@ type ArticleRepository interface {
 	SearchArticle(ctx context.Context, uid int64, keywords []string) ([]domain.Article, error)
 }
 
+type CommentRepository interface {
+	SyncComment(ctx context.Context, cmt domain.Comment) error
+	DeleteComment(ctx context.Context, id int64) error
+}
+
 type AnyRepository interface {
 	Input(ctx context.Context, index, docId, data string) error
 }
@@ -11,12 +11,21 @@ type syncService struct {
 	userRepo repository.UserRepository
 	artiRepo repository.ArticleRepository
 	anyRepo  repository.AnyRepository
+	cmtRepo  repository.CommentRepository
 }
 
 func (s *syncService) SyncAny(ctx context.Context, index, docId, data string) error {
 	return s.anyRepo.Input(ctx, index, docId, data)
 }
 
+func (s *syncService) SyncComment(ctx context.Context, cmt domain.Comment) error {
+	return s.cmtRepo.SyncComment(ctx, cmt)
+}
+
+func (s *syncService) DeleteComment(ctx context.Context, id int64) error {
+	return s.cmtRepo.DeleteComment(ctx, id)
+}
+
 func (s *syncService) SyncUser(ctx context.Context, user domain.User) error {
 	return s.userRepo.SyncUser(ctx, user)
 }
@@ -10,6 +10,9 @@ type SyncService interface {
 	SyncUser(ctx context.Context, user domain.User) error
 	SyncArticle(ctx context.Context, arti domain.Article) error
 	SyncAny(ctx context.Context, index, docId, data string) error
+	SyncComment(ctx context.Context, cmt domain.Comment) error
+	// DeleteComment 评论删掉了或者要重新审核，不能再被搜到
+	DeleteComment(ctx context.Context, id int64) error
 }
 
 type SearchService interface {
@@ -7,6 +7,7 @@ import (
 	repository3 "github.com/daidai53/webook/code/repository"
 	cache3 "github.com/daidai53/webook/code/repository/cache"
 	service3 "github.com/daidai53/webook/code/service"
+	feedioc "github.com/daidai53/webook/feed/ioc"
 	"github.com/daidai53/webook/internal/events/article"
 	"github.com/daidai53/webook/internal/repository"
 	"github.com/daidai53/webook/internal/repository/cache"
@@ -42,8 +43,12 @@ func InitWebServer() *app.App {
 		ioc.InitRlockClient,
 		ioc.InitJobs,
 		ioc.InitRankingJob,
+		feedioc.InitRetentionService,
+		feedioc.InitRetentionJob,
 		ioc.InitInterClient,
 		ioc.InitCodeClient,
+		ioc.InitCommentClient,
+		ioc.InitRewardClient,
 
 		article.NewSaramaSyncProducer,
 		ioc.InitConsumers,
@@ -70,6 +75,7 @@ func InitWebServer() *app.App {
 		web.NewUserHandler,
 		web.NewOAuth2WechatHandler,
 		web.NewArticleHandler,
+		web.NewCommentHandler,
 		ijwt.NewRedisJWTHandler,
 		ioc.InitWebServer,
 		ioc.InitGinMiddlewares,
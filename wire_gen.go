@@ -10,6 +10,7 @@ import (
 	repository2 "github.com/daidai53/webook/code/repository"
 	cache2 "github.com/daidai53/webook/code/repository/cache"
 	service2 "github.com/daidai53/webook/code/service"
+	ioc2 "github.com/daidai53/webook/feed/ioc"
 	"github.com/daidai53/webook/internal/events/article"
 	"github.com/daidai53/webook/internal/repository"
 	"github.com/daidai53/webook/internal/repository/cache"
@@ -56,20 +57,25 @@ func InitWebServer() *app.App {
 	rankingCache := cache.NewRankingRedisCache(cmdable)
 	rankingRepository := repository.NewCachedRankingRepository(rankingCache)
 	rankingService := service.NewBatchRankingService(interactiveServiceClient, articleService, rankingRepository)
-	articleHandler := web.NewArticleHandler(loggerV1, articleService, interactiveServiceClient, rankingService)
+	rewardServiceClient := ioc.InitRewardClient(clientv3Client)
+	articleHandler := web.NewArticleHandler(loggerV1, articleService, interactiveServiceClient, rankingService, rewardServiceClient)
 	wechatService := ioc.InitWechatService(loggerV1)
 	oAuth2WechatHandler := web.NewOAuth2WechatHandler(wechatService, userService, handler)
-	engine := ioc.InitWebServer(v, userHandler, articleHandler, oAuth2WechatHandler)
+	commentServiceClient := ioc.InitCommentClient(clientv3Client)
+	commentHandler := web.NewCommentHandler(commentServiceClient, userService, loggerV1)
+	engine := ioc.InitWebServer(v, userHandler, articleHandler, oAuth2WechatHandler, commentHandler)
 	v2 := ioc.InitConsumers()
 	rlockClient := ioc.InitRlockClient(cmdable)
 	rankingJob := ioc.InitRankingJob(rankingService, loggerV1, rlockClient)
-	cron := ioc.InitJobs(loggerV1, rankingJob)
-	app := &app.App{
+	retentionService := ioc2.InitRetentionService(db)
+	retentionJob := ioc2.InitRetentionJob(retentionService, loggerV1, rlockClient)
+	cron := ioc.InitJobs(loggerV1, rankingJob, retentionJob)
+	appApp := &app.App{
 		Server:    engine,
 		Consumers: v2,
 		Cron:      cron,
 	}
-	return app
+	return appApp
 }
 
 // wire.go: